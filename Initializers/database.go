@@ -3,24 +3,20 @@ package initializers
 import (
 	"fmt"
 	"log"
-	"os"
 
-	"github.com/Zenithive/it-crm-backend/models"
-	"github.com/joho/godotenv"
+	"github.com/Zenithive/it-crm-backend/internal/migrate"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-func ConnectToDatabase() {
-	if os.Getenv("RENDER") == "" { // Render automatically sets	 this env variable
-		err := godotenv.Load()
-		if err != nil {
-			log.Println("No .env file found, using system environment variables")
-		}
+// ConnectToDatabase opens DB at dsn. It does not change the schema; see MigrateDatabase.
+func ConnectToDatabase(dsn string) {
+	if DB != nil {
+		return
 	}
-	dsn := os.Getenv("DB_URL")
 	var err error
 	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
@@ -29,36 +25,17 @@ func ConnectToDatabase() {
 	if DB == nil {
 		fmt.Println("DB is nil")
 	}
-	DB.Exec(`CREATE TYPE resource_type AS ENUM ('CONSULTANT', 'FREELANCER', 'CONTRACTOR', 'EMPLOYEE');`)
-	DB.Exec(`CREATE TYPE resource_status AS ENUM ('ACTIVE', 'INACTIVE', 'ON_BENCH');`)
-	DB.Exec(`CREATE TYPE vendor_status AS ENUM ('ACTIVE', 'INACTIVE', 'PREFERRED');`)
-	DB.Exec(`CREATE TYPE payment_terms AS ENUM ('NET_30', 'NET_60', 'NET_90');`)
-	DB.Exec(`CREATE TYPE task_status AS ENUM ('TODO', 'IN_PROGRESS', 'COMPLETED', 'ON_HOLD');`)
-	DB.Exec(`CREATE TYPE task_priority AS ENUM ('LOW', 'MEDIUM', 'HIGH', 'URGENT');`)
-	DB.Exec(`CREATE TYPE skill_type AS ENUM ('FRONTEND', 'BACKEND', 'DESIGN', 'OTHER');`)
+}
 
-	// DB.AutoMigrate(&models.Activity{})
-	err = DB.AutoMigrate(
-		&models.User{},
-		&models.Campaign{},
-		&models.Organization{},
-		&models.Lead{},
-		&models.Activity{},
-		&models.Deal{},
-		&models.ResourceProfile{},   // New Model
-		&models.Vendor{},            // New Model
-		&models.Skill{},             // Supporting model
-		&models.PastProject{},       // Supporting model
-		&models.Contact{},           // Supporting model
-		&models.PerformanceRating{}, // Supporting model
-		&models.Task{},              // Supporting model
-		&models.ResourceSkill{},     // Supporting model
-		&models.LeadStageHistory{},  // Supporting model
-		&models.Document{},
-		&models.RefreshToken{},
-		&models.UserDemo{},
-	)
-	if err != nil {
+// MigrateDatabase applies the pending schema migrations (see internal/migrate),
+// then fills in data the application expects.
+func MigrateDatabase() {
+	if _, err := migrate.Up(DB, 0); err != nil {
 		log.Fatalf("Failed to migrate database schema: %v", err)
 	}
+	convertLegacyMoneyColumns()
+
+	if err := scoring.SeedDefaultRules(DB); err != nil {
+		log.Printf("Error seeding scoring rules: %v", err)
+	}
 }
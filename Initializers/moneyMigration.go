@@ -0,0 +1,71 @@
+package initializers
+
+import (
+	"log"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// legacyMoneyColumns lists the free-text amount columns that were replaced by
+// embedded models.Money fields. Upgrading a database of the release before
+// versioned migrations renames them to <column>_legacy and adds the new ones
+// (internal/migrate/upgrade.sql). prefix is the embeddedPrefix of the new columns.
+var legacyMoneyColumns = []struct {
+	table  string
+	column string
+	prefix string
+}{
+	{table: "deals", column: "deal_amount", prefix: "deal_amount_"},
+	{table: "organizations", column: "annual_revenue", prefix: "annual_revenue_"},
+}
+
+// convertLegacyMoneyColumns fills the new money columns from the renamed text columns.
+// Rows that were never converted still have an empty currency. Blank amounts become 0
+// in the base currency; values that cannot be parsed are logged by id and left for review.
+func convertLegacyMoneyColumns() {
+	base := models.BaseCurrency()
+	for _, legacy := range legacyMoneyColumns {
+		legacyColumn := legacy.column + "_legacy"
+		if !DB.Migrator().HasColumn(legacy.table, legacyColumn) {
+			continue
+		}
+
+		var rows []struct {
+			ID    uuid.UUID
+			Value *string
+		}
+		if err := DB.Table(legacy.table).
+			Select("id, " + legacyColumn + " AS value").
+			Where(legacy.prefix + "currency = ''").
+			Scan(&rows).Error; err != nil {
+			log.Fatalf("Failed to read legacy values from %s.%s: %v", legacy.table, legacyColumn, err)
+		}
+
+		converted, failed := 0, 0
+		for _, row := range rows {
+			money := models.Money{Currency: base}
+			if row.Value != nil && *row.Value != "" {
+				parsed, err := models.ParseMoney(*row.Value, base)
+				if err != nil {
+					log.Printf("Could not convert %s %s %s=%q: %v", legacy.table, row.ID, legacy.column, *row.Value, err)
+					failed++
+					continue
+				}
+				money = parsed
+			}
+			if err := DB.Table(legacy.table).Where("id = ?", row.ID).Updates(map[string]interface{}{
+				legacy.prefix + "amount":   money.Amount,
+				legacy.prefix + "currency": money.Currency,
+			}).Error; err != nil {
+				log.Printf("Could not update %s %s: %v", legacy.table, row.ID, err)
+				failed++
+				continue
+			}
+			converted++
+		}
+		if len(rows) > 0 {
+			log.Printf("Converted %d %s.%s values to money, %d failed", converted, legacy.table, legacy.column, failed)
+		}
+	}
+}
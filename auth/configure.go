@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"gorm.io/gorm"
+)
+
+// settings holds the configuration given to Configure.
+var settings config.Auth
+
+// database holds the sessions, role permissions and Google users, see Configure.
+var database *gorm.DB
+
+// errNotConfigured is returned for tokens signed or checked before Configure,
+// rather than using an empty key, and for permissions changed without a database.
+var errNotConfigured = errors.New("auth is not configured")
+
+// Configure sets the token keys and lifetimes and the Google sign-in from cfg,
+// and stores sessions, role permissions and Google users in db. The server
+// calls it once at startup, before handling requests. Without a database, as in
+// tests, every role holds its DefaultRolePermissions.
+func Configure(cfg config.Auth, db *gorm.DB) {
+	settings = cfg
+	database = db
+	initGoogleStore(cfg)
+}
@@ -8,10 +8,11 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateJWT(user *models.User, authProvider string, expiryHours int, key []byte) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(expiryHours) * time.Hour).Unix()
-
-	fmt.Println("Refresh Key (generate):", key)
+func GenerateJWT(user *models.User, authProvider string, expiry time.Duration, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", errNotConfigured
+	}
+	expirationTime := time.Now().Add(expiry).Unix()
 
 	fmt.Println("username:", user.Name)
 	fmt.Println("role:", user.Role)
@@ -28,10 +29,11 @@ func GenerateJWT(user *models.User, authProvider string, expiryHours int, key []
 	fmt.Println("Token Signing Method:", token.Method)
 	return token.SignedString(key)
 }
-func GenerateJWTDemo(user *models.UserDemo, authProvider string, expiryHours int, key []byte) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(expiryHours) * time.Hour).Unix()
-
-	fmt.Println("Refresh Key (generate):", key)
+func GenerateJWTDemo(user *models.UserDemo, authProvider string, expiry time.Duration, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", errNotConfigured
+	}
+	expirationTime := time.Now().Add(expiry).Unix()
 
 	fmt.Println("username:", user.Name)
 	fmt.Println("role:", user.Role)
@@ -6,10 +6,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"os"
 	"time"
 
-	initializers "github.com/Zenithive/it-crm-backend/Initializers"
+	"github.com/Zenithive/it-crm-backend/internal/config"
 	"github.com/Zenithive/it-crm-backend/models"
 	"github.com/google/uuid"
 	"github.com/gorilla/sessions"
@@ -27,22 +26,24 @@ type GoogleResponse struct {
 	Picture string `json:"picture"`
 }
 
-func InitGoogleStore() {
+func initGoogleStore(cfg config.Auth) {
 	var store *sessions.CookieStore
-	key := "your-session-key" // Replace with a secure key in production
-	store = sessions.NewCookieStore([]byte(key))
+	store = sessions.NewCookieStore([]byte(cfg.SessionKey))
 	store.MaxAge(86400) // 1 day
 
 	// Set up Gothic
 	gothic.Store = store
 
+	if cfg.GoogleClientID == "" {
+		log.Println("GOOGLE_CLIENT_ID is not set, Google sign-in is disabled")
+		return
+	}
 	// Initialize goth with Google provider
 	goth.UseProviders(
 		google.New(
-			os.Getenv("GOOGLE_CLIENT_ID"),
-			os.Getenv("GOOGLE_CLIENT_SECRET"),
-			// "http://localhost:8080/auth/google/callback",
-			"http://localhost:8080/auth/google/callback",
+			cfg.GoogleClientID,
+			cfg.GoogleClientSecret,
+			cfg.OAuthCallbackURL,
 			"https://www.googleapis.com/auth/calendar.events.readonly",
 			"email", "profile",
 		),
@@ -120,7 +121,7 @@ func OauthCallbackHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Check if user exists by email
 	var user models.UserDemo
-	result := initializers.DB.Where("email = ?", gothUser.Email).First(&user)
+	result := database.Where("email = ?", gothUser.Email).First(&user)
 
 	if result.Error == gorm.ErrRecordNotFound {
 		// Create new user
@@ -137,7 +138,7 @@ func OauthCallbackHandler(w http.ResponseWriter, r *http.Request) {
 			Role:                "SALES_EXECUTIVE", // Default role
 		}
 
-		result = initializers.DB.Create(&user)
+		result = database.Create(&user)
 		if result.Error != nil {
 			http.Error(w, "Failed to create user", http.StatusInternalServerError)
 			return
@@ -147,18 +148,14 @@ func OauthCallbackHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	log.Println("User :", &user)
-	_, refreshToken, err := GenerateTokensDemo(&user, "Google")
+	_, refreshToken, err := GenerateTokensDemo(&user, "Google", r.UserAgent())
 	if err != nil {
 		http.Error(w, "Failed to generate tokens", http.StatusInternalServerError)
 		return
 	}
 
-	// Store refresh token in DB
-	user.BackendRefreshToken = refreshToken
-	initializers.DB.Save(&user)
-
 	// Redirect with tokens or return JSON
 	// For example, redirect to frontend with tokens as query parameters
-	http.Redirect(w, r, fmt.Sprintf("http://localhost:8080/oauth-success?access_token=%s&refresh_token=%s&user_id=%s&auth_provider=%s",
-		gothUser.AccessToken, refreshToken, user.ID.String(), user.Provider), http.StatusTemporaryRedirect)
+	http.Redirect(w, r, fmt.Sprintf("%s?access_token=%s&refresh_token=%s&user_id=%s&auth_provider=%s",
+		settings.OAuthSuccessURL, gothUser.AccessToken, refreshToken, user.ID.String(), user.Provider), http.StatusTemporaryRedirect)
 }
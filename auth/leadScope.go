@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Lead actions accepted by LeadScope.
+const (
+	LeadActionRead   = "read"
+	LeadActionWrite  = "write"
+	LeadActionDelete = "delete"
+)
+
+// LeadScope restricts a query on leads to the rows the user in ctx may perform action on:
+//   - lead:<action>           every lead (admins)
+//   - lead:<action>:campaign  leads of campaigns the user is a member of (managers)
+//   - lead:<action>:team      leads created by or assigned to anyone in the user's team
+//   - lead:<action>:own       leads the user created or is assigned to (sales executives)
+//
+// Broader scopes include the narrower ones. Every resolver that reads or changes leads
+// goes through this scope, so a lead that is not visible behaves exactly like one that
+// does not exist.
+func LeadScope(ctx context.Context, action string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		permission := "lead:" + action
+		if HasPermission(ctx, permission) {
+			return db
+		}
+
+		userID, err := CurrentUserID(ctx)
+		if err != nil || !HasPermission(ctx, permission+":"+PermissionScopeOwn) {
+			return db.Where("1 = 0")
+		}
+
+		condition := "leads.lead_created_by = @user OR leads.lead_assigned_to = @user"
+		if HasPermission(ctx, permission+":"+PermissionScopeTeam) {
+			condition += " OR leads.lead_created_by IN (" + teamUsersSQL + ") OR leads.lead_assigned_to IN (" + teamUsersSQL + ")"
+		}
+		if HasPermission(ctx, permission+":"+PermissionScopeCampaign) {
+			condition += " OR leads.campaign_id IN (SELECT campaign_id FROM campaign_users WHERE user_id = @user)"
+		}
+		return db.Where("("+condition+")", map[string]interface{}{"user": userID})
+	}
+}
@@ -3,22 +3,38 @@ package auth
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"strconv"
+	"slices"
 	"strings"
 
-	initializers "github.com/Zenithive/it-crm-backend/Initializers"
-	"github.com/Zenithive/it-crm-backend/models"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
 )
 
+// publicMutations are the mutations allowed without an access token: signing
+// in, and those that take a refresh token instead.
+var publicMutations = []string{"login", "refreshToken", "logout"}
+
 func Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
+		// Subscriptions have no body; they authenticate in WebsocketInit unless the
+		// client sent its token on the upgrade request
+		if IsWebsocketUpgrade(r) {
+			if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+				claims, err := ValidateJWT(strings.TrimPrefix(authHeader, "Bearer "), []byte(settings.JWTSecret))
+				if err != nil {
+					http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
+					return
+				}
+				r = r.WithContext(context.WithValue(r.Context(), UserCtxKey, claims))
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		// Read request body to extract GraphQL operation name
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -28,7 +44,8 @@ func Middleware(next http.Handler) http.Handler {
 		r.Body = io.NopCloser(strings.NewReader(string(body)))
 
 		var graphqlReq struct {
-			Query string `json:"query"`
+			Query         string `json:"query"`
+			OperationName string `json:"operationName"`
 		}
 		err = json.Unmarshal(body, &graphqlReq)
 		if err != nil {
@@ -36,9 +53,14 @@ func Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Allow login mutation without a token
-		if strings.Contains(graphqlReq.Query, "login") {
-			fmt.Println("Login mutation detected, skipping auth check.")
+		// Allow the mutations that authenticate by themselves without a token,
+		// still identifying the caller if a valid one was sent
+		if isPublicOperation(graphqlReq.Query, graphqlReq.OperationName) {
+			if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+				if claims, err := ValidateJWT(strings.TrimPrefix(authHeader, "Bearer "), []byte(settings.JWTSecret)); err == nil {
+					r = r.WithContext(context.WithValue(r.Context(), UserCtxKey, claims))
+				}
+			}
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -51,159 +73,17 @@ func Middleware(next http.Handler) http.Handler {
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		claims, err := ValidateJWT(tokenString, []byte(SecretKey))
-
+		claims, err := ValidateJWT(tokenString, []byte(settings.JWTSecret))
 		if err != nil {
 			fmt.Println("error validating token:", err)
-
-			var validationErr *jwt.ValidationError
-			if errors.As(err, &validationErr) {
-				fmt.Printf("ValidationError detected: %v\n", validationErr.Errors)
-
-				if validationErr.Errors&jwt.ValidationErrorExpired != 0 {
-					fmt.Println("Access token expired. Attempting refresh...")
-
-					// Parse token without validation to extract claims
-					parsedToken, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-					if err != nil {
-						http.Error(w, "Unauthorized: Unable to parse token", http.StatusUnauthorized)
-						return
-
-					}
-
-					// Extract user ID
-					claims, ok := parsedToken.Claims.(jwt.MapClaims)
-					if !ok {
-						http.Error(w, "Unauthorized: Invalid token claims", http.StatusUnauthorized)
-						return
-					}
-
-					userID, ok := claims["user_id"].(string)
-					if !ok {
-						http.Error(w, "Unauthorized: Invalid user ID in token", http.StatusUnauthorized)
-						return
-					}
-
-					// Fetch refresh token from DB
-					var refreshRecord models.RefreshToken
-					result := initializers.DB.Where("user_id = ?", userID).First(&refreshRecord)
-					if result.Error != nil {
-						http.Error(w, "Unauthorized: No valid refresh token found", http.StatusUnauthorized)
-						return
-					}
-					fmt.Println("Refresh token found:", refreshRecord.Token)
-
-					// Validate refresh token
-					newClaims, err := ValidateJWT(refreshRecord.Token, []byte(RefreshSecretKey))
-					if err != nil {
-						fmt.Println("Refresh Token Validation Error:", err) // Log error
-						http.Error(w, "Unauthorized: Invalid refresh token", http.StatusUnauthorized)
-						return
-					}
-
-					// Retrieve user info
-					var user models.User
-					result = initializers.DB.Where("id = ?", userID).First(&user)
-					if result.Error != nil {
-						http.Error(w, "Unauthorized: User not found", http.StatusUnauthorized)
-						return
-					}
-					fmt.Println("User found:", user.Name)
-					fmt.Println("User Role:", user.Role)
-					// Generate a new access token
-					accessExpiry, _ := strconv.Atoi(os.Getenv("JWT_EXPIRY_TIME"))
-					newAccessToken, err := GenerateJWT(&user, newClaims["auth_provider"].(string), accessExpiry, []byte(SecretKey))
-					if err != nil {
-						http.Error(w, "Failed to generate new access token", http.StatusInternalServerError)
-						return
-					}
-
-					// Attach the new access token in the response header
-					w.Header().Set("New-Access-Token", newAccessToken)
-					fmt.Println("New access token issued:", newAccessToken)
-
-					fmt.Println("Final Claims Before Storing in Context with expired access:", newClaims)
-					// Continue request processing with updated claims
-					ctx := context.WithValue(r.Context(), UserCtxKey, newClaims)
-					next.ServeHTTP(w, r.WithContext(ctx))
-					return
-				}
-			} else if strings.Contains(err.Error(), "Token is expired") {
-				// Fallback: Check error message manually if wrapped
-				fmt.Println("Access token expired (detected via string match). Attempting refresh...")
-
-				// Parse token without validation to extract claims
-				parsedToken, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-				if err != nil {
-					http.Error(w, "Unauthorized: Unable to parse token", http.StatusUnauthorized)
-					return
-
-				}
-
-				// Extract user ID
-				claims, ok := parsedToken.Claims.(jwt.MapClaims)
-				if !ok {
-					http.Error(w, "Unauthorized: Invalid token claims", http.StatusUnauthorized)
-					return
-				}
-
-				userID, ok := claims["user_id"].(string)
-				if !ok {
-					http.Error(w, "Unauthorized: Invalid user ID in token", http.StatusUnauthorized)
-					return
-				}
-
-				// Fetch refresh token from DB
-				var refreshRecord models.RefreshToken
-				result := initializers.DB.Where("user_id = ?", userID).First(&refreshRecord)
-				if result.Error != nil {
-					http.Error(w, "Unauthorized: No valid refresh token found", http.StatusUnauthorized)
-					return
-				}
-				fmt.Println("Refresh token found:", refreshRecord.Token)
-
-				// Validate refresh token
-				newClaims, err := ValidateRefreshToken(refreshRecord.Token)
-				if err != nil {
-					fmt.Println("Refresh Token Validation Error:", err) // Log error
-					http.Error(w, "Unauthorized: Invalid refresh token", http.StatusUnauthorized)
-					return
-				}
-
-				// Retrieve user info
-				var user models.User
-				result = initializers.DB.Where("id = ?", userID).First(&user)
-				if result.Error != nil {
-					http.Error(w, "Unauthorized: User not found", http.StatusUnauthorized)
-					return
-				}
-				fmt.Println("User found:", user.Name)
-				fmt.Println("User Role:", user.Role)
-				// Generate a new access token
-				accessExpiry, _ := strconv.Atoi(os.Getenv("JWT_EXPIRY_TIME"))
-				newAccessToken, err := GenerateJWT(&user, newClaims["auth_provider"].(string), accessExpiry, []byte(SecretKey))
-				if err != nil {
-					http.Error(w, "Failed to generate new access token", http.StatusInternalServerError)
-					return
-				}
-
-				// Attach the new access token in the response header
-				w.Header().Set("New-Access-Token", newAccessToken)
-				fmt.Println("New access token issued:", newAccessToken)
-
-				fmt.Println("Final Claims Before Storing in Context with expired access:", newClaims)
-				// Continue request processing with updated claims
-				ctx := context.WithValue(r.Context(), UserCtxKey, newClaims)
-				next.ServeHTTP(w, r.WithContext(ctx))
-				return
-			} else {
-				fmt.Println("Unexpected error:", err)
-				http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
+			// Clients renew expired access tokens with the refreshToken mutation
+			if strings.Contains(err.Error(), "expired") {
+				http.Error(w, "Unauthorized: Token expired", http.StatusUnauthorized)
 				return
 			}
-
+			http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
+			return
 		}
-		fmt.Println("Final Claims Before Storing in Context with accesstoken:", claims)
 
 		// If token is valid, continue processing the request
 		ctx := context.WithValue(r.Context(), UserCtxKey, claims)
@@ -211,6 +91,34 @@ func Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// isPublicOperation reports whether the operation of query that runs, the one
+// named operationName or else the only one, is a mutation whose fields are all
+// publicMutations. Queries that do not parse are not public.
+func isPublicOperation(query, operationName string) bool {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return false
+	}
+	var operation *ast.OperationDefinition
+	switch {
+	case operationName != "":
+		operation = doc.Operations.ForName(operationName)
+	case len(doc.Operations) == 1:
+		operation = doc.Operations[0]
+	}
+	if operation == nil || operation.Operation != ast.Mutation || len(operation.SelectionSet) == 0 {
+		return false
+	}
+	for _, selection := range operation.SelectionSet {
+		// Fragments could hide other fields, so only plain fields count
+		field, ok := selection.(*ast.Field)
+		if !ok || !slices.Contains(publicMutations, field.Name) {
+			return false
+		}
+	}
+	return true
+}
+
 func MiddlewareFuncForUploads(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token := r.Header.Get("Authorization")
@@ -220,7 +128,7 @@ func MiddlewareFuncForUploads(next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		tokenString := strings.TrimPrefix(token, "Bearer ")
-		claims, err := ValidateJWT(tokenString, []byte(SecretKey))
+		claims, err := ValidateJWT(tokenString, []byte(settings.JWTSecret))
 		if err != nil {
 			http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
 			return
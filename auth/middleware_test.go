@@ -0,0 +1,33 @@
+package auth
+
+import "testing"
+
+func TestIsPublicOperation(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		want          bool
+	}{
+		{"login", `mutation { login(email: "a@b.c", password: "x") { accessToken } }`, "", true},
+		{"aliased refresh", `mutation Renew { renewed: refreshToken(refreshToken: "t") { accessToken } }`, "", true},
+		{"several public fields", `mutation { logout(refreshToken: "t") login(email: "a@b.c", password: "x") { accessToken } }`, "", true},
+		{"named operation", `query Me { me { id } } mutation SignIn { login(email: "a@b.c", password: "x") { accessToken } }`, "SignIn", true},
+		{"other named operation", `query Me { me { id } } mutation SignIn { login(email: "a@b.c", password: "x") { accessToken } }`, "Me", false},
+		{"several operations without a name", `query Me { me { id } } mutation SignIn { login(email: "a@b.c", password: "x") { accessToken } }`, "", false},
+		{"name in an argument", `mutation { deleteLead(leadID: "login") { id } }`, "", false},
+		{"name in a comment", "# login\nmutation { deleteLead(leadID: \"1\") { id } }", "", false},
+		{"public field next to another", `mutation { login(email: "a@b.c", password: "x") { accessToken } deleteLead(leadID: "1") { id } }`, "", false},
+		{"query with the same field name", `query { login }`, "", false},
+		{"fragment spread", `mutation { ...M } fragment M on Mutation { deleteLead(leadID: "1") { id } }`, "", false},
+		{"inline fragment", `mutation { ... on Mutation { login(email: "a@b.c", password: "x") { accessToken } } }`, "", false},
+		{"unparsable", `mutation { login(`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPublicOperation(tt.query, tt.operationName); got != tt.want {
+				t.Errorf("isPublicOperation(%q, %q) = %v, want %v", tt.query, tt.operationName, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// Permissions have the form "<resource>:<action>" or "<resource>:<action>:<scope>".
+// A permission without a scope covers every scope of the same action, so a role
+// holding "lead:write" also satisfies a check for "lead:write:own".
+const (
+	PermissionScopeOwn      = "own"
+	PermissionScopeTeam     = "team"
+	PermissionScopeCampaign = "campaign"
+
+	PermissionManage = "permission:manage"
+)
+
+// permissionScopes orders scopes from narrowest to broadest. A broader scope
+// satisfies checks for the narrower ones, e.g. "lead:read:campaign" passes a
+// "lead:read:own" check (campaign members still see their own and their team's leads).
+var permissionScopes = []string{PermissionScopeOwn, PermissionScopeTeam, PermissionScopeCampaign}
+
+// Permissions lists every permission the API checks. Grants outside this list are rejected.
+var Permissions = []string{
+	"user:read", "user:write", "user:delete",
+	"campaign:read", "campaign:write", "campaign:delete",
+	"lead:read", "lead:read:campaign", "lead:read:team", "lead:read:own",
+	"lead:write", "lead:write:campaign", "lead:write:team", "lead:write:own",
+	"lead:delete", "lead:delete:campaign", "lead:delete:team", "lead:delete:own",
+	"lead:assign", "lead:assign:team", "lead:assign:own",
+	"team:read", "team:write", "team:delete",
+	"assignment:manage",
+	"scoring:manage",
+	"import:run",
+	"export:run",
+	"webhook:manage",
+	"audit:read",
+	"trash:read", "trash:restore", "trash:purge",
+	"organization:read", "organization:write", "organization:delete",
+	"deal:read", "deal:write", "deal:delete",
+	"activity:read", "activity:write", "activity:delete",
+	"resource:read", "resource:write", "resource:delete",
+	"vendor:read", "vendor:write", "vendor:delete",
+	"task:read", "task:write", "task:delete",
+	"casestudy:read", "casestudy:write", "casestudy:delete",
+	"skill:read", "skill:write", "skill:delete",
+	"analytics:read",
+	"exchangerate:write",
+	PermissionManage,
+}
+
+// DefaultRolePermissions is what the role_permissions table is seeded with.
+// It mirrors the role checks the resolvers used to hard-code.
+var DefaultRolePermissions = map[string][]string{
+	"ADMIN": Permissions,
+	"MANAGER": {
+		"user:read", "user:write", "user:delete",
+		"campaign:read", "campaign:write", "campaign:delete",
+		"lead:read:campaign", "lead:write:campaign", "lead:delete:campaign",
+		"lead:assign:team",
+		"team:read", "team:write",
+		"assignment:manage",
+		"import:run",
+		"export:run",
+		"trash:read", "trash:restore",
+		"organization:read", "organization:write", "organization:delete",
+		"deal:read", "deal:write", "deal:delete",
+		"activity:read", "activity:write", "activity:delete",
+		"resource:read", "resource:write", "resource:delete",
+		"vendor:read", "vendor:write", "vendor:delete",
+		"task:read", "task:write", "task:delete",
+		"casestudy:read", "casestudy:write", "casestudy:delete",
+		"skill:read", "skill:write", "skill:delete",
+		"analytics:read",
+	},
+	"SALES_EXECUTIVE": {
+		"campaign:read",
+		"lead:read:own", "lead:write:own", "lead:delete:own",
+		"lead:assign:own",
+		"team:read",
+		"import:run",
+		"export:run",
+		"organization:read", "organization:write",
+		"deal:read", "deal:write",
+		"activity:read", "activity:write",
+		"resource:read",
+		"vendor:read",
+		"task:read", "task:write",
+		"casestudy:read",
+		"skill:read",
+	},
+}
+
+// Roles lists the roles permissions can be granted to.
+var Roles = []string{"ADMIN", "MANAGER", "SALES_EXECUTIVE"}
+
+// permissionCacheTTL bounds how long a grant made on another instance takes to apply here.
+const permissionCacheTTL = time.Minute
+
+var permissionCache = struct {
+	sync.RWMutex
+	roles    map[string]map[string]bool
+	loadedAt time.Time
+}{}
+
+// IsKnownPermission reports whether permission is in the Permissions catalogue.
+func IsKnownPermission(permission string) bool {
+	for _, known := range Permissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsKnownRole reports whether role is one of Roles.
+func IsKnownRole(role string) bool {
+	for _, known := range Roles {
+		if known == role {
+			return true
+		}
+	}
+	return false
+}
+
+// loadRolePermissions reads the role-to-permission mapping, seeding the defaults on first use.
+func loadRolePermissions() (map[string]map[string]bool, error) {
+	if database == nil {
+		return defaultRoles(), nil
+	}
+	permissionCache.RLock()
+	if permissionCache.roles != nil && time.Since(permissionCache.loadedAt) < permissionCacheTTL {
+		roles := permissionCache.roles
+		permissionCache.RUnlock()
+		return roles, nil
+	}
+	permissionCache.RUnlock()
+
+	permissionCache.Lock()
+	defer permissionCache.Unlock()
+
+	var count int64
+	if err := database.Model(&models.RolePermission{}).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		var rows []models.RolePermission
+		for role, permissions := range DefaultRolePermissions {
+			for _, permission := range permissions {
+				rows = append(rows, models.RolePermission{ID: uuid.New(), Role: role, Permission: permission})
+			}
+		}
+		if err := database.Create(&rows).Error; err != nil {
+			return nil, err
+		}
+		log.Printf("Seeded %d default role permissions", len(rows))
+	}
+
+	var rows []models.RolePermission
+	if err := database.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	roles := make(map[string]map[string]bool)
+	for _, row := range rows {
+		if roles[row.Role] == nil {
+			roles[row.Role] = make(map[string]bool)
+		}
+		roles[row.Role][row.Permission] = true
+	}
+	permissionCache.roles = roles
+	permissionCache.loadedAt = time.Now()
+	return roles, nil
+}
+
+// defaultRoles returns DefaultRolePermissions as a role-to-permission mapping.
+func defaultRoles() map[string]map[string]bool {
+	roles := make(map[string]map[string]bool, len(DefaultRolePermissions))
+	for role, permissions := range DefaultRolePermissions {
+		roles[role] = make(map[string]bool, len(permissions))
+		for _, permission := range permissions {
+			roles[role][permission] = true
+		}
+	}
+	return roles
+}
+
+// InvalidatePermissionCache forces the next check to re-read the role_permissions table.
+func InvalidatePermissionCache() {
+	permissionCache.Lock()
+	permissionCache.roles = nil
+	permissionCache.Unlock()
+}
+
+// RoleHasPermission reports whether role holds permission, either exactly, through
+// a broader scope of it, or through the same permission without a scope.
+func RoleHasPermission(role string, permission string) (bool, error) {
+	roles, err := loadRolePermissions()
+	if err != nil {
+		return false, err
+	}
+	granted := roles[role]
+	if granted[permission] {
+		return true, nil
+	}
+	parts := strings.Split(permission, ":")
+	if len(parts) != 3 {
+		return false, nil
+	}
+	base := parts[0] + ":" + parts[1]
+	if granted[base] {
+		return true, nil
+	}
+	broader := false
+	for _, scope := range permissionScopes {
+		if scope == parts[2] {
+			broader = true
+			continue
+		}
+		if broader && granted[base+":"+scope] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasPermission reports whether the user in ctx holds permission.
+func HasPermission(ctx context.Context, permission string) bool {
+	role, err := GetUserRoleFromJWT(ctx)
+	if err != nil {
+		return false
+	}
+	ok, err := RoleHasPermission(role, permission)
+	if err != nil {
+		log.Printf("Error checking permission %s for role %s: %v", permission, role, err)
+		return false
+	}
+	return ok
+}
+
+// RequirePermission returns an error unless the user in ctx holds permission.
+func RequirePermission(ctx context.Context, permission string) error {
+	if _, err := GetUserRoleFromJWT(ctx); err != nil {
+		return apperr.Unauthenticated("unauthorized")
+	}
+	if !HasPermission(ctx, permission) {
+		return apperr.Forbidden("unauthorized: missing permission %s", permission)
+	}
+	return nil
+}
+
+// HasPermissionDirective implements the @hasPermission schema directive.
+func HasPermissionDirective(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (interface{}, error) {
+	if err := RequirePermission(ctx, permission); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// GetRolePermissions returns the sorted permissions granted to each role.
+func GetRolePermissions() (map[string][]string, error) {
+	roles, err := loadRolePermissions()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string, len(Roles))
+	for _, role := range Roles {
+		permissions := []string{}
+		for permission := range roles[role] {
+			permissions = append(permissions, permission)
+		}
+		sort.Strings(permissions)
+		result[role] = permissions
+	}
+	return result, nil
+}
+
+// GrantPermission adds permission to role. Granting an existing permission is a no-op.
+// ctx identifies who made the change in the audit log.
+func GrantPermission(ctx context.Context, role string, permission string) error {
+	if !IsKnownRole(role) {
+		return apperr.InvalidField("role", "unknown role %s", role)
+	}
+	if !IsKnownPermission(permission) {
+		return apperr.InvalidField("permission", "unknown permission %s", permission)
+	}
+	if database == nil {
+		return errNotConfigured
+	}
+	// Make sure the defaults are seeded before the first custom grant
+	if _, err := loadRolePermissions(); err != nil {
+		return err
+	}
+	var existing models.RolePermission
+	if err := database.Where("role = ? AND permission = ?", role, permission).Limit(1).Find(&existing).Error; err != nil {
+		return err
+	}
+	if existing.ID == uuid.Nil {
+		if err := database.WithContext(ctx).Create(&models.RolePermission{ID: uuid.New(), Role: role, Permission: permission}).Error; err != nil {
+			return err
+		}
+	}
+	InvalidatePermissionCache()
+	return nil
+}
+
+// RevokePermission removes permission from role.
+// Admins cannot lose permission:manage, otherwise nobody could restore the mapping.
+func RevokePermission(ctx context.Context, role string, permission string) error {
+	if !IsKnownRole(role) {
+		return apperr.InvalidField("role", "unknown role %s", role)
+	}
+	if role == "ADMIN" && permission == PermissionManage {
+		return apperr.Invalid("cannot revoke %s from ADMIN", PermissionManage)
+	}
+	if database == nil {
+		return errNotConfigured
+	}
+	if _, err := loadRolePermissions(); err != nil {
+		return err
+	}
+	if err := database.WithContext(ctx).Unscoped().Where("role = ? AND permission = ?", role, permission).Delete(&models.RolePermission{}).Error; err != nil {
+		return err
+	}
+	InvalidatePermissionCache()
+	return nil
+}
+
+// ResetRolePermissions restores role to its DefaultRolePermissions.
+func ResetRolePermissions(ctx context.Context, role string) error {
+	if !IsKnownRole(role) {
+		return apperr.InvalidField("role", "unknown role %s", role)
+	}
+	if database == nil {
+		return errNotConfigured
+	}
+	if _, err := loadRolePermissions(); err != nil {
+		return err
+	}
+	tx := database.WithContext(ctx).Begin()
+	if err := tx.Unscoped().Where("role = ?", role).Delete(&models.RolePermission{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	var rows []models.RolePermission
+	for _, permission := range DefaultRolePermissions[role] {
+		rows = append(rows, models.RolePermission{ID: uuid.New(), Role: role, Permission: permission})
+	}
+	if len(rows) > 0 {
+		if err := tx.Create(&rows).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	InvalidatePermissionCache()
+	return nil
+}
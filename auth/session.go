@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Refresh tokens rotate: each works once, and refreshing returns the next token
+// of its family, the tokens descending from one sign-in on one device. Only
+// their hashes are stored. A token presented again after it was used has been
+// copied, so its whole family is revoked and whoever holds it must sign in again.
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, the session has been revoked")
+)
+
+// startSession issues the first refresh token of a new family.
+func startSession(userID uuid.UUID, authProvider, device string) (string, error) {
+	// Tokens past their expiry are of no use, not even to detect reuse
+	if err := database.Where("user_id = ? AND expires_at < ?", userID, time.Now()).Delete(&models.RefreshToken{}).Error; err != nil {
+		return "", fmt.Errorf("failed to remove expired refresh tokens: %w", err)
+	}
+	return issueRefreshToken(database, models.RefreshToken{
+		UserID:       userID,
+		FamilyID:     uuid.New(),
+		Device:       device,
+		AuthProvider: authProvider,
+	})
+}
+
+// issueRefreshToken signs the next refresh token of the family of session and stores its hash.
+func issueRefreshToken(db *gorm.DB, session models.RefreshToken) (string, error) {
+	if settings.RefreshSecret == "" {
+		return "", errNotConfigured
+	}
+	now := time.Now()
+	record := models.RefreshToken{
+		ID:           uuid.New(),
+		UserID:       session.UserID,
+		FamilyID:     session.FamilyID,
+		Device:       session.Device,
+		AuthProvider: session.AuthProvider,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(time.Duration(settings.RefreshTokenExpiry)),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":       record.UserID.String(),
+		"auth_provider": record.AuthProvider,
+		"sid":           record.FamilyID.String(),
+		"jti":           record.ID.String(),
+		"exp":           record.ExpiresAt.Unix(),
+	}).SignedString([]byte(settings.RefreshSecret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign refresh token: %w", err)
+	}
+	record.TokenHash = hashToken(token)
+	if err := db.Create(&record).Error; err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// RefreshSession exchanges a refresh token for a new access token and the next
+// refresh token of its family, and returns them with their user.
+func RefreshSession(token string) (*models.User, string, string, error) {
+	if _, err := ValidateJWT(token, []byte(settings.RefreshSecret)); err != nil {
+		if errors.Is(err, errNotConfigured) {
+			return nil, "", "", err
+		}
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+
+	var user models.User
+	var accessToken, refreshToken string
+	var reused *models.RefreshToken
+	err := database.Transaction(func(tx *gorm.DB) error {
+		// Locked, so of two requests racing with the same token one sees it used
+		var current models.RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", hashToken(token)).First(&current).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if current.RevokedAt != nil || now.After(current.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+		if current.UsedAt != nil {
+			// Committed, unlike the rest of a failed refresh
+			reused = &current
+			return revokeFamily(tx, current.FamilyID)
+		}
+
+		if err := tx.Model(&current).Update("used_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", current.UserID).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+		if refreshToken, err = issueRefreshToken(tx, current); err != nil {
+			return err
+		}
+		accessToken, err = GenerateJWT(&user, current.AuthProvider, time.Duration(settings.AccessTokenExpiry), []byte(settings.JWTSecret))
+		return err
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+	if reused != nil {
+		log.Printf("Refresh token of session %s of user %s was used twice, revoked the session", reused.FamilyID, reused.UserID)
+		return nil, "", "", ErrRefreshTokenReused
+	}
+	return &user, accessToken, refreshToken, nil
+}
+
+// EndSession revokes the session of a refresh token, or with allDevices every
+// session of its user.
+func EndSession(token string, allDevices bool) error {
+	if _, err := ValidateJWT(token, []byte(settings.RefreshSecret)); err != nil {
+		if errors.Is(err, errNotConfigured) {
+			return err
+		}
+		return ErrInvalidRefreshToken
+	}
+	var current models.RefreshToken
+	err := database.Where("token_hash = ?", hashToken(token)).First(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	if allDevices {
+		return RevokeUserSessions(database, current.UserID.String())
+	}
+	return revokeFamily(database, current.FamilyID)
+}
+
+// RevokeUserSessions revokes every session of a user stored in db, who then has
+// to sign in again once their access token expires.
+func RevokeUserSessions(db *gorm.DB, userID string) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func revokeFamily(db *gorm.DB, familyID uuid.UUID) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
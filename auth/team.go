@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// teamUsersSQL selects the ids of the users that make up @user's team: everyone in
+// the same team plus the users who report directly to @user. @user is always included.
+const teamUsersSQL = `SELECT team_users.id FROM users team_users
+	WHERE team_users.deleted_at IS NULL AND (
+		team_users.id = @user
+		OR team_users.manager_id = @user
+		OR team_users.team_id = (SELECT me.team_id FROM users me WHERE me.id = @user AND me.team_id IS NOT NULL)
+	)`
+
+// TeamLookup returns the ids of the users in userID's team.
+type TeamLookup func(ctx context.Context, userID string) ([]uuid.UUID, error)
+
+// TeamUserIDs returns the ids of userID's team in db, see teamUsersSQL.
+func TeamUserIDs(ctx context.Context, db *gorm.DB, userID string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := db.WithContext(ctx).Raw(teamUsersSQL, map[string]interface{}{"user": userID}).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CurrentUserID returns the user_id claim of the user in ctx.
+func CurrentUserID(ctx context.Context) (string, error) {
+	claims, ok := GetUserFromJWT(ctx)
+	if !ok {
+		return "", apperr.Unauthenticated("unauthorized")
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return "", apperr.Unauthenticated("invalid user ID in token")
+	}
+	return userID, nil
+}
+
+// CurrentUser returns the user_id and role claims of the user in ctx.
+func CurrentUser(ctx context.Context) (string, string, error) {
+	userID, err := CurrentUserID(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	role, err := GetUserRoleFromJWT(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	return userID, role, nil
+}
+
+// CanAssignLeadTo reports whether the user in ctx may assign a lead to assigneeID:
+//   - lead:assign       to anyone
+//   - lead:assign:team  to members of their team, as returned by team
+//   - lead:assign:own   only to themselves
+func CanAssignLeadTo(ctx context.Context, assigneeID uuid.UUID, team TeamLookup) (bool, error) {
+	if HasPermission(ctx, "lead:assign") {
+		return true, nil
+	}
+	userID, err := CurrentUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+	if assigneeID.String() == userID {
+		return HasPermission(ctx, "lead:assign:"+PermissionScopeOwn), nil
+	}
+	if !HasPermission(ctx, "lead:assign:"+PermissionScopeTeam) {
+		return false, nil
+	}
+	teamIDs, err := team(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range teamIDs {
+		if id == assigneeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
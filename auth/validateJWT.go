@@ -13,6 +13,9 @@ func ValidateJWT(tokenStr string, key []byte) (jwt.MapClaims, error) {
 	if tokenStr == "" {
 		return nil, errors.New("missing token")
 	}
+	if len(key) == 0 {
+		return nil, errNotConfigured
+	}
 
 	// Ensure token has three segments (header.payload.signature)
 	if len(strings.Split(tokenStr, ".")) != 3 {
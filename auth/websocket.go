@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// IsWebsocketUpgrade reports whether r opens a GraphQL subscription connection.
+func IsWebsocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// WebsocketInit authenticates a subscription connection. Browsers cannot set headers
+// on WebSocket requests, so the access token is sent in the connection_init payload
+// as "Authorization" (with or without "Bearer "). Clients that did send the header
+// on the upgrade request are already authenticated by Middleware.
+func WebsocketInit(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	token := payload.Authorization()
+	if token == "" {
+		token = payload.GetString("authToken")
+	}
+	if token == "" {
+		if _, ok := GetUserFromJWT(ctx); ok {
+			return ctx, nil, nil
+		}
+		return nil, nil, errors.New("unauthorized: missing token")
+	}
+
+	claims, err := ValidateJWT(strings.TrimPrefix(token, "Bearer "), []byte(settings.JWTSecret))
+	if err != nil {
+		return nil, nil, errors.New("unauthorized: invalid token")
+	}
+	return context.WithValue(ctx, UserCtxKey, claims), nil, nil
+}
@@ -0,0 +1,105 @@
+// Package apperr defines the errors the API reports to clients. Each carries a
+// code, sent as extensions.code, that clients can branch on instead of parsing
+// messages.
+//
+// Resolvers return these for anything the caller can act on. Any other error
+// is logged and reported as INTERNAL without its message (see Presenter), so
+// database errors never reach clients.
+package apperr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Code classifies an error for clients.
+type Code string
+
+const (
+	CodeUnauthenticated  Code = "UNAUTHENTICATED"
+	CodeForbidden        Code = "FORBIDDEN"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeConflict         Code = "CONFLICT"
+	CodeInternal         Code = "INTERNAL"
+)
+
+// Error is an error whose message is safe to show to clients.
+type Error struct {
+	Code    Code
+	Message string
+	// Fields maps input fields to what is wrong with them, for VALIDATION_FAILED.
+	Fields map[string]string
+	// Err is the underlying cause. It is logged, never shown.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(code Code, format string, args []any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Unauthenticated reports a missing or invalid login.
+func Unauthenticated(format string, args ...any) *Error {
+	return newError(CodeUnauthenticated, format, args)
+}
+
+// Forbidden reports that the caller may not do what they asked.
+func Forbidden(format string, args ...any) *Error {
+	return newError(CodeForbidden, format, args)
+}
+
+// NotFound reports a record that does not exist or that the caller cannot see.
+func NotFound(format string, args ...any) *Error {
+	return newError(CodeNotFound, format, args)
+}
+
+// Invalid reports input that is wrong as a whole.
+func Invalid(format string, args ...any) *Error {
+	return newError(CodeValidationFailed, format, args)
+}
+
+// InvalidField reports a wrong input field. The message is also the field's detail.
+func InvalidField(field, format string, args ...any) *Error {
+	err := newError(CodeValidationFailed, format, args)
+	err.Fields = map[string]string{field: err.Message}
+	return err
+}
+
+// InvalidFields reports several wrong input fields, mapped to their details.
+func InvalidFields(fields map[string]string) *Error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	details := make([]string, 0, len(names))
+	for _, name := range names {
+		details = append(details, name+" "+fields[name])
+	}
+	return &Error{
+		Code:    CodeValidationFailed,
+		Message: "invalid input: " + strings.Join(details, "; "),
+		Fields:  fields,
+	}
+}
+
+// Conflict reports a request that clashes with the current state, e.g. a duplicate.
+func Conflict(format string, args ...any) *Error {
+	return newError(CodeConflict, format, args)
+}
+
+// Internal reports a failure of the server. Only the message is shown.
+func Internal(err error, format string, args ...any) *Error {
+	e := newError(CodeInternal, format, args)
+	e.Err = err
+	return e
+}
@@ -0,0 +1,79 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gorm.io/gorm"
+)
+
+// internalPrefix starts the messages resolvers give failures they have logged
+// and described safely themselves, e.g. "internal error: failed to fetch leads".
+const internalPrefix = "internal error: "
+
+// Presenter is the gqlgen ErrorPresenter. It sets extensions.code, and
+// extensions.fields for validation errors, and hides the message of any error
+// that is not an *Error.
+func Presenter(ctx context.Context, err error) *gqlerror.Error {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		var gqlErr *gqlerror.Error
+		if errors.As(err, &gqlErr) {
+			// Raised by gqlgen itself: parsing, validation, query limits or,
+			// wrapping an error, arguments that do not parse
+			if gqlErr.Err != nil && gqlErr.Extensions["code"] == nil {
+				if gqlErr.Extensions == nil {
+					gqlErr.Extensions = map[string]interface{}{}
+				}
+				gqlErr.Extensions["code"] = CodeValidationFailed
+			}
+			return gqlErr
+		}
+		appErr = classify(ctx, err)
+		err = appErr
+	}
+
+	// Errors wrapping an *Error keep their message, which only adds context to it
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	presented.Extensions = map[string]interface{}{"code": appErr.Code}
+	if len(appErr.Fields) > 0 {
+		presented.Extensions["fields"] = appErr.Fields
+	}
+	return presented
+}
+
+// classify maps an untyped error to the code the client should see.
+func classify(ctx context.Context, err error) *Error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound("record not found")
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return Conflict("a record with the same values already exists")
+		case "23503": // foreign_key_violation
+			return Conflict("the record is still referenced by, or references, other records")
+		case "22P02": // invalid_text_representation, e.g. a malformed ID
+			return Invalid("invalid input value")
+		}
+	}
+
+	if message := err.Error(); strings.HasPrefix(message, internalPrefix) {
+		return Internal(err, "%s", message)
+	}
+	log.Printf("Error resolving %v: %v", graphql.GetPath(ctx), err)
+	return Internal(err, "internal error")
+}
+
+// Recover is the gqlgen RecoverFunc. It logs the panic and reports an internal error.
+func Recover(ctx context.Context, p interface{}) error {
+	log.Printf("Panic resolving %v: %v\n%s", graphql.GetPath(ctx), p, debug.Stack())
+	return Internal(nil, "internal error")
+}
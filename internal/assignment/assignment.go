@@ -0,0 +1,224 @@
+// Package assignment picks an owner for new leads using the rules stored in
+// assignment_rules, and logs every decision in lead_assignment_logs.
+package assignment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/audit"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNoRuleMatched is returned when no enabled rule can assign the lead.
+var ErrNoRuleMatched = apperr.InvalidField("leadAssignedTo", "no assignment rule matched the lead, set leadAssignedTo explicitly")
+
+// Lead holds the attributes rules are matched against.
+type Lead struct {
+	Country    string
+	CampaignID uuid.UUID
+}
+
+// Decision is the outcome of Assign.
+type Decision struct {
+	User     models.User
+	Rule     *models.AssignmentRule // nil for manual assignments
+	Strategy string
+	Reason   string
+}
+
+// Manual records an assignment made by hand, so it shows up in the log like rule decisions.
+func Manual(user models.User) *Decision {
+	return &Decision{
+		User:     user,
+		Strategy: models.AssignmentStrategyManual,
+		Reason:   "assigned explicitly by the creator",
+	}
+}
+
+// Assign runs the rules in priority order and returns the first decision.
+// A rule that matches but has no candidate users falls through to the next one.
+// The round-robin position is updated inside db's transaction, so pass the
+// transaction that creates the lead.
+func Assign(db *gorm.DB, lead Lead) (*Decision, error) {
+	var rules []models.AssignmentRule
+	if err := db.Where("enabled = ?", true).Order("priority ASC, created_at ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to load assignment rules: %w", err)
+	}
+
+	var skipped []string
+	for i := range rules {
+		rule := &rules[i]
+		if !matches(rule, lead) {
+			continue
+		}
+		candidates, err := candidates(db, rule)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			skipped = append(skipped, rule.Name)
+			continue
+		}
+
+		var decision *Decision
+		switch rule.Strategy {
+		case models.AssignmentStrategyRoundRobin:
+			decision, err = roundRobin(db, rule, candidates)
+		case models.AssignmentStrategyLeastLoaded:
+			decision, err = leastLoaded(db, rule, candidates)
+		default:
+			return nil, fmt.Errorf("assignment rule %q has unknown strategy %s", rule.Name, rule.Strategy)
+		}
+		if err != nil {
+			return nil, err
+		}
+		decision.Rule = rule
+		decision.Strategy = rule.Strategy
+		if len(skipped) > 0 {
+			decision.Reason += fmt.Sprintf("; skipped rules without candidates: %s", strings.Join(skipped, ", "))
+		}
+		return decision, nil
+	}
+	return nil, ErrNoRuleMatched
+}
+
+// AssignLead sets lead.LeadAssignedTo. With a manual assignee that user is used,
+// otherwise the rules decide. Call it in the transaction that creates the lead and
+// log the returned decision with LogDecision once the lead exists.
+func AssignLead(db *gorm.DB, lead *models.Lead, manual *models.User) (*Decision, error) {
+	var decision *Decision
+	if manual != nil {
+		decision = Manual(*manual)
+	} else {
+		var err error
+		decision, err = Assign(db, Lead{Country: lead.Country, CampaignID: lead.CampaignID})
+		if err != nil {
+			return nil, err
+		}
+	}
+	lead.LeadAssignedTo = decision.User.ID
+	return decision, nil
+}
+
+// LogDecision stores decision for leadID. assignedBy is the user who created the lead, if known.
+func LogDecision(db *gorm.DB, leadID uuid.UUID, decision *Decision, assignedBy *uuid.UUID) error {
+	entry := models.LeadAssignmentLog{
+		ID:         uuid.New(),
+		LeadID:     leadID,
+		AssignedTo: decision.User.ID,
+		AssignedBy: assignedBy,
+		Strategy:   decision.Strategy,
+		Reason:     decision.Reason,
+	}
+	if decision.Rule != nil {
+		entry.RuleID = &decision.Rule.ID
+		entry.RuleName = decision.Rule.Name
+	}
+	return db.Create(&entry).Error
+}
+
+// matches reports whether lead satisfies every condition of rule.
+func matches(rule *models.AssignmentRule, lead Lead) bool {
+	if rule.CampaignID != nil && *rule.CampaignID != lead.CampaignID {
+		return false
+	}
+	if len(rule.Countries) > 0 {
+		found := false
+		for _, country := range rule.Countries {
+			if strings.EqualFold(strings.TrimSpace(country), strings.TrimSpace(lead.Country)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// candidates returns the rule's users and team members, ordered by id so the
+// round-robin rotation is stable.
+func candidates(db *gorm.DB, rule *models.AssignmentRule) ([]models.User, error) {
+	conditions := []string{"id IN (SELECT user_id FROM assignment_rule_users WHERE assignment_rule_id = ?)"}
+	args := []interface{}{rule.ID}
+	if rule.TeamID != nil {
+		conditions = append(conditions, "team_id = ?")
+		args = append(args, *rule.TeamID)
+	}
+
+	var users []models.User
+	if err := db.Model(&models.User{}).Where("("+strings.Join(conditions, " OR ")+")", args...).Order("id ASC").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to load candidates of rule %q: %w", rule.Name, err)
+	}
+	return users, nil
+}
+
+// roundRobin gives the lead to the candidate after the one who got the previous lead.
+// The rule row is locked so concurrent leads do not land on the same rep.
+func roundRobin(db *gorm.DB, rule *models.AssignmentRule, candidates []models.User) (*Decision, error) {
+	var locked models.AssignmentRule
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "last_assigned_user_id").First(&locked, "id = ?", rule.ID).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock assignment rule %q: %w", rule.Name, err)
+	}
+
+	next := 0
+	if locked.LastAssignedUserID != nil {
+		for i, candidate := range candidates {
+			if candidate.ID == *locked.LastAssignedUserID {
+				next = (i + 1) % len(candidates)
+				break
+			}
+		}
+	}
+	chosen := candidates[next]
+	// Bookkeeping, not an edit of the rule
+	if err := audit.Skip(db).Model(&models.AssignmentRule{}).Where("id = ?", rule.ID).Update("last_assigned_user_id", chosen.ID).Error; err != nil {
+		return nil, fmt.Errorf("failed to update round-robin position of rule %q: %w", rule.Name, err)
+	}
+	return &Decision{
+		User:   chosen,
+		Reason: fmt.Sprintf("rule %q: round-robin, candidate %d of %d", rule.Name, next+1, len(candidates)),
+	}, nil
+}
+
+// leastLoaded gives the lead to the candidate with the fewest open leads.
+// Ties go to the candidate who comes first by id.
+func leastLoaded(db *gorm.DB, rule *models.AssignmentRule, candidates []models.User) (*Decision, error) {
+	ids := make([]uuid.UUID, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.ID)
+	}
+	var rows []struct {
+		LeadAssignedTo uuid.UUID
+		Count          int64
+	}
+	if err := db.Model(&models.Lead{}).
+		Select("lead_assigned_to, COUNT(*) AS count").
+		Where("lead_assigned_to IN ?", ids).
+		Where("lead_stage NOT IN ?", []models.LeadStage{models.LeadStageClosedWon, models.LeadStageClosedLost}).
+		Group("lead_assigned_to").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count open leads for rule %q: %w", rule.Name, err)
+	}
+	load := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		load[row.LeadAssignedTo] = row.Count
+	}
+
+	sorted := append([]models.User(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return load[sorted[i].ID] < load[sorted[j].ID]
+	})
+	chosen := sorted[0]
+	return &Decision{
+		User:   chosen,
+		Reason: fmt.Sprintf("rule %q: least loaded, %d open leads among %d candidates", rule.Name, load[chosen.ID], len(candidates)),
+	}, nil
+}
@@ -0,0 +1,355 @@
+// Package audit records every row created, updated or deleted through GORM as a
+// models.AuditEvent, with the column values before and after the change.
+//
+// It works through GORM callbacks, so nothing has to call it. The actor is the
+// user in the statement's context, as found by the ActorFunc given to Register:
+// use DB.WithContext(ctx) for changes made on a user's behalf. Without one the
+// change is recorded as made by the system.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxRows caps how many rows of one bulk update or delete are audited.
+const maxRows = 1000
+
+// skippedTables are logs and bookkeeping that are not worth auditing, or that
+// hold secrets.
+var skippedTables = map[string]bool{
+	"audit_events":         true,
+	"refresh_tokens":       true,
+	"lead_stage_histories": true,
+	"lead_assignment_logs": true,
+	"import_jobs":          true,
+	"import_job_rows":      true,
+	"export_jobs":          true,
+	"webhook_deliveries":   true,
+	"campaign_users":       true,
+	"schema_migrations":    true,
+}
+
+// redactedWords mark the columns that are recorded as changed, but without
+// their values: any column whose name contains one, e.g. token_hash or
+// backend_refresh_token.
+var redactedWords = []string{"password", "secret", "token"}
+
+// redactedColumn reports whether the values of column are kept out of the log.
+func redactedColumn(column string) bool {
+	column = strings.ToLower(column)
+	for _, word := range redactedWords {
+		if strings.Contains(column, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredColumns change on every write and say nothing about the change.
+var ignoredColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+const redacted = "[redacted]"
+
+type skipKey struct{}
+
+// Skip returns db with auditing turned off, for derived values such as lead
+// scores that are not edits by anyone.
+func Skip(db *gorm.DB) *gorm.DB {
+	return db.WithContext(context.WithValue(db.Statement.Context, skipKey{}, true))
+}
+
+// ActorFunc returns the id and role of the user in ctx, or an error if there is none.
+type ActorFunc func(ctx context.Context) (userID string, role string, err error)
+
+var actorOf ActorFunc = func(context.Context) (string, string, error) {
+	return "", "", errors.New("no actor")
+}
+
+// Register installs the audit callbacks on db.
+func Register(db *gorm.DB, actor ActorFunc) error {
+	actorOf = actor
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("audit:after_create", afterCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("audit:before_update", before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("audit:after_update", afterUpdate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("audit:before_delete", before); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("audit:after_delete", afterDelete)
+}
+
+const beforeKey = "audit:before"
+
+// audited reports whether the statement's rows should be audited.
+func audited(db *gorm.DB) bool {
+	if db.Error != nil || db.Statement.Schema == nil || len(db.Statement.Schema.PrimaryFields) != 1 {
+		return false
+	}
+	if skip, _ := db.Statement.Context.Value(skipKey{}).(bool); skip {
+		return false
+	}
+	return !skippedTables[db.Statement.Table]
+}
+
+// before snapshots the rows an update or delete is about to change.
+func before(db *gorm.DB) {
+	if db.Error == nil && db.Statement.Table == "audit_events" {
+		db.AddError(errors.New("audit events are append-only"))
+		return
+	}
+	if !audited(db) {
+		return
+	}
+	rows, err := load(db, primaryKeys(db))
+	if err != nil {
+		log.Printf("Error reading %s rows for the audit log: %v", db.Statement.Table, err)
+		return
+	}
+	db.InstanceSet(beforeKey, rows)
+}
+
+func afterCreate(db *gorm.DB) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	ids := primaryKeys(db)
+	if len(ids) == 0 {
+		return
+	}
+	rows, err := load(db, ids)
+	if err != nil {
+		log.Printf("Error reading created %s rows for the audit log: %v", db.Statement.Table, err)
+		return
+	}
+	var events []models.AuditEvent
+	for _, row := range rows {
+		events = append(events, newEvent(db, row, models.AuditActionCreate, diff(nil, row)))
+	}
+	write(db, events)
+}
+
+func afterUpdate(db *gorm.DB) {
+	previous, ok := beforeRows(db)
+	if !ok || db.Statement.RowsAffected == 0 {
+		return
+	}
+	rows, err := load(db, idsOf(db, previous))
+	if err != nil {
+		log.Printf("Error reading updated %s rows for the audit log: %v", db.Statement.Table, err)
+		return
+	}
+	current := map[string]map[string]interface{}{}
+	for _, row := range rows {
+		current[entityID(db, row)] = row
+	}
+	var events []models.AuditEvent
+	for _, old := range previous {
+		changes := diff(old, current[entityID(db, old)])
+		if len(changes) == 0 {
+			continue
+		}
+		events = append(events, newEvent(db, old, updateAction(old, current[entityID(db, old)]), changes))
+	}
+	write(db, events)
+}
+
+// updateAction tells soft deletes and restores, which only set or clear
+// deleted_at, from other updates.
+func updateAction(old, new map[string]interface{}) string {
+	switch {
+	case old["deleted_at"] == nil && new["deleted_at"] != nil:
+		return models.AuditActionDelete
+	case old["deleted_at"] != nil && new != nil && new["deleted_at"] == nil:
+		return models.AuditActionRestore
+	}
+	return models.AuditActionUpdate
+}
+
+func afterDelete(db *gorm.DB) {
+	previous, ok := beforeRows(db)
+	if !ok || db.Statement.RowsAffected == 0 {
+		return
+	}
+	var events []models.AuditEvent
+	for _, old := range previous {
+		action := models.AuditActionDelete
+		if old["deleted_at"] != nil {
+			// Hard delete of a row that was already soft-deleted
+			action = models.AuditActionPurge
+		}
+		events = append(events, newEvent(db, old, action, diff(old, nil)))
+	}
+	write(db, events)
+}
+
+func beforeRows(db *gorm.DB) ([]map[string]interface{}, bool) {
+	if !audited(db) {
+		return nil, false
+	}
+	value, ok := db.InstanceGet(beforeKey)
+	if !ok {
+		return nil, false
+	}
+	rows, _ := value.([]map[string]interface{})
+	return rows, len(rows) > 0
+}
+
+// primaryKeys returns the primary keys of the statement's model values, if it has any.
+func primaryKeys(db *gorm.DB) []interface{} {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil {
+		field = db.Statement.Schema.PrimaryFields[0]
+	}
+	var ids []interface{}
+	add := func(value reflect.Value) {
+		if id, zero := field.ValueOf(db.Statement.Context, value); !zero {
+			ids = append(ids, id)
+		}
+	}
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	switch value.Kind() {
+	case reflect.Struct:
+		add(value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if item := reflect.Indirect(value.Index(i)); item.Kind() == reflect.Struct {
+				add(item)
+			}
+		}
+	}
+	return ids
+}
+
+// load reads the statement's rows as column maps: by ids when there are any,
+// otherwise by the statement's own conditions.
+func load(db *gorm.DB, ids []interface{}) ([]map[string]interface{}, error) {
+	query := db.Session(&gorm.Session{NewDB: true}).Table(db.Statement.Table)
+	primary := primaryColumn(db)
+	switch {
+	case len(ids) > 0:
+		query = query.Where(clause.IN{Column: clause.Column{Table: db.Statement.Table, Name: primary}, Values: ids})
+	case db.Statement.Clauses["WHERE"].Expression != nil:
+		query = query.Clauses(db.Statement.Clauses["WHERE"].Expression)
+	default:
+		return nil, nil
+	}
+	var rows []map[string]interface{}
+	err := query.Limit(maxRows).Find(&rows).Error
+	return rows, err
+}
+
+func idsOf(db *gorm.DB, rows []map[string]interface{}) []interface{} {
+	ids := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row[primaryColumn(db)])
+	}
+	return ids
+}
+
+func primaryColumn(db *gorm.DB) string {
+	if field := db.Statement.Schema.PrioritizedPrimaryField; field != nil {
+		return field.DBName
+	}
+	return db.Statement.Schema.PrimaryFields[0].DBName
+}
+
+func entityID(db *gorm.DB, row map[string]interface{}) string {
+	return fmt.Sprint(normalize(row[primaryColumn(db)]))
+}
+
+// diff lists the columns whose values differ between two rows; either may be nil.
+func diff(old, new map[string]interface{}) map[string]models.AuditChange {
+	changes := map[string]models.AuditChange{}
+	columns := map[string]bool{}
+	for column := range old {
+		columns[column] = true
+	}
+	for column := range new {
+		columns[column] = true
+	}
+	for column := range columns {
+		if ignoredColumns[column] {
+			continue
+		}
+		from, to := normalize(old[column]), normalize(new[column])
+		if fmt.Sprint(from) == fmt.Sprint(to) {
+			continue
+		}
+		if redactedColumn(column) {
+			if from != nil {
+				from = redacted
+			}
+			if to != nil {
+				to = redacted
+			}
+		}
+		changes[column] = models.AuditChange{From: from, To: to}
+	}
+	return changes
+}
+
+func newEvent(db *gorm.DB, row map[string]interface{}, action string, changes map[string]models.AuditChange) models.AuditEvent {
+	event := models.AuditEvent{
+		ID:         uuid.New(),
+		CreatedAt:  time.Now(),
+		EntityType: db.Statement.Schema.Name,
+		EntityID:   entityID(db, row),
+		Action:     action,
+		Changes:    changes,
+	}
+	if userID, role, err := actorOf(db.Statement.Context); err == nil {
+		if actorID, err := uuid.Parse(userID); err == nil {
+			event.ActorID = &actorID
+			event.ActorRole = role
+		}
+	}
+	return event
+}
+
+// write stores events in the statement's transaction, so they stand or fall with the change.
+func write(db *gorm.DB, events []models.AuditEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&events).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to write audit log: %w", err))
+	}
+}
+
+// normalize turns database values into comparable, JSON-friendly ones.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case [16]byte:
+		return uuid.UUID(v).String()
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return value
+}
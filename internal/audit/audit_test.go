@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+func TestDiffRedactsSecretColumns(t *testing.T) {
+	old := map[string]interface{}{
+		"name":                  "Ann",
+		"password":              "old-hash",
+		"token_hash":            "aaa",
+		"backend_refresh_token": nil,
+		"client_secret":         "s1",
+		"updated_at":            "yesterday",
+	}
+	new := map[string]interface{}{
+		"name":                  "Anne",
+		"password":              "new-hash",
+		"token_hash":            "bbb",
+		"backend_refresh_token": "rt",
+		"client_secret":         "s2",
+		"updated_at":            "today",
+	}
+	want := map[string]models.AuditChange{
+		"name":                  {From: "Ann", To: "Anne"},
+		"password":              {From: redacted, To: redacted},
+		"token_hash":            {From: redacted, To: redacted},
+		"backend_refresh_token": {From: nil, To: redacted},
+		"client_secret":         {From: redacted, To: redacted},
+	}
+
+	changes := diff(old, new)
+	if len(changes) != len(want) {
+		t.Errorf("changes = %v, want %v", changes, want)
+	}
+	for column, change := range want {
+		if got, ok := changes[column]; !ok || got.From != change.From || got.To != change.To {
+			t.Errorf("change of %s = %+v, want %+v", column, got, change)
+		}
+	}
+}
+
+func TestRedactedColumn(t *testing.T) {
+	tests := map[string]bool{
+		"password":        true,
+		"PasswordHash":    true,
+		"webhook_secret":  true,
+		"access_token":    true,
+		"token":           true,
+		"email":           false,
+		"organization_id": false,
+	}
+	for column, want := range tests {
+		if got := redactedColumn(column); got != want {
+			t.Errorf("redactedColumn(%q) = %v, want %v", column, got, want)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Package config loads the server's settings into a Config and checks them
+// before anything starts.
+//
+// Settings come from, in order of precedence: environment variables, a .env
+// file in the working directory, and a YAML file (CONFIG_FILE, or config.yaml
+// if it exists; see config.example.yaml). Anything left unset has a default,
+// except for the secrets, which Validate requires.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the YAML file read when CONFIG_FILE is not set, if it exists.
+const DefaultFile = "config.yaml"
+
+// Prefixes of the variables that set QueryLimits, followed by the role.
+const (
+	maxDepthPrefix      = "GRAPHQL_MAX_DEPTH_"
+	maxComplexityPrefix = "GRAPHQL_MAX_COMPLEXITY_"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// minSecretLength is the shortest secret Validate accepts: 32 bytes, the size of an HS256 key.
+const minSecretLength = 32
+
+// Config is every setting of the server.
+type Config struct {
+	Port        string `yaml:"port"`        // PORT
+	DatabaseURL string `yaml:"databaseURL"` // DB_URL
+	// PublicURL is where browsers reach the server, e.g. for OAuth redirects
+	PublicURL   string   `yaml:"publicURL"`   // PUBLIC_URL
+	CORSOrigins []string `yaml:"corsOrigins"` // CORS_ORIGINS, comma separated
+	// TaskReminder is how long before its due date a task is announced on taskDueSoon
+	TaskReminder Duration `yaml:"taskReminder"` // TASK_REMINDER_MINUTES
+	// BaseCurrency is the ISO 4217 code pipeline totals are reported in and amounts without a currency are read in
+	BaseCurrency string `yaml:"baseCurrency"` // BASE_CURRENCY
+	// TrashRetention is how long deleted rows are kept before purgeTrash removes them
+	TrashRetention Duration `yaml:"trashRetention"` // TRASH_RETENTION_DAYS
+	// QueryLimits override the GraphQL depth and complexity limits of roles, see internal/querylimit
+	QueryLimits map[string]QueryLimit `yaml:"queryLimits"` // GRAPHQL_MAX_DEPTH_<ROLE>, GRAPHQL_MAX_COMPLEXITY_<ROLE>
+	Auth        Auth                  `yaml:"auth"`
+}
+
+// QueryLimit bounds the GraphQL operations of a role. Zero keeps the default limit.
+type QueryLimit struct {
+	Depth      int `yaml:"depth"`
+	Complexity int `yaml:"complexity"`
+}
+
+// Auth is the configuration of the auth package.
+type Auth struct {
+	JWTSecret          string   `yaml:"jwtSecret"`          // SECRET_KEY, signs access tokens
+	RefreshSecret      string   `yaml:"refreshSecret"`      // REFRESH_SECRET_KEY, signs refresh tokens
+	SessionKey         string   `yaml:"sessionKey"`         // SESSION_KEY, signs OAuth session cookies
+	AccessTokenExpiry  Duration `yaml:"accessTokenExpiry"`  // JWT_EXPIRY_TIME
+	RefreshTokenExpiry Duration `yaml:"refreshTokenExpiry"` // REFRESH_TOKEN_EXPIRY
+	GoogleClientID     string   `yaml:"googleClientID"`     // GOOGLE_CLIENT_ID
+	GoogleClientSecret string   `yaml:"googleClientSecret"` // GOOGLE_CLIENT_SECRET
+	// OAuthCallbackURL is where Google sends users back, PublicURL/auth/google/callback by default
+	OAuthCallbackURL string `yaml:"oauthCallbackURL"` // OAUTH_CALLBACK_URL
+	// OAuthSuccessURL receives the tokens after a Google sign-in, PublicURL/oauth-success by default
+	OAuthSuccessURL string `yaml:"oauthSuccessURL"` // OAUTH_SUCCESS_URL
+}
+
+// Duration is a time.Duration, written like "15m" or "168h".
+type Duration time.Duration
+
+// UnmarshalYAML reads a duration such as "15m".
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %q is not a duration such as 15m or 24h", node.Line, node.Value)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Default returns the settings used where nothing else is set.
+func Default() *Config {
+	return &Config{
+		Port:           "8080",
+		PublicURL:      "http://localhost:8080",
+		CORSOrigins:    []string{"http://localhost:3000"},
+		TaskReminder:   Duration(time.Hour),
+		BaseCurrency:   "USD",
+		TrashRetention: Duration(30 * 24 * time.Hour),
+		Auth: Auth{
+			AccessTokenExpiry:  Duration(15 * time.Minute),
+			RefreshTokenExpiry: Duration(7 * 24 * time.Hour),
+		},
+	}
+}
+
+// Load reads the configuration. It fails on values that cannot be parsed,
+// but does not check that required settings are present; see Validate.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	file, explicit := os.LookupEnv("CONFIG_FILE")
+	if !explicit {
+		file = DefaultFile
+	}
+	if err := cfg.readFile(file); err != nil {
+		if explicit || !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	// Roles are upper case, as in the variables that override their limits
+	if len(cfg.QueryLimits) > 0 {
+		limits := make(map[string]QueryLimit, len(cfg.QueryLimits))
+		for role, limit := range cfg.QueryLimits {
+			limits[strings.ToUpper(role)] = limit
+		}
+		cfg.QueryLimits = limits
+	}
+
+	if os.Getenv("RENDER") == "" { // Render automatically sets this env variable
+		if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read .env: %w", err)
+		}
+	}
+
+	var errs []error
+	setString(&cfg.Port, "PORT")
+	setString(&cfg.DatabaseURL, "DB_URL")
+	setString(&cfg.PublicURL, "PUBLIC_URL")
+	setList(&cfg.CORSOrigins, "CORS_ORIGINS")
+	setDuration(&cfg.TaskReminder, "TASK_REMINDER_MINUTES", time.Minute, &errs)
+	setString(&cfg.BaseCurrency, "BASE_CURRENCY")
+	setDuration(&cfg.TrashRetention, "TRASH_RETENTION_DAYS", 24*time.Hour, &errs)
+	setQueryLimits(&cfg.QueryLimits, &errs)
+	setString(&cfg.Auth.JWTSecret, "SECRET_KEY")
+	setString(&cfg.Auth.RefreshSecret, "REFRESH_SECRET_KEY")
+	setString(&cfg.Auth.SessionKey, "SESSION_KEY")
+	setDuration(&cfg.Auth.AccessTokenExpiry, "JWT_EXPIRY_TIME", time.Hour, &errs)
+	setDuration(&cfg.Auth.RefreshTokenExpiry, "REFRESH_TOKEN_EXPIRY", time.Hour, &errs)
+	setString(&cfg.Auth.GoogleClientID, "GOOGLE_CLIENT_ID")
+	setString(&cfg.Auth.GoogleClientSecret, "GOOGLE_CLIENT_SECRET")
+	setString(&cfg.Auth.OAuthCallbackURL, "OAUTH_CALLBACK_URL")
+	setString(&cfg.Auth.OAuthSuccessURL, "OAUTH_SUCCESS_URL")
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	cfg.BaseCurrency = strings.ToUpper(strings.TrimSpace(cfg.BaseCurrency))
+	if cfg.Auth.OAuthCallbackURL == "" {
+		cfg.Auth.OAuthCallbackURL = cfg.PublicURL + "/auth/google/callback"
+	}
+	if cfg.Auth.OAuthSuccessURL == "" {
+		cfg.Auth.OAuthSuccessURL = cfg.PublicURL + "/oauth-success"
+	}
+	return cfg, nil
+}
+
+// readFile overlays the settings in the YAML file at path.
+func (c *Config) readFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	log.Printf("Loaded configuration from %s", path)
+	return nil
+}
+
+// Validate checks that everything the server needs is set and usable, and
+// reports every problem at once.
+func (c *Config) Validate() error {
+	var problems []string
+	if err := c.ValidateDatabase(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := strconv.Atoi(c.Port); err != nil {
+		problems = append(problems, fmt.Sprintf("PORT %q must be a port number", c.Port))
+	}
+	if !isHTTPURL(c.PublicURL) {
+		problems = append(problems, fmt.Sprintf("PUBLIC_URL %q must be an absolute http or https URL", c.PublicURL))
+	}
+	for _, origin := range c.CORSOrigins {
+		if !isHTTPURL(origin) {
+			problems = append(problems, fmt.Sprintf("CORS_ORIGINS entry %q must be an absolute http or https URL", origin))
+		}
+	}
+	if c.TaskReminder <= 0 {
+		problems = append(problems, "TASK_REMINDER_MINUTES must be positive")
+	}
+	if !currencyCodePattern.MatchString(c.BaseCurrency) {
+		problems = append(problems, fmt.Sprintf("BASE_CURRENCY %q must be an ISO 4217 code such as USD", c.BaseCurrency))
+	}
+	if c.TrashRetention <= 0 {
+		problems = append(problems, "TRASH_RETENTION_DAYS must be positive")
+	}
+	for role, limit := range c.QueryLimits {
+		if limit.Depth < 0 {
+			problems = append(problems, fmt.Sprintf("%s%s must not be negative", maxDepthPrefix, role))
+		}
+		if limit.Complexity < 0 {
+			problems = append(problems, fmt.Sprintf("%s%s must not be negative", maxComplexityPrefix, role))
+		}
+	}
+
+	auth := c.Auth
+	for _, secret := range []struct{ name, value string }{
+		{"SECRET_KEY", auth.JWTSecret},
+		{"REFRESH_SECRET_KEY", auth.RefreshSecret},
+		{"SESSION_KEY", auth.SessionKey},
+	} {
+		switch {
+		case secret.value == "":
+			problems = append(problems, fmt.Sprintf("%s is required", secret.name))
+		case len(secret.value) < minSecretLength:
+			problems = append(problems, fmt.Sprintf("%s must be at least %d characters, e.g. from `openssl rand -hex 32`", secret.name, minSecretLength))
+		}
+	}
+	if auth.JWTSecret != "" && auth.JWTSecret == auth.RefreshSecret {
+		problems = append(problems, "SECRET_KEY and REFRESH_SECRET_KEY must differ")
+	}
+	if auth.AccessTokenExpiry <= 0 {
+		problems = append(problems, "JWT_EXPIRY_TIME must be positive")
+	}
+	if auth.RefreshTokenExpiry <= auth.AccessTokenExpiry {
+		problems = append(problems, "REFRESH_TOKEN_EXPIRY must be longer than JWT_EXPIRY_TIME")
+	}
+	if (auth.GoogleClientID == "") != (auth.GoogleClientSecret == "") {
+		problems = append(problems, "GOOGLE_CLIENT_ID and GOOGLE_CLIENT_SECRET must be set together")
+	}
+	if !isHTTPURL(auth.OAuthCallbackURL) {
+		problems = append(problems, fmt.Sprintf("OAUTH_CALLBACK_URL %q must be an absolute http or https URL", auth.OAuthCallbackURL))
+	}
+	if !isHTTPURL(auth.OAuthSuccessURL) {
+		problems = append(problems, fmt.Sprintf("OAUTH_SUCCESS_URL %q must be an absolute http or https URL", auth.OAuthSuccessURL))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// ValidateDatabase checks the settings of commands that only use the database.
+func (c *Config) ValidateDatabase() error {
+	if c.DatabaseURL == "" {
+		return errors.New("DB_URL is required")
+	}
+	return nil
+}
+
+// Empty variables count as unset, so they do not clear settings of the YAML file.
+func setString(target *string, name string) {
+	if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+		*target = value
+	}
+}
+
+func setList(target *[]string, name string) {
+	value := os.Getenv(name)
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	*target = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*target = append(*target, item)
+		}
+	}
+}
+
+// setDuration reads a duration. A bare number counts in unit, e.g. hours for
+// JWT_EXPIRY_TIME, as these variables used to be plain numbers.
+func setDuration(target *Duration, name string, unit time.Duration, errs *[]error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		*target = Duration(time.Duration(n) * unit)
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s %q is not a duration such as 15m or 24h", name, value))
+		return
+	}
+	*target = Duration(parsed)
+}
+
+// setQueryLimits reads the GRAPHQL_MAX_DEPTH_<ROLE> and
+// GRAPHQL_MAX_COMPLEXITY_<ROLE> variables of every role that has them.
+func setQueryLimits(target *map[string]QueryLimit, errs *[]error) {
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		value = strings.TrimSpace(value)
+		var role string
+		var field func(*QueryLimit) *int
+		switch {
+		case strings.HasPrefix(name, maxDepthPrefix):
+			role, field = strings.TrimPrefix(name, maxDepthPrefix), func(l *QueryLimit) *int { return &l.Depth }
+		case strings.HasPrefix(name, maxComplexityPrefix):
+			role, field = strings.TrimPrefix(name, maxComplexityPrefix), func(l *QueryLimit) *int { return &l.Complexity }
+		default:
+			continue
+		}
+		if role == "" || value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("%s %q is not a number", name, value))
+			continue
+		}
+		if *target == nil {
+			*target = make(map[string]QueryLimit)
+		}
+		role = strings.ToUpper(role)
+		limit := (*target)[role]
+		*field(&limit) = n
+		(*target)[role] = limit
+	}
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.ParseRequestURI(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,242 @@
+// Package dedupe finds likely duplicate leads and organizations and merges
+// duplicates into a surviving record.
+package dedupe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// nameThreshold is the Similarity from which two names are considered the same person or company.
+const nameThreshold = 0.85
+
+// candidateLimit bounds how many rows a duplicate check compares.
+const candidateLimit = 200
+
+// Reasons reported with a match.
+const (
+	ReasonSameEmail       = "same email"
+	ReasonSamePhone       = "same phone"
+	ReasonSameName        = "same name"
+	ReasonSimilarName     = "similar name at the same organization"
+	ReasonSameDomain      = "same website domain"
+	ReasonSameCompanyName = "same organization name"
+	ReasonSimilarCompany  = "similar organization name"
+)
+
+// Lead holds the attributes a lead is matched on. ID, if set, is excluded from the results.
+type Lead struct {
+	ID             uuid.UUID
+	FirstName      string
+	LastName       string
+	Email          string
+	Phone          string
+	OrganizationID uuid.UUID
+}
+
+// LeadMatch is an existing lead that is likely the same person.
+type LeadMatch struct {
+	Lead    models.Lead
+	Reasons []string
+}
+
+// Organization holds the attributes an organization is matched on. ID, if set, is excluded from the results.
+type Organization struct {
+	ID      uuid.UUID
+	Name    string
+	Email   string
+	Website string
+}
+
+// OrganizationMatch is an existing organization that is likely the same company.
+type OrganizationMatch struct {
+	Organization models.Organization
+	Reasons      []string
+}
+
+// FindLeadDuplicates returns the leads that share the email or phone of lead,
+// have the same name, or have a similar name and work for the same organization.
+// The organization is the lead's own one, or any organization whose website
+// domain is the domain of the lead's email. Scope db to limit what is searched.
+func FindLeadDuplicates(db *gorm.DB, lead Lead) ([]LeadMatch, error) {
+	email := NormalizeEmail(lead.Email)
+	phone := NormalizePhone(lead.Phone)
+	name := normalizeName(lead.FirstName + " " + lead.LastName)
+
+	// Organizations the lead plausibly works for
+	organizationIDs := []uuid.UUID{}
+	if lead.OrganizationID != uuid.Nil {
+		organizationIDs = append(organizationIDs, lead.OrganizationID)
+	}
+	domain := EmailDomain(lead.Email)
+	if domain != "" {
+		var organizations []models.Organization
+		if err := db.Session(&gorm.Session{NewDB: true}).Model(&models.Organization{}).
+			Select("id", "organization_website").
+			Where("LOWER(organization_website) LIKE ?", "%"+domain+"%").
+			Limit(candidateLimit).
+			Find(&organizations).Error; err != nil {
+			return nil, fmt.Errorf("failed to look up organizations by domain: %w", err)
+		}
+		for _, organization := range organizations {
+			if WebsiteDomain(organization.OrganizationWebsite) == domain {
+				organizationIDs = append(organizationIDs, organization.ID)
+			}
+		}
+	}
+
+	// --- Narrow down in SQL, decide in Go ---
+	conditions := []string{}
+	args := []interface{}{}
+	if email != "" {
+		conditions = append(conditions, "LOWER(TRIM(leads.email)) = ?")
+		args = append(args, email)
+	}
+	if phone != "" {
+		conditions = append(conditions, "RIGHT(REGEXP_REPLACE(leads.phone, '[^0-9]', '', 'g'), 10) = ?")
+		args = append(args, phone)
+	}
+	if name != "" {
+		conditions = append(conditions, "LOWER(TRIM(leads.first_name)) = ? AND LOWER(TRIM(leads.last_name)) = ?")
+		args = append(args, strings.ToLower(strings.TrimSpace(lead.FirstName)), strings.ToLower(strings.TrimSpace(lead.LastName)))
+	}
+	if len(organizationIDs) > 0 {
+		conditions = append(conditions, "leads.organization_id IN ?")
+		args = append(args, organizationIDs)
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	query := db.Model(&models.Lead{}).Where("("+strings.Join(conditions, ") OR (")+")", args...)
+	if lead.ID != uuid.Nil {
+		query = query.Where("leads.id <> ?", lead.ID)
+	}
+	var candidates []models.Lead
+	if err := query.Order("leads.created_at ASC").Limit(candidateLimit).Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up duplicate leads: %w", err)
+	}
+
+	sameOrganization := make(map[uuid.UUID]bool, len(organizationIDs))
+	for _, id := range organizationIDs {
+		sameOrganization[id] = true
+	}
+	matches := []LeadMatch{}
+	for _, candidate := range candidates {
+		if reasons := LeadReasons(lead, sameOrganization, candidate); len(reasons) > 0 {
+			matches = append(matches, LeadMatch{Lead: candidate, Reasons: reasons})
+		}
+	}
+	return matches, nil
+}
+
+// LeadReasons returns why candidate is likely the same person as lead, or nothing
+// if it is not. sameOrganization holds the organizations lead plausibly works for.
+func LeadReasons(lead Lead, sameOrganization map[uuid.UUID]bool, candidate models.Lead) []string {
+	email := NormalizeEmail(lead.Email)
+	phone := NormalizePhone(lead.Phone)
+	name := normalizeName(lead.FirstName + " " + lead.LastName)
+
+	var reasons []string
+	if email != "" && NormalizeEmail(candidate.Email) == email {
+		reasons = append(reasons, ReasonSameEmail)
+	}
+	if phone != "" && NormalizePhone(candidate.Phone) == phone {
+		reasons = append(reasons, ReasonSamePhone)
+	}
+	if name != "" {
+		candidateName := normalizeName(candidate.FirstName + " " + candidate.LastName)
+		if candidateName == name {
+			reasons = append(reasons, ReasonSameName)
+		} else if sameOrganization[candidate.OrganizationID] && Similarity(candidateName, name) >= nameThreshold {
+			reasons = append(reasons, ReasonSimilarName)
+		}
+	}
+	return reasons
+}
+
+// FindOrganizationDuplicates returns the organizations that share the website
+// domain of organization (its email domain counts when it has no website) or
+// have the same or a similar name. Candidates are found by trigram similarity
+// of their names (pg_trgm), the most similar first after those of the same domain.
+func FindOrganizationDuplicates(db *gorm.DB, organization Organization) ([]OrganizationMatch, error) {
+	domain := WebsiteDomain(organization.Website)
+	if domain == "" {
+		domain = EmailDomain(organization.Email)
+	}
+	name := NormalizeCompanyName(organization.Name)
+
+	conditions := []string{}
+	args := []interface{}{}
+	order := []string{}
+	orderArgs := []interface{}{}
+	if domain != "" {
+		sameDomain := "LOWER(organization_website) LIKE ? OR LOWER(organization_email) LIKE ?"
+		conditions = append(conditions, sameDomain)
+		args = append(args, "%"+domain+"%", "%@"+domain)
+		order = append(order, "("+sameDomain+") DESC")
+		orderArgs = append(orderArgs, "%"+domain+"%", "%@"+domain)
+	}
+	if name != "" {
+		// % is pg_trgm's similarity operator, served by idx_organizations_name_trgm
+		conditions = append(conditions, "LOWER(organization_name) % ?")
+		args = append(args, name)
+		order = append(order, "similarity(LOWER(organization_name), ?) DESC")
+		orderArgs = append(orderArgs, name)
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	query := db.Model(&models.Organization{}).Where("("+strings.Join(conditions, ") OR (")+")", args...)
+	if organization.ID != uuid.Nil {
+		query = query.Where("id <> ?", organization.ID)
+	}
+	var candidates []models.Organization
+	if err := query.Order(clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(order, ", "), Vars: orderArgs, WithoutParentheses: true}}).Limit(candidateLimit).Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up duplicate organizations: %w", err)
+	}
+
+	matches := []OrganizationMatch{}
+	for _, candidate := range candidates {
+		if reasons := OrganizationReasons(organization, candidate); len(reasons) > 0 {
+			matches = append(matches, OrganizationMatch{Organization: candidate, Reasons: reasons})
+		}
+	}
+	return matches, nil
+}
+
+// OrganizationReasons returns why candidate is likely the same company as
+// organization, or nothing if it is not.
+func OrganizationReasons(organization Organization, candidate models.Organization) []string {
+	domain := WebsiteDomain(organization.Website)
+	if domain == "" {
+		domain = EmailDomain(organization.Email)
+	}
+	name := NormalizeCompanyName(organization.Name)
+
+	var reasons []string
+	if domain != "" {
+		candidateDomain := WebsiteDomain(candidate.OrganizationWebsite)
+		if candidateDomain == "" {
+			candidateDomain = EmailDomain(candidate.OrganizationEmail)
+		}
+		if candidateDomain == domain {
+			reasons = append(reasons, ReasonSameDomain)
+		}
+	}
+	if name != "" {
+		candidateName := NormalizeCompanyName(candidate.OrganizationName)
+		if candidateName == name {
+			reasons = append(reasons, ReasonSameCompanyName)
+		} else if Similarity(candidateName, name) >= nameThreshold {
+			reasons = append(reasons, ReasonSimilarCompany)
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,128 @@
+package dedupe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MergeLeads moves the activities, deals, documents, stage history and
+// assignment log of duplicates onto survivor, fills survivor's blank fields
+// from them and deletes them. Run it in a transaction.
+func MergeLeads(tx *gorm.DB, survivor *models.Lead, duplicates []models.Lead) error {
+	ids := make([]uuid.UUID, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		if duplicate.ID == survivor.ID {
+			return fmt.Errorf("lead %s cannot be merged into itself", survivor.ID)
+		}
+		ids = append(ids, duplicate.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// --- Re-parent related records ---
+	reparent := []struct {
+		model  interface{}
+		column string
+	}{
+		{&models.Activity{}, "lead_id"},
+		{&models.Deal{}, "lead_id"},
+		{&models.LeadStageHistory{}, "lead_id"},
+		{&models.LeadAssignmentLog{}, "lead_id"},
+		{&models.Document{}, "reference_id"},
+	}
+	for _, r := range reparent {
+		if err := tx.Model(r.model).Where(r.column+" IN ?", ids).Update(r.column, survivor.ID).Error; err != nil {
+			return fmt.Errorf("failed to move %T records: %w", r.model, err)
+		}
+	}
+
+	// --- Keep what the survivor is missing ---
+	FillLead(survivor, duplicates)
+	if err := tx.Select("email", "phone", "linked_in", "country", "lead_source", "organization_id", "campaign_id", "lead_notes").
+		Updates(survivor).Error; err != nil {
+		return fmt.Errorf("failed to update surviving lead: %w", err)
+	}
+
+	if err := tx.Where("id IN ?", ids).Delete(&models.Lead{}).Error; err != nil {
+		return fmt.Errorf("failed to delete merged leads: %w", err)
+	}
+	return nil
+}
+
+// MergeOrganizations moves the leads and documents of duplicates onto survivor,
+// fills survivor's blank fields from them and deletes them. Run it in a transaction.
+func MergeOrganizations(tx *gorm.DB, survivor *models.Organization, duplicates []models.Organization) error {
+	ids := make([]uuid.UUID, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		if duplicate.ID == survivor.ID {
+			return fmt.Errorf("organization %s cannot be merged into itself", survivor.ID)
+		}
+		ids = append(ids, duplicate.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&models.Lead{}).Where("organization_id IN ?", ids).Update("organization_id", survivor.ID).Error; err != nil {
+		return fmt.Errorf("failed to move leads: %w", err)
+	}
+	if err := tx.Model(&models.Document{}).Where("reference_id IN ?", ids).Update("reference_id", survivor.ID).Error; err != nil {
+		return fmt.Errorf("failed to move documents: %w", err)
+	}
+
+	FillOrganization(survivor, duplicates)
+	if err := tx.Save(survivor).Error; err != nil {
+		return fmt.Errorf("failed to update surviving organization: %w", err)
+	}
+
+	if err := tx.Where("id IN ?", ids).Delete(&models.Organization{}).Error; err != nil {
+		return fmt.Errorf("failed to delete merged organizations: %w", err)
+	}
+	return nil
+}
+
+// FillLead fills the blank fields of survivor from duplicates and appends their
+// notes to its own.
+func FillLead(survivor *models.Lead, duplicates []models.Lead) {
+	for _, duplicate := range duplicates {
+		fillBlank(&survivor.Email, duplicate.Email)
+		fillBlank(&survivor.Phone, duplicate.Phone)
+		fillBlank(&survivor.LinkedIn, duplicate.LinkedIn)
+		fillBlank(&survivor.Country, duplicate.Country)
+		fillBlank(&survivor.LeadSource, duplicate.LeadSource)
+		if survivor.OrganizationID == uuid.Nil {
+			survivor.OrganizationID = duplicate.OrganizationID
+		}
+		if survivor.CampaignID == uuid.Nil {
+			survivor.CampaignID = duplicate.CampaignID
+		}
+		if notes := strings.TrimSpace(duplicate.LeadNotes); notes != "" && !strings.Contains(survivor.LeadNotes, notes) {
+			survivor.LeadNotes = strings.TrimSpace(survivor.LeadNotes + "\n" + notes)
+		}
+	}
+}
+
+// FillOrganization fills the blank fields of survivor from duplicates.
+func FillOrganization(survivor *models.Organization, duplicates []models.Organization) {
+	for _, duplicate := range duplicates {
+		fillBlank(&survivor.OrganizationEmail, duplicate.OrganizationEmail)
+		fillBlank(&survivor.OrganizationWebsite, duplicate.OrganizationWebsite)
+		fillBlank(&survivor.City, duplicate.City)
+		fillBlank(&survivor.Country, duplicate.Country)
+		fillBlank(&survivor.NoOfEmployees, duplicate.NoOfEmployees)
+		if survivor.AnnualRevenue.Amount.IsZero() && !duplicate.AnnualRevenue.Amount.IsZero() {
+			survivor.AnnualRevenue = duplicate.AnnualRevenue
+		}
+	}
+}
+
+func fillBlank(target *string, value string) {
+	if strings.TrimSpace(*target) == "" {
+		*target = value
+	}
+}
@@ -0,0 +1,126 @@
+package dedupe
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// freeMailDomains are shared by unrelated people, so they never link records.
+var freeMailDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true, "yahoo.com": true, "outlook.com": true,
+	"hotmail.com": true, "live.com": true, "icloud.com": true, "me.com": true,
+	"aol.com": true, "proton.me": true, "protonmail.com": true, "gmx.com": true,
+	"yandex.com": true, "zoho.com": true, "mail.com": true, "rediffmail.com": true,
+}
+
+// companySuffixes are dropped before organization names are compared.
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "ltd": true, "limited": true,
+	"pvt": true, "private": true, "corp": true, "corporation": true, "co": true,
+	"company": true, "gmbh": true, "plc": true, "sa": true, "ag": true, "bv": true,
+}
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+// NormalizeEmail lowercases and trims an email address.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone keeps the last 10 digits, so "+1 (555) 010-2030" and
+// "555-010-2030" compare equal. Numbers with fewer than 7 digits give "".
+func NormalizePhone(phone string) string {
+	digits := nonDigits.ReplaceAllString(phone, "")
+	if len(digits) < 7 {
+		return ""
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
+
+// EmailDomain returns the domain of an email address, or "" for free-mail
+// providers and malformed addresses.
+func EmailDomain(email string) string {
+	email = NormalizeEmail(email)
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return ""
+	}
+	domain := strings.TrimPrefix(email[at+1:], "www.")
+	if freeMailDomains[domain] {
+		return ""
+	}
+	return domain
+}
+
+// WebsiteDomain returns the host of a website without "www.", e.g.
+// "https://www.Acme.com/about" gives "acme.com".
+func WebsiteDomain(website string) string {
+	website = strings.ToLower(strings.TrimSpace(website))
+	if website == "" {
+		return ""
+	}
+	if !strings.Contains(website, "://") {
+		website = "http://" + website
+	}
+	parsed, err := url.Parse(website)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
+
+// normalizeName lowercases, strips punctuation and collapses whitespace.
+func normalizeName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// NormalizeCompanyName is normalizeName without legal suffixes such as "Inc" or "Pvt Ltd".
+func NormalizeCompanyName(name string) string {
+	fields := strings.Fields(normalizeName(name))
+	for len(fields) > 1 && companySuffixes[fields[len(fields)-1]] {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Similarity compares two normalized names: 1 means equal, 0 means nothing in common.
+// It is the Levenshtein distance scaled by the length of the longer name.
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
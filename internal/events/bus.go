@@ -0,0 +1,234 @@
+// Package events is the in-process event bus. Mutations publish what happened
+// once their transaction is committed; GraphQL subscriptions listen for it.
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/google/uuid"
+)
+
+// Event types. They double as the event names webhooks subscribe to.
+const (
+	LeadCreated       = "lead.created"
+	LeadUpdated       = "lead.updated"
+	LeadDeleted       = "lead.deleted"
+	LeadAssigned      = "lead.assigned"
+	LeadStageChanged  = "lead.stage_changed"
+	DealCreated       = "deal.created"
+	DealUpdated       = "deal.updated"
+	DealDeleted       = "deal.deleted"
+	DealStatusChanged = "deal.status_changed"
+	TaskCreated       = "task.created"
+	TaskUpdated       = "task.updated"
+	TaskDeleted       = "task.deleted"
+	TaskDueSoon       = "task.due_soon"
+	VendorCreated     = "vendor.created"
+	VendorUpdated     = "vendor.updated"
+	VendorDeleted     = "vendor.deleted"
+)
+
+// Types lists every event type.
+var Types = []string{
+	LeadCreated, LeadUpdated, LeadDeleted, LeadAssigned, LeadStageChanged,
+	DealCreated, DealUpdated, DealDeleted, DealStatusChanged,
+	TaskCreated, TaskUpdated, TaskDeleted, TaskDueSoon,
+	VendorCreated, VendorUpdated, VendorDeleted,
+}
+
+// bufferSize is how many events a subscriber may lag behind before events are
+// dropped for it, or queued for a reliable subscriber.
+const bufferSize = 64
+
+// Event is something that happened to a record.
+type Event struct {
+	Type       string
+	EntityID   uuid.UUID // The lead, deal or task
+	UserID     uuid.UUID // Who the event is meant for, e.g. the new assignee; uuid.Nil for anyone
+	ActorID    uuid.UUID // Who caused it; uuid.Nil for the system
+	CampaignID uuid.UUID // The lead's campaign, for lead events
+	From       string    // Previous stage or status
+	To         string    // New stage or status
+	At         time.Time
+	Data       interface{} // The record as the mutation returned it, for webhook payloads
+}
+
+type subscriber struct {
+	types map[string]bool
+	ch    chan Event
+
+	// reliable subscribers get every event: Publish queues them in pending, and
+	// a goroutine forwards them to ch as fast as the subscriber reads
+	reliable bool
+	mu       sync.Mutex
+	pending  []Event
+	queued   chan struct{}
+}
+
+// Bus fans events out to subscribers. Publishing never blocks: a subscriber that
+// falls behind misses events rather than slowing the mutation down, unless it
+// subscribed with SubscribeReliable, in which case its events queue up.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewBus returns an empty bus.
+func NewBus() *Bus {
+	return &Bus{subscribers: map[*subscriber]struct{}{}}
+}
+
+// Publish sends event to every subscriber of its type.
+func (b *Bus) Publish(event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers {
+		if s.types != nil && !s.types[event.Type] {
+			continue
+		}
+		if s.reliable {
+			s.mu.Lock()
+			s.pending = append(s.pending, event)
+			s.mu.Unlock()
+			select {
+			case s.queued <- struct{}{}:
+			default:
+			}
+			continue
+		}
+		select {
+		case s.ch <- event:
+		default:
+			log.Printf("Dropping %s event for %s: subscriber is not keeping up", event.Type, event.EntityID)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving events of the given types, or of every
+// type if none are given, until ctx is done. Events are dropped while the
+// listener lags bufferSize events behind.
+func (b *Bus) Subscribe(ctx context.Context, types ...string) <-chan Event {
+	s := b.subscribe(types, false)
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(s)
+		close(s.ch)
+	}()
+	return s.ch
+}
+
+// SubscribeReliable is Subscribe for listeners that must not miss an event,
+// such as the webhooks. Events the listener is not ready for are queued in
+// memory, however many there are, so it can catch up after a burst like an import.
+func (b *Bus) SubscribeReliable(ctx context.Context, types ...string) <-chan Event {
+	s := b.subscribe(types, true)
+	go func() {
+		defer close(s.ch)
+		defer b.unsubscribe(s)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.queued:
+			}
+			for {
+				s.mu.Lock()
+				pending := s.pending
+				s.pending = nil
+				s.mu.Unlock()
+				if len(pending) == 0 {
+					break
+				}
+				for _, event := range pending {
+					select {
+					case s.ch <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return s.ch
+}
+
+func (b *Bus) subscribe(types []string, reliable bool) *subscriber {
+	s := &subscriber{ch: make(chan Event, bufferSize), reliable: reliable, queued: make(chan struct{}, 1)}
+	if len(types) > 0 {
+		s.types = map[string]bool{}
+		for _, t := range types {
+			s.types[t] = true
+		}
+	}
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+func (b *Bus) unsubscribe(s *subscriber) {
+	b.mu.Lock()
+	delete(b.subscribers, s)
+	b.mu.Unlock()
+}
+
+// Default is the bus the application publishes to.
+var Default = NewBus()
+
+// Publish sends event on the Default bus.
+func Publish(event Event) {
+	Default.Publish(event)
+}
+
+// Subscribe listens on the Default bus.
+func Subscribe(ctx context.Context, types ...string) <-chan Event {
+	return Default.Subscribe(ctx, types...)
+}
+
+// SubscribeReliable listens on the Default bus without missing events.
+func SubscribeReliable(ctx context.Context, types ...string) <-chan Event {
+	return Default.SubscribeReliable(ctx, types...)
+}
+
+// Stream subscribes to the given event types and sends what load makes of each
+// event, skipping the events load rejects. The channel is closed when ctx is done.
+// It is the shape GraphQL subscription resolvers return.
+func Stream[T any](ctx context.Context, load func(Event) (T, bool), types ...string) <-chan T {
+	events := Subscribe(ctx, types...)
+	out := make(chan T, 1)
+	go func() {
+		defer close(out)
+		for event := range events {
+			value, ok := load(event)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ActorOf returns the id of the user in ctx, or uuid.Nil when there is none.
+func ActorOf(ctx context.Context) uuid.UUID {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return uuid.Nil
+	}
+	actorID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return actorID
+}
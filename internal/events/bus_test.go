@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSubscribeReliableKeepsEveryEvent(t *testing.T) {
+	bus := NewBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reliable := bus.SubscribeReliable(ctx, LeadCreated)
+	lossy := bus.Subscribe(ctx, LeadCreated)
+
+	// Far more than either buffer holds, published before anyone reads
+	const published = 10 * bufferSize
+	ids := make([]uuid.UUID, published)
+	for i := range ids {
+		ids[i] = uuid.New()
+		bus.Publish(Event{Type: LeadCreated, EntityID: ids[i]})
+	}
+	bus.Publish(Event{Type: LeadUpdated, EntityID: uuid.New()})
+
+	for i, id := range ids {
+		if event := <-reliable; event.EntityID != id {
+			t.Fatalf("event %d = %s, want %s", i, event.EntityID, id)
+		}
+	}
+	if len(lossy) != bufferSize {
+		t.Errorf("lossy subscriber holds %d events, want the %d that fit its buffer", len(lossy), bufferSize)
+	}
+
+	cancel()
+	for range reliable {
+		t.Error("event after the last one published")
+	}
+}
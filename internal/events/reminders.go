@@ -0,0 +1,58 @@
+package events
+
+import (
+	"log"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/audit"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"gorm.io/gorm"
+)
+
+// RemindDueTasks publishes a TaskDueSoon event for every open task that comes
+// within window of its due date, checking every interval. Each task is reminded
+// once per due date; it blocks forever, run it in its own goroutine.
+func RemindDueTasks(db *gorm.DB, interval, window time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := remindDueTasks(db, window); err != nil {
+			log.Printf("Error sending task reminders: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+func remindDueTasks(db *gorm.DB, window time.Duration) error {
+	now := time.Now()
+	var tasks []models.Task
+	if err := db.Preload("User").
+		Where("due_date IS NOT NULL AND due_date > ? AND due_date <= ?", now, now.Add(window)).
+		Where("status <> ?", models.COMPLETED).
+		Where("reminded_at IS NULL").
+		Find(&tasks).Error; err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		// Mark first, so a task is never reminded twice even if two instances race
+		result := audit.Skip(db).Model(&models.Task{}).
+			Where("id = ? AND reminded_at IS NULL", task.ID).
+			UpdateColumn("reminded_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		Publish(Event{
+			Type:     TaskDueSoon,
+			EntityID: task.ID,
+			UserID:   task.UserID,
+			To:       string(task.Status),
+			At:       now,
+			Data:     utils.ConvertTask(task),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxDepth is how deep nested objects are flattened, e.g. "organization.organizationName".
+const maxDepth = 1
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// hiddenFields are never exported, wherever they appear.
+var hiddenFields = map[string]bool{
+	"password": true,
+}
+
+// column is a flattened scalar field of a list item.
+type column struct {
+	Name  string
+	Index [][]int // Field index per nesting level
+}
+
+// columnsOf lists the exportable columns of a GraphQL type in field order. Nested
+// objects are flattened with dotted names; lists of objects are left out.
+func columnsOf(t reflect.Type) []column {
+	return appendColumns(nil, t, "", nil, 0)
+}
+
+func appendColumns(columns []column, t reflect.Type, prefix string, index [][]int, depth int) []column {
+	t = indirect(t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "" || name == "-" || hiddenFields[name] {
+			continue
+		}
+		path := append(append([][]int{}, index...), field.Index)
+		fieldType := indirect(field.Type)
+		switch {
+		case isScalar(fieldType):
+			columns = append(columns, column{Name: prefix + name, Index: path})
+		case fieldType.Kind() == reflect.Slice && isScalar(indirect(fieldType.Elem())):
+			columns = append(columns, column{Name: prefix + name, Index: path})
+		case fieldType.Kind() == reflect.Struct && depth < maxDepth:
+			columns = appendColumns(columns, fieldType, prefix+name+".", path, depth+1)
+		}
+	}
+	return columns
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isScalar(t reflect.Type) bool {
+	if t.Implements(stringerType) || reflect.PointerTo(t).Implements(stringerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// value reads the column from item. Missing nested objects give nil.
+func (c column) value(item reflect.Value) interface{} {
+	v := item
+	for _, index := range c.Index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.FieldByIndex(index)
+	}
+	return cell(v)
+}
+
+// cell converts a field to a value the writers understand: string, bool, int64, float64 or nil.
+func cell(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(stringerType) {
+		return v.Interface().(fmt.Stringer).String()
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(stringerType) {
+		return v.Addr().Interface().(fmt.Stringer).String()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if value := cell(v.Index(i)); value != nil {
+				parts = append(parts, fmt.Sprint(value))
+			}
+		}
+		return strings.Join(parts, "; ")
+	}
+	return nil
+}
+
+// selectColumns picks the requested columns in the requested order, or all of them.
+func selectColumns(all []column, names []string) ([]column, error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+	byName := make(map[string]column, len(all))
+	for _, c := range all {
+		byName[strings.ToLower(c.Name)] = c
+	}
+	selected := make([]column, 0, len(names))
+	for _, name := range names {
+		c, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
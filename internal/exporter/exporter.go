@@ -0,0 +1,229 @@
+// Package exporter writes every row of a list query to CSV, XLSX or NDJSON.
+// Small exports are streamed in the response; large ones run as a
+// models.ExportJob whose file is stored as a models.Document.
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// pageSize is how many rows are fetched from the list resolver at a time.
+const pageSize = 500
+
+// BackgroundThreshold is the row count above which an export runs as a job.
+const BackgroundThreshold = 10000
+
+// exportDir is where background export files are kept. It is outside the
+// served uploads directory: only the user who ran an export downloads its file,
+// through /download.
+var exportDir = filepath.Join("storage", "exports")
+
+// Request is what the client asks to export. Filter and Sort are the GraphQL
+// filter and sort inputs of the query, as JSON.
+type Request struct {
+	Query      string          `json:"query"`
+	Filter     json.RawMessage `json:"filter"`
+	Sort       json.RawMessage `json:"sort"`
+	Columns    []string        `json:"columns"`
+	Format     string          `json:"format"`
+	Background bool            `json:"background"`
+}
+
+// Prepare checks the request and normalizes its format.
+func Prepare(sources map[string]Source, request *Request) (Source, error) {
+	source, ok := sources[request.Query]
+	if !ok {
+		return Source{}, fmt.Errorf("query %q cannot be exported", request.Query)
+	}
+	request.Format = strings.ToUpper(strings.TrimSpace(request.Format))
+	if request.Format == "" {
+		request.Format = FormatCSV
+	}
+	if _, ok := formats[request.Format]; !ok {
+		return Source{}, fmt.Errorf("unknown export format %q, expected CSV, XLSX or NDJSON", request.Format)
+	}
+	if _, err := selectColumns(source.columns, request.Columns); err != nil {
+		return Source{}, err
+	}
+	return source, nil
+}
+
+// Count returns how many rows the export has, or -1 when the query does not report it.
+func Count(ctx context.Context, source Source, request Request) (int, error) {
+	_, total, err := source.fetch(ctx, request.Filter, request.Sort, 1, 1)
+	return int(total), err
+}
+
+// Export writes every row matching the request to w and returns the row count.
+func Export(ctx context.Context, w io.Writer, source Source, request Request) (int, error) {
+	columns, err := selectColumns(source.columns, request.Columns)
+	if err != nil {
+		return 0, err
+	}
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Name
+	}
+	writer, err := newRowWriter(request.Format, w, header)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	values := make([]interface{}, len(columns))
+	for page := int32(1); ; page++ {
+		items, _, err := source.fetch(ctx, request.Filter, request.Sort, page, pageSize)
+		if err != nil {
+			return rows, err
+		}
+		for i := 0; i < items.Len(); i++ {
+			for j, c := range columns {
+				values[j] = c.value(items.Index(i))
+			}
+			if err := writer.WriteRow(values); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if items.Len() < pageSize {
+			break
+		}
+	}
+	return rows, writer.Close()
+}
+
+// Start records a background export for the user in ctx and runs it.
+func Start(ctx context.Context, db *gorm.DB, sources map[string]Source, request Request) (*models.ExportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	job := models.ExportJob{
+		ID:        uuid.New(),
+		Query:     request.Query,
+		Filter:    string(request.Filter),
+		Sort:      string(request.Sort),
+		Columns:   request.Columns,
+		Format:    request.Format,
+		Status:    models.ExportStatusPending,
+		CreatedBy: parsedUserID,
+	}
+	if err := db.Create(&job).Error; err != nil {
+		return nil, fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	// The request ends before the job does, keep only the caller's identity
+	background := context.WithValue(context.Background(), auth.UserCtxKey, ctx.Value(auth.UserCtxKey))
+	go func() {
+		if err := Run(background, db, sources, job.ID); err != nil {
+			log.Printf("Error running export job %s: %v", job.ID, err)
+		}
+	}()
+	return &job, nil
+}
+
+// Run writes the job's file and records it as a Document owned by the job's creator.
+func Run(ctx context.Context, db *gorm.DB, sources map[string]Source, jobID uuid.UUID) (err error) {
+	var job models.ExportJob
+	if err := db.First(&job, "id = ?", jobID).Error; err != nil {
+		return err
+	}
+	fail := func(cause error) error {
+		finished := time.Now()
+		job.Status = models.ExportStatusFailed
+		job.Error = cause.Error()
+		job.FinishedAt = &finished
+		if err := db.Select("status", "error", "finished_at").Save(&job).Error; err != nil {
+			log.Printf("Error saving failed export job %s: %v", job.ID, err)
+		}
+		return cause
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fail(fmt.Errorf("internal error: %v", recovered))
+		}
+	}()
+
+	started := time.Now()
+	job.Status = models.ExportStatusRunning
+	job.StartedAt = &started
+	if err := db.Select("status", "started_at").Save(&job).Error; err != nil {
+		return err
+	}
+
+	request := Request{
+		Query:   job.Query,
+		Filter:  json.RawMessage(job.Filter),
+		Sort:    json.RawMessage(job.Sort),
+		Columns: job.Columns,
+		Format:  job.Format,
+	}
+	source, err := Prepare(sources, &request)
+	if err != nil {
+		return fail(err)
+	}
+	if err := auth.RequirePermission(ctx, source.Permission); err != nil {
+		return fail(err)
+	}
+
+	if err := os.MkdirAll(exportDir, os.ModePerm); err != nil {
+		return fail(errors.New("failed to create export directory"))
+	}
+	filePath := filepath.Join(exportDir, job.ID.String()+Extension(job.Format))
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fail(errors.New("failed to create export file"))
+	}
+	rows, err := Export(ctx, file, source, request)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return fail(err)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fail(errors.New("failed to read export file"))
+	}
+	document := models.Document{
+		ID:            uuid.New(),
+		Title:         fmt.Sprintf("%s-%s%s", strings.TrimPrefix(job.Query, "get"), started.Format("20060102-150405"), Extension(job.Format)),
+		UserID:        job.CreatedBy,
+		FilePath:      filePath,
+		FileSize:      fmt.Sprintf("%d bytes", info.Size()),
+		FileType:      Extension(job.Format),
+		ReferenceID:   job.ID,
+		ReferenceType: models.ExportDocumentType,
+	}
+	if err := db.WithContext(ctx).Create(&document).Error; err != nil {
+		os.Remove(filePath)
+		return fail(fmt.Errorf("failed to record export file: %w", err))
+	}
+
+	finished := time.Now()
+	job.Status = models.ExportStatusCompleted
+	job.RowCount = rows
+	job.DocumentID = &document.ID
+	job.FinishedAt = &finished
+	return db.Select("status", "row_count", "document_id", "finished_at").Save(&job).Error
+}
@@ -0,0 +1,139 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+)
+
+// Source is an exportable list query. It runs the list resolver itself, so
+// filters, sorting and visibility rules are exactly those of the GraphQL query.
+type Source struct {
+	Permission string // Same as the query's @hasPermission
+	columns    []column
+	// fetch returns one page of items as a slice, and the total count or -1 when unknown
+	fetch func(ctx context.Context, filter, sort json.RawMessage, page, pageSize int32) (reflect.Value, int32, error)
+}
+
+// Columns lists the names of the columns the source can export.
+func (s Source) Columns() []string {
+	names := make([]string, 0, len(s.columns))
+	for _, c := range s.columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// list builds a Source from a list resolver adapted to the (filter, pagination, sort) order.
+func list[F, S, I any](permission string, fetch func(context.Context, *F, *generated.PaginationInput, *S) ([]I, int32, error)) Source {
+	return Source{
+		Permission: permission,
+		columns:    columnsOf(reflect.TypeOf((*I)(nil)).Elem()),
+		fetch: func(ctx context.Context, rawFilter, rawSort json.RawMessage, page, pageSize int32) (reflect.Value, int32, error) {
+			filter, err := decode[F](rawFilter)
+			if err != nil {
+				return reflect.Value{}, 0, fmt.Errorf("invalid filter: %w", err)
+			}
+			sort, err := decode[S](rawSort)
+			if err != nil {
+				return reflect.Value{}, 0, fmt.Errorf("invalid sort: %w", err)
+			}
+			items, total, err := fetch(ctx, filter, &generated.PaginationInput{Page: page, PageSize: pageSize}, sort)
+			if err != nil {
+				return reflect.Value{}, 0, err
+			}
+			return reflect.ValueOf(items), total, nil
+		},
+	}
+}
+
+// decode reads a filter or sort payload, which uses the GraphQL input field names.
+func decode[T any](raw json.RawMessage) (*T, error) {
+	if len(bytes.TrimSpace(raw)) == 0 || string(bytes.TrimSpace(raw)) == "null" {
+		return nil, nil
+	}
+	var value T
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Sources returns the exportable queries by GraphQL field name.
+func Sources(q generated.QueryResolver) map[string]Source {
+	return map[string]Source{
+		"getUsers": list("user:read", func(ctx context.Context, f *generated.UserFilter, p *generated.PaginationInput, s *generated.UserSortInput) ([]*generated.User, int32, error) {
+			page, err := q.GetUsers(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getCampaigns": list("campaign:read", func(ctx context.Context, f *generated.CampaignFilter, p *generated.PaginationInput, s *generated.CampaignSortInput) ([]*generated.Campaign, int32, error) {
+			page, err := q.GetCampaigns(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getLeads": list("lead:read:own", func(ctx context.Context, f *generated.LeadFilter, p *generated.PaginationInput, s *generated.LeadSortInput) ([]*generated.Lead, int32, error) {
+			page, err := q.GetLeads(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getOrganizations": list("organization:read", func(ctx context.Context, f *generated.OrganizationFilter, p *generated.PaginationInput, s *generated.OrganizationSortInput) ([]*generated.Organization, int32, error) {
+			page, err := q.GetOrganizations(ctx, f, s, p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getResourceProfiles": list("resource:read", func(ctx context.Context, f *generated.ResourceProfileFilter, p *generated.PaginationInput, s *generated.ResourceProfileSortInput) ([]*generated.ResourceProfile, int32, error) {
+			page, err := q.GetResourceProfiles(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getVendors": list("vendor:read", func(ctx context.Context, f *generated.VendorFilter, p *generated.PaginationInput, s *generated.VendorSortInput) ([]*generated.Vendor, int32, error) {
+			page, err := q.GetVendors(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getTasks": list("task:read", func(ctx context.Context, f *generated.TaskFilter, p *generated.PaginationInput, s *generated.TaskSortInput) ([]*generated.Task, int32, error) {
+			page, err := q.GetTasks(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getTasksByUser": list("task:read", func(ctx context.Context, f *generated.TaskFilter, p *generated.PaginationInput, s *generated.TaskSortInput) ([]*generated.Task, int32, error) {
+			page, err := q.GetTasksByUser(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getCaseStudies": list("casestudy:read", func(ctx context.Context, f *generated.CaseStudyFilter, p *generated.PaginationInput, s *generated.CaseStudySortInput) ([]*generated.CaseStudy, int32, error) {
+			page, err := q.GetCaseStudies(ctx, f, p, s)
+			if err != nil {
+				return nil, 0, err
+			}
+			return page.Items, page.TotalCount, nil
+		}),
+		"getDeals": list("deal:read", func(ctx context.Context, f *generated.DealFilter, p *generated.PaginationInput, s *generated.DealSortInput) ([]*generated.Deal, int32, error) {
+			deals, err := q.GetDeals(ctx, f, p, s)
+			return deals, -1, err
+		}),
+	}
+}
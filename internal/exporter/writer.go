@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Export formats.
+const (
+	FormatCSV    = "CSV"
+	FormatXLSX   = "XLSX"
+	FormatNDJSON = "NDJSON"
+)
+
+// formats maps each format to its file extension and content type.
+var formats = map[string]struct{ Extension, ContentType string }{
+	FormatCSV:    {".csv", "text/csv"},
+	FormatXLSX:   {".xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	FormatNDJSON: {".ndjson", "application/x-ndjson"},
+}
+
+// Extension returns the file extension of format.
+func Extension(format string) string { return formats[format].Extension }
+
+// ContentType returns the MIME type of format.
+func ContentType(format string) string { return formats[format].ContentType }
+
+type rowWriter interface {
+	WriteRow(values []interface{}) error
+	// Close flushes what is buffered; XLSX workbooks are only written here
+	Close() error
+}
+
+func newRowWriter(format string, w io.Writer, header []string) (rowWriter, error) {
+	switch format {
+	case FormatCSV:
+		writer := &csvWriter{w: csv.NewWriter(w)}
+		return writer, writer.w.Write(header)
+	case FormatNDJSON:
+		return &ndjsonWriter{w: w, header: header}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, header)
+	}
+	return nil, fmt.Errorf("unknown export format %q, expected CSV, XLSX or NDJSON", format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		if value != nil {
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonWriter writes one JSON object per line, keys in column order.
+type ndjsonWriter struct {
+	w      io.Writer
+	header []string
+	buf    bytes.Buffer
+}
+
+func (n *ndjsonWriter) WriteRow(values []interface{}) error {
+	n.buf.Reset()
+	n.buf.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			n.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(n.header[i])
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		n.buf.Write(key)
+		n.buf.WriteByte(':')
+		n.buf.Write(encoded)
+	}
+	n.buf.WriteString("}\n")
+	_, err := n.w.Write(n.buf.Bytes())
+	return err
+}
+
+func (n *ndjsonWriter) Close() error { return nil }
+
+// xlsxWriter streams rows into a single sheet, then writes the workbook on Close.
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, header []string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return nil, err
+	}
+	writer := &xlsxWriter{w: w, file: file, stream: stream}
+	values := make([]interface{}, len(header))
+	for i, name := range header {
+		values[i] = name
+	}
+	return writer, writer.WriteRow(values)
+}
+
+func (x *xlsxWriter) WriteRow(values []interface{}) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.w)
+}
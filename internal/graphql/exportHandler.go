@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/exporter"
+)
+
+// exportFileHandler exports every row of a list query.
+//
+// The JSON body is an exporter.Request:
+//   - query: the list query, e.g. getLeads
+//   - filter, sort: the query's filter and sort inputs, as in GraphQL
+//   - columns: optional column names, see getExportColumns; all columns by default
+//   - format: CSV (default), XLSX or NDJSON
+//   - background: run as an export job even for small exports
+//
+// Exports up to exporter.BackgroundThreshold rows are streamed in the response.
+// Larger ones answer 202 with a job ID; poll getExportJob for the file.
+func (s *server) exportFileHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Only POST requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := auth.RequirePermission(r.Context(), "export:run"); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var request exporter.Request
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	sources := exporter.Sources(s.resolver.Query())
+	source, err := exporter.Prepare(sources, &request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := auth.RequirePermission(r.Context(), source.Permission); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	background := request.Background
+	if !background {
+		count, err := exporter.Count(r.Context(), source, request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		background = count > exporter.BackgroundThreshold
+	}
+	if background {
+		job, err := exporter.Start(r.Context(), s.db, sources, request)
+		if err != nil {
+			http.Error(w, "Failed to start export job", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jobID":  job.ID,
+			"status": job.Status,
+		})
+		fmt.Println("Export job started:", job.ID)
+		return
+	}
+
+	fileName := fmt.Sprintf("%s-%s%s", strings.TrimPrefix(request.Query, "get"), time.Now().Format("20060102-150405"), exporter.Extension(request.Format))
+	w.Header().Set("Content-Type", exporter.ContentType(request.Format))
+	w.Header().Set("Content-Disposition", "attachment; filename="+fileName)
+	if _, err := exporter.Export(r.Context(), w, source, request); err != nil {
+		// Headers are gone once rows are written, the client sees a truncated file
+		fmt.Println("Error exporting", request.Query+":", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+
+	"github.com/Zenithive/it-crm-backend/models"
 )
 
 type Activity struct {
@@ -19,9 +21,74 @@ type Activity struct {
 	LeadID               string `json:"leadID"`
 }
 
+type ActivityConnection struct {
+	Edges    []*ActivityEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type ActivityEdge struct {
+	Node   *Activity `json:"node"`
+	Cursor string    `json:"cursor"`
+}
+
+type AssignmentRule struct {
+	RuleID     string             `json:"ruleID"`
+	Name       string             `json:"name"`
+	Priority   int32              `json:"priority"`
+	Enabled    bool               `json:"enabled"`
+	Strategy   AssignmentStrategy `json:"strategy"`
+	Countries  []string           `json:"countries"`
+	CampaignID *string            `json:"campaignID,omitempty"`
+	TeamID     *string            `json:"teamID,omitempty"`
+	Users      []*User            `json:"users"`
+}
+
+type AssignmentRuleInput struct {
+	Name       string             `json:"name"`
+	Priority   int32              `json:"priority"`
+	Enabled    *bool              `json:"enabled,omitempty"`
+	Strategy   AssignmentStrategy `json:"strategy"`
+	Countries  []string           `json:"countries,omitempty"`
+	CampaignID *string            `json:"campaignID,omitempty"`
+	TeamID     *string            `json:"teamID,omitempty"`
+	UserIDs    []string           `json:"userIDs,omitempty"`
+}
+
+type AuditChange struct {
+	Field string  `json:"field"`
+	From  *string `json:"from,omitempty"`
+	To    *string `json:"to,omitempty"`
+}
+
+type AuditEvent struct {
+	AuditEventID string         `json:"auditEventID"`
+	Actor        *User          `json:"actor,omitempty"`
+	ActorRole    *string        `json:"actorRole,omitempty"`
+	EntityType   string         `json:"entityType"`
+	EntityID     string         `json:"entityID"`
+	Action       AuditAction    `json:"action"`
+	Changes      []*AuditChange `json:"changes"`
+	OccurredAt   string         `json:"occurredAt"`
+}
+
+type AuditEventPage struct {
+	Items      []*AuditEvent `json:"items"`
+	TotalCount int32         `json:"totalCount"`
+}
+
+type AuditLogFilter struct {
+	EntityType *string      `json:"entityType,omitempty"`
+	EntityID   *string      `json:"entityID,omitempty"`
+	ActorID    *string      `json:"actorID,omitempty"`
+	Action     *AuditAction `json:"action,omitempty"`
+	From       *string      `json:"from,omitempty"`
+	To         *string      `json:"to,omitempty"`
+}
+
 type AuthPayload struct {
-	Token string `json:"token"`
-	User  *User  `json:"user"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	User         *User  `json:"user"`
 }
 
 type Campaign struct {
@@ -88,13 +155,13 @@ type CreateCaseStudyInput struct {
 }
 
 type CreateDealInput struct {
-	DealName            string     `json:"dealName"`
-	LeadID              string     `json:"leadID"`
-	DealStartDate       string     `json:"dealStartDate"`
-	DealEndDate         string     `json:"dealEndDate"`
-	ProjectRequirements string     `json:"projectRequirements"`
-	DealAmount          string     `json:"dealAmount"`
-	DealStatus          DealStatus `json:"dealStatus"`
+	DealName            string       `json:"dealName"`
+	LeadID              string       `json:"leadID"`
+	DealStartDate       string       `json:"dealStartDate"`
+	DealEndDate         string       `json:"dealEndDate"`
+	ProjectRequirements string       `json:"projectRequirements"`
+	DealAmount          models.Money `json:"dealAmount"`
+	DealStatus          DealStatus   `json:"dealStatus"`
 }
 
 type CreateLeadInput struct {
@@ -106,7 +173,7 @@ type CreateLeadInput struct {
 	Phone              string       `json:"phone"`
 	LeadSource         string       `json:"leadSource"`
 	InitialContactDate string       `json:"initialContactDate"`
-	LeadAssignedTo     string       `json:"leadAssignedTo"`
+	LeadAssignedTo     *string      `json:"leadAssignedTo,omitempty"`
 	LeadStage          LeadStage    `json:"leadStage"`
 	LeadNotes          string       `json:"leadNotes"`
 	LeadPriority       LeadPriority `json:"leadPriority"`
@@ -124,7 +191,7 @@ type CreateLeadWithActivityInput struct {
 	Phone                string       `json:"phone"`
 	LeadSource           string       `json:"leadSource"`
 	InitialContactDate   string       `json:"initialContactDate"`
-	LeadAssignedTo       string       `json:"leadAssignedTo"`
+	LeadAssignedTo       *string      `json:"leadAssignedTo,omitempty"`
 	LeadStage            LeadStage    `json:"leadStage"`
 	LeadNotes            string       `json:"leadNotes"`
 	LeadPriority         LeadPriority `json:"leadPriority"`
@@ -140,13 +207,13 @@ type CreateLeadWithActivityInput struct {
 }
 
 type CreateOrganizationInput struct {
-	OrganizationName    string  `json:"organizationName"`
-	OrganizationEmail   string  `json:"organizationEmail"`
-	OrganizationWebsite *string `json:"organizationWebsite,omitempty"`
-	City                string  `json:"city"`
-	Country             string  `json:"country"`
-	NoOfEmployees       string  `json:"noOfEmployees"`
-	AnnualRevenue       string  `json:"annualRevenue"`
+	OrganizationName    string       `json:"organizationName"`
+	OrganizationEmail   string       `json:"organizationEmail"`
+	OrganizationWebsite *string      `json:"organizationWebsite,omitempty"`
+	City                string       `json:"city"`
+	Country             string       `json:"country"`
+	NoOfEmployees       string       `json:"noOfEmployees"`
+	AnnualRevenue       models.Money `json:"annualRevenue"`
 }
 
 type CreateResourceProfileInput struct {
@@ -176,6 +243,12 @@ type CreateTaskInput struct {
 	DueDate     string       `json:"dueDate"`
 }
 
+type CreateTeamInput struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	ManagerID   *string `json:"managerID,omitempty"`
+}
+
 type CreateUserInput struct {
 	GoogleID *string  `json:"googleID,omitempty"`
 	Name     string   `json:"name"`
@@ -195,23 +268,36 @@ type CreateVendorInput struct {
 	SkillIDs        []string     `json:"skillIDs,omitempty"`
 }
 
+type CreateWebhookInput struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Description *string  `json:"description,omitempty"`
+}
+
+type DateRangeInput struct {
+	StartDate *string `json:"startDate,omitempty"`
+	EndDate   *string `json:"endDate,omitempty"`
+}
+
 type Deal struct {
-	DealID              string `json:"dealID"`
-	DealName            string `json:"dealName"`
-	LeadID              string `json:"leadID"`
-	DealStartDate       string `json:"dealStartDate"`
-	DealEndDate         string `json:"dealEndDate"`
-	ProjectRequirements string `json:"projectRequirements"`
-	DealAmount          string `json:"dealAmount"`
-	DealStatus          string `json:"dealStatus"`
+	DealID              string       `json:"dealID"`
+	DealName            string       `json:"dealName"`
+	LeadID              string       `json:"leadID"`
+	DealStartDate       string       `json:"dealStartDate"`
+	DealEndDate         string       `json:"dealEndDate"`
+	ProjectRequirements string       `json:"projectRequirements"`
+	DealAmount          models.Money `json:"dealAmount"`
+	DealStatus          string       `json:"dealStatus"`
 }
 
 type DealFilter struct {
-	DealName   *string `json:"dealName,omitempty"`
-	LeadID     *string `json:"leadId,omitempty"`
-	DealStatus *string `json:"dealStatus,omitempty"`
-	DealAmount *string `json:"dealAmount,omitempty"`
-	Search     *string `json:"search,omitempty"`
+	DealName   *string       `json:"dealName,omitempty"`
+	LeadID     *string       `json:"leadId,omitempty"`
+	DealStatus *string       `json:"dealStatus,omitempty"`
+	DealAmount *models.Money `json:"dealAmount,omitempty"`
+	MinAmount  *models.Money `json:"minAmount,omitempty"`
+	MaxAmount  *models.Money `json:"maxAmount,omitempty"`
+	Search     *string       `json:"search,omitempty"`
 }
 
 type DealSortInput struct {
@@ -219,30 +305,138 @@ type DealSortInput struct {
 	Order SortOrder     `json:"order"`
 }
 
+type DealStatusChange struct {
+	Deal      *Deal   `json:"deal"`
+	OldStatus string  `json:"oldStatus"`
+	NewStatus string  `json:"newStatus"`
+	ChangedBy *string `json:"changedBy,omitempty"`
+	ChangedAt string  `json:"changedAt"`
+}
+
+type ExchangeRate struct {
+	Currency     string `json:"currency"`
+	BaseCurrency string `json:"baseCurrency"`
+	RateToBase   string `json:"rateToBase"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+type ExportJob struct {
+	JobID       string          `json:"jobID"`
+	Query       string          `json:"query"`
+	Format      ExportFormat    `json:"format"`
+	Columns     []string        `json:"columns"`
+	Status      ExportJobStatus `json:"status"`
+	Error       *string         `json:"error,omitempty"`
+	RowCount    int32           `json:"rowCount"`
+	DocumentID  *string         `json:"documentID,omitempty"`
+	DownloadURL *string         `json:"downloadURL,omitempty"`
+	CreatedBy   *User           `json:"createdBy"`
+	CreatedAt   string          `json:"createdAt"`
+	StartedAt   *string         `json:"startedAt,omitempty"`
+	FinishedAt  *string         `json:"finishedAt,omitempty"`
+}
+
+type ImportColumnMapping struct {
+	Column string `json:"column"`
+	Field  string `json:"field"`
+}
+
+type ImportField struct {
+	Name        string `json:"name"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+type ImportJob struct {
+	JobID       string                 `json:"jobID"`
+	Entity      ImportEntity           `json:"entity"`
+	FileName    string                 `json:"fileName"`
+	DryRun      bool                   `json:"dryRun"`
+	Status      ImportJobStatus        `json:"status"`
+	Error       *string                `json:"error,omitempty"`
+	Mapping     []*ImportColumnMapping `json:"mapping"`
+	TotalRows   int32                  `json:"totalRows"`
+	ValidRows   int32                  `json:"validRows"`
+	CreatedRows int32                  `json:"createdRows"`
+	FailedRows  int32                  `json:"failedRows"`
+	CreatedBy   *User                  `json:"createdBy"`
+	CreatedAt   string                 `json:"createdAt"`
+	StartedAt   *string                `json:"startedAt,omitempty"`
+	FinishedAt  *string                `json:"finishedAt,omitempty"`
+	Rows        []*ImportRow           `json:"rows"`
+}
+
+type ImportRow struct {
+	Row      int32           `json:"row"`
+	Status   ImportRowStatus `json:"status"`
+	RecordID *string         `json:"recordID,omitempty"`
+	Values   []*ImportValue  `json:"values"`
+	Errors   []string        `json:"errors"`
+	Warnings []string        `json:"warnings"`
+}
+
+type ImportValue struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
 type Lead struct {
-	LeadID             string        `json:"leadID"`
-	FirstName          string        `json:"firstName"`
-	LastName           string        `json:"lastName"`
-	Email              string        `json:"email"`
-	LinkedIn           string        `json:"linkedIn"`
-	Country            string        `json:"country"`
-	Phone              string        `json:"phone"`
-	LeadSource         string        `json:"leadSource"`
-	InitialContactDate string        `json:"initialContactDate"`
-	LeadCreatedBy      *User         `json:"leadCreatedBy"`
-	LeadAssignedTo     *User         `json:"leadAssignedTo"`
-	LeadStage          string        `json:"leadStage"`
-	LeadNotes          string        `json:"leadNotes"`
-	LeadPriority       string        `json:"leadPriority"`
-	LeadType           string        `json:"leadType"`
-	Organization       *Organization `json:"organization"`
-	Campaign           *Campaign     `json:"campaign"`
-	Activities         []*Activity   `json:"activities"`
+	LeadID                 string              `json:"leadID"`
+	FirstName              string              `json:"firstName"`
+	LastName               string              `json:"lastName"`
+	Email                  string              `json:"email"`
+	LinkedIn               string              `json:"linkedIn"`
+	Country                string              `json:"country"`
+	Phone                  string              `json:"phone"`
+	LeadSource             string              `json:"leadSource"`
+	InitialContactDate     string              `json:"initialContactDate"`
+	LeadCreatedBy          *User               `json:"leadCreatedBy"`
+	LeadAssignedTo         *User               `json:"leadAssignedTo"`
+	LeadStage              string              `json:"leadStage"`
+	LeadNotes              string              `json:"leadNotes"`
+	LeadPriority           string              `json:"leadPriority"`
+	LeadType               string              `json:"leadType"`
+	Score                  int32               `json:"score"`
+	CreatedAt              string              `json:"createdAt"`
+	Organization           *Organization       `json:"organization"`
+	Campaign               *Campaign           `json:"campaign"`
+	Activities             []*Activity         `json:"activities"`
+	StageHistory           []*LeadStageHistory `json:"stageHistory"`
+	DurationInCurrentStage int32               `json:"durationInCurrentStage"`
+	PossibleDuplicates     []*LeadDuplicate    `json:"possibleDuplicates"`
+}
+
+type LeadAssignmentLog struct {
+	LogID      string  `json:"logID"`
+	LeadID     string  `json:"leadID"`
+	AssignedTo *User   `json:"assignedTo"`
+	AssignedBy *User   `json:"assignedBy,omitempty"`
+	RuleID     *string `json:"ruleID,omitempty"`
+	RuleName   *string `json:"ruleName,omitempty"`
+	Strategy   string  `json:"strategy"`
+	Reason     string  `json:"reason"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type LeadConnection struct {
+	Edges    []*LeadEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type LeadDuplicate struct {
+	Lead    *Lead    `json:"lead"`
+	Reasons []string `json:"reasons"`
+}
+
+type LeadEdge struct {
+	Node   *Lead  `json:"node"`
+	Cursor string `json:"cursor"`
 }
 
 type LeadFilter struct {
-	Name  *string `json:"name,omitempty"`
-	Email *string `json:"email,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	Email  *string `json:"email,omitempty"`
+	TeamID *string `json:"teamID,omitempty"`
 }
 
 type LeadPage struct {
@@ -255,6 +449,24 @@ type LeadSortInput struct {
 	Order SortOrder     `json:"order"`
 }
 
+type LeadStageChange struct {
+	Lead      *Lead   `json:"lead"`
+	OldStage  string  `json:"oldStage"`
+	NewStage  string  `json:"newStage"`
+	ChangedBy *string `json:"changedBy,omitempty"`
+	ChangedAt string  `json:"changedAt"`
+}
+
+type LeadStageHistory struct {
+	StageHistoryID  string `json:"stageHistoryID"`
+	LeadID          string `json:"leadID"`
+	OldStage        string `json:"oldStage"`
+	NewStage        string `json:"newStage"`
+	ChangedBy       *User  `json:"changedBy,omitempty"`
+	ChangedAt       string `json:"changedAt"`
+	DurationInStage int32  `json:"durationInStage"`
+}
+
 type MadeBy struct {
 	ID          string `json:"ID"`
 	Name        string `json:"Name"`
@@ -267,15 +479,21 @@ type Mutation struct {
 }
 
 type Organization struct {
-	OrganizationID      string  `json:"organizationID"`
-	OrganizationName    string  `json:"organizationName"`
-	OrganizationEmail   string  `json:"organizationEmail"`
-	OrganizationWebsite *string `json:"organizationWebsite,omitempty"`
-	City                string  `json:"city"`
-	Country             string  `json:"country"`
-	NoOfEmployees       string  `json:"noOfEmployees"`
-	AnnualRevenue       string  `json:"annualRevenue"`
-	Leads               []*Lead `json:"leads"`
+	OrganizationID      string                   `json:"organizationID"`
+	OrganizationName    string                   `json:"organizationName"`
+	OrganizationEmail   string                   `json:"organizationEmail"`
+	OrganizationWebsite *string                  `json:"organizationWebsite,omitempty"`
+	City                string                   `json:"city"`
+	Country             string                   `json:"country"`
+	NoOfEmployees       string                   `json:"noOfEmployees"`
+	AnnualRevenue       models.Money             `json:"annualRevenue"`
+	Leads               []*Lead                  `json:"leads"`
+	PossibleDuplicates  []*OrganizationDuplicate `json:"possibleDuplicates"`
+}
+
+type OrganizationDuplicate struct {
+	Organization *Organization `json:"organization"`
+	Reasons      []string      `json:"reasons"`
 }
 
 type OrganizationFilter struct {
@@ -295,6 +513,13 @@ type OrganizationSortInput struct {
 	Order SortOrder             `json:"order"`
 }
 
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type PaginationInput struct {
 	Page     int32 `json:"page"`
 	PageSize int32 `json:"pageSize"`
@@ -318,6 +543,34 @@ type PerformanceRating struct {
 	Review               *string `json:"review,omitempty"`
 }
 
+type PipelineAnalytics struct {
+	TotalLeads             int32                 `json:"totalLeads"`
+	StageCounts            []*PipelineStageCount `json:"stageCounts"`
+	ConversionRates        []*StageConversion    `json:"conversionRates"`
+	WonCount               int32                 `json:"wonCount"`
+	LostCount              int32                 `json:"lostCount"`
+	WinLossRatio           *float64              `json:"winLossRatio,omitempty"`
+	WinRate                float64               `json:"winRate"`
+	AverageTimeToCloseDays *float64              `json:"averageTimeToCloseDays,omitempty"`
+	TotalDealValue         models.Money          `json:"totalDealValue"`
+	MissingExchangeRates   []string              `json:"missingExchangeRates"`
+}
+
+type PipelineAnalyticsFilter struct {
+	CampaignID          *string         `json:"campaignID,omitempty"`
+	AssignedTo          *string         `json:"assignedTo,omitempty"`
+	TeamID              *string         `json:"teamID,omitempty"`
+	OrganizationCountry *string         `json:"organizationCountry,omitempty"`
+	DateRange           *DateRangeInput `json:"dateRange,omitempty"`
+}
+
+type PipelineStageCount struct {
+	Stage     string       `json:"stage"`
+	LeadCount int32        `json:"leadCount"`
+	DealCount int32        `json:"dealCount"`
+	DealValue models.Money `json:"dealValue"`
+}
+
 type Query struct {
 }
 
@@ -336,6 +589,16 @@ type ResourceProfile struct {
 	PastProjects       []*PastProject   `json:"pastProjects"`
 }
 
+type ResourceProfileConnection struct {
+	Edges    []*ResourceProfileEdge `json:"edges"`
+	PageInfo *PageInfo              `json:"pageInfo"`
+}
+
+type ResourceProfileEdge struct {
+	Node   *ResourceProfile `json:"node"`
+	Cursor string           `json:"cursor"`
+}
+
 type ResourceProfileFilter struct {
 	Type               *ResourceType   `json:"type,omitempty"`
 	FirstName          *string         `json:"firstName,omitempty"`
@@ -368,6 +631,28 @@ type ResourceSkillInput struct {
 	ExperienceYears float64 `json:"experienceYears"`
 }
 
+type RolePermissions struct {
+	Role        UserRole `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+type ScoringRule struct {
+	RuleID      string           `json:"ruleID"`
+	Attribute   ScoringAttribute `json:"attribute"`
+	Operator    ScoringOperator  `json:"operator"`
+	Value       string           `json:"value"`
+	Weight      int32            `json:"weight"`
+	Description string           `json:"description"`
+}
+
+type ScoringRuleInput struct {
+	Attribute   ScoringAttribute `json:"attribute"`
+	Operator    ScoringOperator  `json:"operator"`
+	Value       string           `json:"value"`
+	Weight      int32            `json:"weight"`
+	Description *string          `json:"description,omitempty"`
+}
+
 type Skill struct {
 	SkillID     string    `json:"skillID"`
 	Name        string    `json:"name"`
@@ -390,6 +675,16 @@ type SkillSortInput struct {
 	Order string `json:"order"`
 }
 
+type StageConversion struct {
+	FromStage      string  `json:"fromStage"`
+	ToStage        string  `json:"toStage"`
+	Count          int32   `json:"count"`
+	ConversionRate float64 `json:"conversionRate"`
+}
+
+type Subscription struct {
+}
+
 type Task struct {
 	TaskID      string       `json:"taskID"`
 	User        *User        `json:"user"`
@@ -400,10 +695,21 @@ type Task struct {
 	DueDate     string       `json:"dueDate"`
 }
 
+type TaskConnection struct {
+	Edges    []*TaskEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type TaskEdge struct {
+	Node   *Task  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
 type TaskFilter struct {
 	Status   *TaskStatus   `json:"status,omitempty"`
 	Priority *TaskPriority `json:"priority,omitempty"`
 	UserID   *string       `json:"userID,omitempty"`
+	TeamID   *string       `json:"teamID,omitempty"`
 	Title    *string       `json:"title,omitempty"`
 	DueDate  *string       `json:"dueDate,omitempty"`
 	Search   *string       `json:"search,omitempty"`
@@ -419,6 +725,26 @@ type TaskSortInput struct {
 	Order SortOrder     `json:"order"`
 }
 
+type Team struct {
+	TeamID      string  `json:"teamID"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Manager     *User   `json:"manager,omitempty"`
+	Members     []*User `json:"members"`
+}
+
+type TrashItem struct {
+	EntityType TrashEntityType `json:"entityType"`
+	ID         string          `json:"id"`
+	Label      string          `json:"label"`
+	DeletedAt  string          `json:"deletedAt"`
+}
+
+type TrashPage struct {
+	Items      []*TrashItem `json:"items"`
+	TotalCount int32        `json:"totalCount"`
+}
+
 type UpdateActivityInput struct {
 	ActivityType         *string `json:"activityType,omitempty"`
 	DateTime             *string `json:"dateTime,omitempty"`
@@ -447,13 +773,13 @@ type UpdateCaseStudyInput struct {
 }
 
 type UpdateDealInput struct {
-	DealName            string     `json:"dealName"`
-	LeadID              string     `json:"leadID"`
-	DealStartDate       string     `json:"dealStartDate"`
-	DealEndDate         string     `json:"dealEndDate"`
-	ProjectRequirements string     `json:"projectRequirements"`
-	DealAmount          string     `json:"dealAmount"`
-	DealStatus          DealStatus `json:"dealStatus"`
+	DealName            string       `json:"dealName"`
+	LeadID              string       `json:"leadID"`
+	DealStartDate       string       `json:"dealStartDate"`
+	DealEndDate         string       `json:"dealEndDate"`
+	ProjectRequirements string       `json:"projectRequirements"`
+	DealAmount          models.Money `json:"dealAmount"`
+	DealStatus          DealStatus   `json:"dealStatus"`
 }
 
 type UpdateLeadInput struct {
@@ -475,14 +801,14 @@ type UpdateLeadInput struct {
 }
 
 type UpdateOrganizationInput struct {
-	OrganizationID      string  `json:"organizationID"`
-	OrganizationName    *string `json:"organizationName,omitempty"`
-	OrganizationEmail   *string `json:"organizationEmail,omitempty"`
-	OrganizationWebsite *string `json:"organizationWebsite,omitempty"`
-	City                *string `json:"city,omitempty"`
-	Country             *string `json:"country,omitempty"`
-	NoOfEmployees       *string `json:"noOfEmployees,omitempty"`
-	AnnualRevenue       *string `json:"annualRevenue,omitempty"`
+	OrganizationID      string        `json:"organizationID"`
+	OrganizationName    *string       `json:"organizationName,omitempty"`
+	OrganizationEmail   *string       `json:"organizationEmail,omitempty"`
+	OrganizationWebsite *string       `json:"organizationWebsite,omitempty"`
+	City                *string       `json:"city,omitempty"`
+	Country             *string       `json:"country,omitempty"`
+	NoOfEmployees       *string       `json:"noOfEmployees,omitempty"`
+	AnnualRevenue       *models.Money `json:"annualRevenue,omitempty"`
 }
 
 type UpdateResourceProfileInput struct {
@@ -512,6 +838,12 @@ type UpdateTaskInput struct {
 	DueDate     *string       `json:"dueDate,omitempty"`
 }
 
+type UpdateTeamInput struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ManagerID   *string `json:"managerID,omitempty"`
+}
+
 type UpdateUserInput struct {
 	Name  *string   `json:"name,omitempty"`
 	Email *string   `json:"email,omitempty"`
@@ -529,6 +861,13 @@ type UpdateVendorInput struct {
 	SkillIDs        []string      `json:"skillIDs,omitempty"`
 }
 
+type UpdateWebhookInput struct {
+	URL         *string  `json:"url,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
 type User struct {
 	UserID    string      `json:"userID"`
 	GoogleID  *string     `json:"googleID,omitempty"`
@@ -538,12 +877,15 @@ type User struct {
 	Role      string      `json:"role"`
 	Password  string      `json:"password"`
 	Campaigns []*Campaign `json:"campaigns"`
+	Team      *Team       `json:"team,omitempty"`
+	Manager   *User       `json:"manager,omitempty"`
 }
 
 type UserFilter struct {
 	Name   *string   `json:"name,omitempty"`
 	Email  *string   `json:"email,omitempty"`
 	Role   *UserRole `json:"role,omitempty"`
+	TeamID *string   `json:"teamID,omitempty"`
 	Search *string   `json:"search,omitempty"`
 }
 
@@ -573,6 +915,16 @@ type Vendor struct {
 	Resources          []*ResourceProfile   `json:"resources"`
 }
 
+type VendorConnection struct {
+	Edges    []*VendorEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type VendorEdge struct {
+	Node   *Vendor `json:"node"`
+	Cursor string  `json:"cursor"`
+}
+
 type VendorFilter struct {
 	CompanyName  *string       `json:"companyName,omitempty"`
 	Status       *VendorStatus `json:"status,omitempty"`
@@ -591,6 +943,43 @@ type VendorSortInput struct {
 	Order SortOrder       `json:"order"`
 }
 
+type Webhook struct {
+	WebhookID   string   `json:"webhookID"`
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Active      bool     `json:"active"`
+	Description *string  `json:"description,omitempty"`
+	Secret      *string  `json:"secret,omitempty"`
+	CreatedBy   *User    `json:"createdBy"`
+	CreatedAt   string   `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID     string                `json:"deliveryID"`
+	WebhookID      string                `json:"webhookID"`
+	Event          string                `json:"event"`
+	Payload        string                `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	NextAttemptAt  *string               `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *string               `json:"lastAttemptAt,omitempty"`
+	ResponseStatus *int32                `json:"responseStatus,omitempty"`
+	ResponseBody   *string               `json:"responseBody,omitempty"`
+	Error          *string               `json:"error,omitempty"`
+	CreatedAt      string                `json:"createdAt"`
+}
+
+type WebhookDeliveryFilter struct {
+	WebhookID *string                `json:"webhookID,omitempty"`
+	Event     *string                `json:"event,omitempty"`
+	Status    *WebhookDeliveryStatus `json:"status,omitempty"`
+}
+
+type WebhookDeliveryPage struct {
+	Items      []*WebhookDelivery `json:"items"`
+	TotalCount int32              `json:"totalCount"`
+}
+
 type CaseStudy struct {
 	CaseStudyID     string `json:"caseStudyID"`
 	ProjectName     string `json:"projectName"`
@@ -622,6 +1011,94 @@ type CaseStudySortInput struct {
 	Order SortOrder          `json:"order"`
 }
 
+type AssignmentStrategy string
+
+const (
+	AssignmentStrategyRoundRobin  AssignmentStrategy = "ROUND_ROBIN"
+	AssignmentStrategyLeastLoaded AssignmentStrategy = "LEAST_LOADED"
+)
+
+var AllAssignmentStrategy = []AssignmentStrategy{
+	AssignmentStrategyRoundRobin,
+	AssignmentStrategyLeastLoaded,
+}
+
+func (e AssignmentStrategy) IsValid() bool {
+	switch e {
+	case AssignmentStrategyRoundRobin, AssignmentStrategyLeastLoaded:
+		return true
+	}
+	return false
+}
+
+func (e AssignmentStrategy) String() string {
+	return string(e)
+}
+
+func (e *AssignmentStrategy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AssignmentStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AssignmentStrategy", str)
+	}
+	return nil
+}
+
+func (e AssignmentStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "CREATE"
+	AuditActionUpdate  AuditAction = "UPDATE"
+	AuditActionDelete  AuditAction = "DELETE"
+	AuditActionRestore AuditAction = "RESTORE"
+	AuditActionPurge   AuditAction = "PURGE"
+)
+
+var AllAuditAction = []AuditAction{
+	AuditActionCreate,
+	AuditActionUpdate,
+	AuditActionDelete,
+	AuditActionRestore,
+	AuditActionPurge,
+}
+
+func (e AuditAction) IsValid() bool {
+	switch e {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionRestore, AuditActionPurge:
+		return true
+	}
+	return false
+}
+
+func (e AuditAction) String() string {
+	return string(e)
+}
+
+func (e *AuditAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditAction", str)
+	}
+	return nil
+}
+
+func (e AuditAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type CampaignSortField string
 
 const (
@@ -663,6 +1140,55 @@ func (e CampaignSortField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ConstraintFormat string
+
+const (
+	ConstraintFormatEmail    ConstraintFormat = "EMAIL"
+	ConstraintFormatPhone    ConstraintFormat = "PHONE"
+	ConstraintFormatURL      ConstraintFormat = "URL"
+	ConstraintFormatDate     ConstraintFormat = "DATE"
+	ConstraintFormatDatetime ConstraintFormat = "DATETIME"
+	ConstraintFormatUUID     ConstraintFormat = "UUID"
+)
+
+var AllConstraintFormat = []ConstraintFormat{
+	ConstraintFormatEmail,
+	ConstraintFormatPhone,
+	ConstraintFormatURL,
+	ConstraintFormatDate,
+	ConstraintFormatDatetime,
+	ConstraintFormatUUID,
+}
+
+func (e ConstraintFormat) IsValid() bool {
+	switch e {
+	case ConstraintFormatEmail, ConstraintFormatPhone, ConstraintFormatURL, ConstraintFormatDate, ConstraintFormatDatetime, ConstraintFormatUUID:
+		return true
+	}
+	return false
+}
+
+func (e ConstraintFormat) String() string {
+	return string(e)
+}
+
+func (e *ConstraintFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ConstraintFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ConstraintFormat", str)
+	}
+	return nil
+}
+
+func (e ConstraintFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type DealSortField string
 
 const (
@@ -710,6 +1236,227 @@ func (e DealSortField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "CSV"
+	ExportFormatXlsx   ExportFormat = "XLSX"
+	ExportFormatNdjson ExportFormat = "NDJSON"
+)
+
+var AllExportFormat = []ExportFormat{
+	ExportFormatCSV,
+	ExportFormatXlsx,
+	ExportFormatNdjson,
+}
+
+func (e ExportFormat) IsValid() bool {
+	switch e {
+	case ExportFormatCSV, ExportFormatXlsx, ExportFormatNdjson:
+		return true
+	}
+	return false
+}
+
+func (e ExportFormat) String() string {
+	return string(e)
+}
+
+func (e *ExportFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExportFormat", str)
+	}
+	return nil
+}
+
+func (e ExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ExportJobStatus string
+
+const (
+	ExportJobStatusPending   ExportJobStatus = "PENDING"
+	ExportJobStatusRunning   ExportJobStatus = "RUNNING"
+	ExportJobStatusCompleted ExportJobStatus = "COMPLETED"
+	ExportJobStatusFailed    ExportJobStatus = "FAILED"
+)
+
+var AllExportJobStatus = []ExportJobStatus{
+	ExportJobStatusPending,
+	ExportJobStatusRunning,
+	ExportJobStatusCompleted,
+	ExportJobStatusFailed,
+}
+
+func (e ExportJobStatus) IsValid() bool {
+	switch e {
+	case ExportJobStatusPending, ExportJobStatusRunning, ExportJobStatusCompleted, ExportJobStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e ExportJobStatus) String() string {
+	return string(e)
+}
+
+func (e *ExportJobStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExportJobStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExportJobStatus", str)
+	}
+	return nil
+}
+
+func (e ExportJobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportEntity string
+
+const (
+	ImportEntityLead            ImportEntity = "LEAD"
+	ImportEntityOrganization    ImportEntity = "ORGANIZATION"
+	ImportEntityResourceProfile ImportEntity = "RESOURCE_PROFILE"
+)
+
+var AllImportEntity = []ImportEntity{
+	ImportEntityLead,
+	ImportEntityOrganization,
+	ImportEntityResourceProfile,
+}
+
+func (e ImportEntity) IsValid() bool {
+	switch e {
+	case ImportEntityLead, ImportEntityOrganization, ImportEntityResourceProfile:
+		return true
+	}
+	return false
+}
+
+func (e ImportEntity) String() string {
+	return string(e)
+}
+
+func (e *ImportEntity) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportEntity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportEntity", str)
+	}
+	return nil
+}
+
+func (e ImportEntity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending      ImportJobStatus = "PENDING"
+	ImportJobStatusRunning      ImportJobStatus = "RUNNING"
+	ImportJobStatusPreviewReady ImportJobStatus = "PREVIEW_READY"
+	ImportJobStatusCompleted    ImportJobStatus = "COMPLETED"
+	ImportJobStatusFailed       ImportJobStatus = "FAILED"
+)
+
+var AllImportJobStatus = []ImportJobStatus{
+	ImportJobStatusPending,
+	ImportJobStatusRunning,
+	ImportJobStatusPreviewReady,
+	ImportJobStatusCompleted,
+	ImportJobStatusFailed,
+}
+
+func (e ImportJobStatus) IsValid() bool {
+	switch e {
+	case ImportJobStatusPending, ImportJobStatusRunning, ImportJobStatusPreviewReady, ImportJobStatusCompleted, ImportJobStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e ImportJobStatus) String() string {
+	return string(e)
+}
+
+func (e *ImportJobStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportJobStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportJobStatus", str)
+	}
+	return nil
+}
+
+func (e ImportJobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportRowStatus string
+
+const (
+	ImportRowStatusValid   ImportRowStatus = "VALID"
+	ImportRowStatusCreated ImportRowStatus = "CREATED"
+	ImportRowStatusFailed  ImportRowStatus = "FAILED"
+)
+
+var AllImportRowStatus = []ImportRowStatus{
+	ImportRowStatusValid,
+	ImportRowStatusCreated,
+	ImportRowStatusFailed,
+}
+
+func (e ImportRowStatus) IsValid() bool {
+	switch e {
+	case ImportRowStatusValid, ImportRowStatusCreated, ImportRowStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e ImportRowStatus) String() string {
+	return string(e)
+}
+
+func (e *ImportRowStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportRowStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportRowStatus", str)
+	}
+	return nil
+}
+
+func (e ImportRowStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type LeadPriority string
 
 const (
@@ -760,6 +1507,7 @@ const (
 	LeadSortFieldLastName  LeadSortField = "LAST_NAME"
 	LeadSortFieldEmail     LeadSortField = "EMAIL"
 	LeadSortFieldCreatedAt LeadSortField = "CREATED_AT"
+	LeadSortFieldScore     LeadSortField = "SCORE"
 )
 
 var AllLeadSortField = []LeadSortField{
@@ -767,11 +1515,12 @@ var AllLeadSortField = []LeadSortField{
 	LeadSortFieldLastName,
 	LeadSortFieldEmail,
 	LeadSortFieldCreatedAt,
+	LeadSortFieldScore,
 }
 
 func (e LeadSortField) IsValid() bool {
 	switch e {
-	case LeadSortFieldFirstName, LeadSortFieldLastName, LeadSortFieldEmail, LeadSortFieldCreatedAt:
+	case LeadSortFieldFirstName, LeadSortFieldLastName, LeadSortFieldEmail, LeadSortFieldCreatedAt, LeadSortFieldScore:
 		return true
 	}
 	return false
@@ -1113,6 +1862,100 @@ func (e ResourceType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ScoringAttribute string
+
+const (
+	ScoringAttributeLeadSource      ScoringAttribute = "LEAD_SOURCE"
+	ScoringAttributeLeadPriority    ScoringAttribute = "LEAD_PRIORITY"
+	ScoringAttributeEmployees       ScoringAttribute = "EMPLOYEES"
+	ScoringAttributeAnnualRevenue   ScoringAttribute = "ANNUAL_REVENUE"
+	ScoringAttributeActivityCount   ScoringAttribute = "ACTIVITY_COUNT"
+	ScoringAttributeActivityRecency ScoringAttribute = "ACTIVITY_RECENCY"
+	ScoringAttributeCampaign        ScoringAttribute = "CAMPAIGN"
+)
+
+var AllScoringAttribute = []ScoringAttribute{
+	ScoringAttributeLeadSource,
+	ScoringAttributeLeadPriority,
+	ScoringAttributeEmployees,
+	ScoringAttributeAnnualRevenue,
+	ScoringAttributeActivityCount,
+	ScoringAttributeActivityRecency,
+	ScoringAttributeCampaign,
+}
+
+func (e ScoringAttribute) IsValid() bool {
+	switch e {
+	case ScoringAttributeLeadSource, ScoringAttributeLeadPriority, ScoringAttributeEmployees, ScoringAttributeAnnualRevenue, ScoringAttributeActivityCount, ScoringAttributeActivityRecency, ScoringAttributeCampaign:
+		return true
+	}
+	return false
+}
+
+func (e ScoringAttribute) String() string {
+	return string(e)
+}
+
+func (e *ScoringAttribute) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScoringAttribute(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScoringAttribute", str)
+	}
+	return nil
+}
+
+func (e ScoringAttribute) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ScoringOperator string
+
+const (
+	ScoringOperatorEquals ScoringOperator = "EQUALS"
+	ScoringOperatorGte    ScoringOperator = "GTE"
+	ScoringOperatorLte    ScoringOperator = "LTE"
+)
+
+var AllScoringOperator = []ScoringOperator{
+	ScoringOperatorEquals,
+	ScoringOperatorGte,
+	ScoringOperatorLte,
+}
+
+func (e ScoringOperator) IsValid() bool {
+	switch e {
+	case ScoringOperatorEquals, ScoringOperatorGte, ScoringOperatorLte:
+		return true
+	}
+	return false
+}
+
+func (e ScoringOperator) String() string {
+	return string(e)
+}
+
+func (e *ScoringOperator) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScoringOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScoringOperator", str)
+	}
+	return nil
+}
+
+func (e ScoringOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type SkillType string
 
 const (
@@ -1334,6 +2177,67 @@ func (e TaskStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type TrashEntityType string
+
+const (
+	TrashEntityTypeLead            TrashEntityType = "LEAD"
+	TrashEntityTypeActivity        TrashEntityType = "ACTIVITY"
+	TrashEntityTypeDeal            TrashEntityType = "DEAL"
+	TrashEntityTypeTask            TrashEntityType = "TASK"
+	TrashEntityTypeOrganization    TrashEntityType = "ORGANIZATION"
+	TrashEntityTypeCampaign        TrashEntityType = "CAMPAIGN"
+	TrashEntityTypeVendor          TrashEntityType = "VENDOR"
+	TrashEntityTypeResourceProfile TrashEntityType = "RESOURCE_PROFILE"
+	TrashEntityTypeCaseStudy       TrashEntityType = "CASE_STUDY"
+	TrashEntityTypeSkill           TrashEntityType = "SKILL"
+	TrashEntityTypeUser            TrashEntityType = "USER"
+	TrashEntityTypeTeam            TrashEntityType = "TEAM"
+)
+
+var AllTrashEntityType = []TrashEntityType{
+	TrashEntityTypeLead,
+	TrashEntityTypeActivity,
+	TrashEntityTypeDeal,
+	TrashEntityTypeTask,
+	TrashEntityTypeOrganization,
+	TrashEntityTypeCampaign,
+	TrashEntityTypeVendor,
+	TrashEntityTypeResourceProfile,
+	TrashEntityTypeCaseStudy,
+	TrashEntityTypeSkill,
+	TrashEntityTypeUser,
+	TrashEntityTypeTeam,
+}
+
+func (e TrashEntityType) IsValid() bool {
+	switch e {
+	case TrashEntityTypeLead, TrashEntityTypeActivity, TrashEntityTypeDeal, TrashEntityTypeTask, TrashEntityTypeOrganization, TrashEntityTypeCampaign, TrashEntityTypeVendor, TrashEntityTypeResourceProfile, TrashEntityTypeCaseStudy, TrashEntityTypeSkill, TrashEntityTypeUser, TrashEntityTypeTeam:
+		return true
+	}
+	return false
+}
+
+func (e TrashEntityType) String() string {
+	return string(e)
+}
+
+func (e *TrashEntityType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TrashEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TrashEntityType", str)
+	}
+	return nil
+}
+
+func (e TrashEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type UserRole string
 
 const (
@@ -1510,6 +2414,49 @@ func (e VendorStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type CaseStudySortField string
 
 const (
@@ -4,32 +4,49 @@ import (
 	"context"
 	"log"
 	"net/http"
-	"os"
+	"slices"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
-	initializers "github.com/Zenithive/it-crm-backend/Initializers"
 	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/audit"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"github.com/Zenithive/it-crm-backend/internal/events"
 	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
 	"github.com/Zenithive/it-crm-backend/internal/graphql/schema"
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/internal/loaders"
+	"github.com/Zenithive/it-crm-backend/internal/querylimit"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/internal/webhooks"
 	"github.com/go-chi/cors"
+	"github.com/gorilla/websocket"
 	"github.com/markbates/goth/gothic"
 	"github.com/vektah/gqlparser/v2/ast"
+	"gorm.io/gorm"
 )
 
-const defaultPort = "8080"
-
-func init() {
-	initializers.ConnectToDatabase()
+// server holds what the HTTP handlers next to the GraphQL endpoint depend on.
+type server struct {
+	db       *gorm.DB
+	resolver *schema.Resolver
 }
 
 // Must contain 6 characters, one uppercase, one lowercase, one number, and one special character
-func Handler() {
+func Handler(cfg *config.Config, db *gorm.DB) {
+	if err := audit.Register(db, auth.CurrentUser); err != nil {
+		log.Fatalf("Failed to register audit callbacks: %v", err)
+	}
+
 	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:3000"},  // Allow only your frontend origin
+		AllowedOrigins:   cfg.CORSOrigins,                    // Allow only your frontend origins
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"}, // Allow common methods
 		AllowedHeaders:   []string{"*"},                      // Allow all headers
 		ExposedHeaders:   []string{"Content-Type"},
@@ -38,35 +55,81 @@ func Handler() {
 
 	// handler := c.Handler(http.DefaultServeMux)
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
-	}
-	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: &schema.Resolver{}}))
+	port := cfg.Port
+	s := &server{db: db, resolver: schema.NewResolver(db)}
+	s.resolver.TrashRetention = time.Duration(cfg.TrashRetention)
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: s.resolver,
+		Directives: generated.DirectiveRoot{
+			HasPermission: auth.HasPermissionDirective,
+		},
+	}))
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || slices.Contains(cfg.CORSOrigins, origin)
+			},
+		},
+		InitFunc: auth.WebsocketInit,
+	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	// Typed error codes for clients; anything else is logged and hidden
+	srv.SetErrorPresenter(apperr.Presenter)
+	srv.SetRecoverFunc(apperr.Recover)
 	srv.Use(extension.Introspection{})
 	srv.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New[string](100),
 	})
+	// Depth and complexity limits of the caller's role
+	srv.Use(&querylimit.Extension{Overrides: cfg.QueryLimits})
+	// @constraint checks of arguments, all reported at once
+	srv.Use(&validation.Extension{})
+	// Fresh DataLoaders for every response, including each subscription event
+	srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		return next(loaders.NewContext(ctx, s.resolver.LoaderSource))
+	})
 	mux := http.NewServeMux()
 
+	// Task reminders for the taskDueSoon subscription
+	go events.RemindDueTasks(db, time.Minute, time.Duration(cfg.TaskReminder))
+
+	// Lead scores that depend on how long ago the last activity was
+	go scoring.RescoreByActivityRecency(db, time.Hour)
+
+	// Files of failed imports and of previews nobody committed
+	go importer.ExpirePreviews(db, time.Hour)
+
+	// Outbound webhooks
+	webhooks.Start(context.Background(), db)
+
 	// GraphQL Playground & API
 	mux.Handle("/playground", c.Handler(auth.Middleware(playground.Handler("GraphQL playground", "/"))))
 	mux.Handle("/graphql", c.Handler(auth.Middleware(srv)))
 
 	// File Upload & Download
-	mux.HandleFunc("/upload", auth.MiddlewareFuncForUploads(uploadFileHandler))
-	mux.HandleFunc("/download", auth.MiddlewareFuncForUploads(downloadFileHandler))
+	mux.HandleFunc("/upload", auth.MiddlewareFuncForUploads(s.uploadFileHandler))
+	mux.HandleFunc("/download", auth.MiddlewareFuncForUploads(s.downloadFileHandler))
+
+	// Spreadsheet Import
+	mux.HandleFunc("/import", auth.MiddlewareFuncForUploads(s.importFileHandler))
+
+	// List Export
+	mux.HandleFunc("/export", auth.MiddlewareFuncForUploads(s.exportFileHandler))
 
 	// Static File Serving
 	mux.Handle("/", http.FileServer(http.Dir("static")))
 	mux.Handle("/uploads/", http.StripPrefix("/uploads/", http.FileServer(http.Dir("uploads"))))
+	// Exports and imports written before they moved to storage/ stay private
+	mux.Handle("/uploads/exports/", http.NotFoundHandler())
+	mux.Handle("/uploads/imports/", http.NotFoundHandler())
 
 	// Document Listing API
-	mux.HandleFunc("/documents", listDocuments)
+	mux.HandleFunc("/documents", s.listDocuments)
 
 	// Serve static files
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./static"))))
@@ -110,10 +173,10 @@ func Handler() {
 	})
 
 	// Start server
-	log.Println("Server running on http://localhost:8080")
+	log.Printf("Server running on %s", cfg.PublicURL)
 
 	// Start Server
-	log.Printf("GraphQL running at http://localhost:%s/graphql", port)
-	log.Printf("File Upload running at http://localhost:%s/upload", port)
+	log.Printf("GraphQL running at %s/graphql", cfg.PublicURL)
+	log.Printf("File Upload running at %s/upload", cfg.PublicURL)
 	log.Fatal(http.ListenAndServe(":"+port, mux))
 }
@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// importFileHandler starts an import job from a CSV or XLSX upload.
+//
+// Form fields:
+//   - file: the spreadsheet, the header row names the columns
+//   - entity: LEAD, ORGANIZATION or RESOURCE_PROFILE
+//   - mapping: optional JSON object from column header to field, see getImportFields;
+//     without it, headers are matched to field names
+//   - dryRun: "false" to create the records right away, otherwise the job only
+//     validates the rows and waits for commitImportJob, for up to importer.PreviewExpiry
+//
+// The job runs in the background; poll getImportJob for its progress and row results.
+func (s *server) importFileHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Only POST requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := auth.CurrentUserID(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		http.Error(w, "File too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	entity := strings.ToUpper(strings.TrimSpace(r.FormValue("entity")))
+	permission, err := importer.Permission(entity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := auth.RequirePermission(r.Context(), permission); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	mapping := map[string]string{}
+	if raw := strings.TrimSpace(r.FormValue("mapping")); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			http.Error(w, "Invalid mapping, expected a JSON object from column to field", http.StatusBadRequest)
+			return
+		}
+	}
+	dryRun := true
+	if raw := r.FormValue("dryRun"); raw != "" {
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			http.Error(w, "Invalid dryRun, expected true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	ext := strings.ToLower(filepath.Ext(handler.Filename))
+	if !importer.Extensions[ext] {
+		http.Error(w, "Invalid file type. Only CSV and XLSX are allowed.", http.StatusBadRequest)
+		return
+	}
+
+	// Keep the file until the job is committed, fails or its preview expires
+	if err := os.MkdirAll(importer.Dir, os.ModePerm); err != nil {
+		http.Error(w, "Failed to create import directory", http.StatusInternalServerError)
+		return
+	}
+	job := models.ImportJob{
+		ID:        uuid.New(),
+		Entity:    entity,
+		FileName:  handler.Filename,
+		Mapping:   mapping,
+		DryRun:    dryRun,
+		Status:    models.ImportStatusPending,
+		CreatedBy: parsedUserID,
+	}
+	job.FilePath = filepath.Join(importer.Dir, job.ID.String()+ext)
+	if err := saveUploadedFile(file, job.FilePath); err != nil {
+		http.Error(w, "Failed to save file", http.StatusInternalServerError)
+		return
+	}
+	if err := s.db.Create(&job).Error; err != nil {
+		os.Remove(job.FilePath)
+		http.Error(w, "Failed to create import job", http.StatusInternalServerError)
+		return
+	}
+
+	importer.Start(r.Context(), s.db, s.resolver.ImportServices(), job.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jobID":  job.ID,
+		"status": job.Status,
+		"dryRun": job.DryRun,
+	})
+	fmt.Println("Import job started:", job.ID)
+}
@@ -1,7 +1,104 @@
 package schema
 
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/internal/loaders"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/service"
+	"gorm.io/gorm"
+)
+
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
-type Resolver struct{}
+// Resolver holds what the resolvers depend on. The CRM entities (users, teams,
+// organizations, campaigns, leads, activities, deals, tasks, resource profiles,
+// case studies, skills and vendors) and what is kept around them (exchange
+// rates, assignment and scoring rules, import and export jobs, webhooks, the
+// audit log and the trash) are read and written through the services, so tests
+// can run the resolvers on the in-memory repositories of
+// internal/repository/fake, see NewResolverOn.
+//
+// DB only serves the pipeline analytics, which aggregate in SQL. Permissions
+// and sessions are kept by package auth. Resolvers never use initializers.DB.
+type Resolver struct {
+	DB               *gorm.DB
+	Users            *service.UserService
+	Teams            *service.TeamService
+	Organizations    *service.OrganizationService
+	Campaigns        *service.CampaignService
+	Leads            *service.LeadService
+	Activities       *service.ActivityService
+	Deals            *service.DealService
+	Tasks            *service.TaskService
+	ResourceProfiles *service.ResourceProfileService
+	CaseStudies      *service.CaseStudyService
+	Skills           *service.SkillService
+	Vendors          *service.VendorService
+	ExchangeRates    *service.ExchangeRateService
+	AssignmentRules  *service.AssignmentRuleService
+	ScoringRules     *service.ScoringRuleService
+	ImportJobs       *service.ImportJobService
+	ExportJobs       *service.ExportJobService
+	Webhooks         *service.WebhookService
+	Audit            *service.AuditService
+	Trash            *service.TrashService
+
+	// LoaderSource feeds the DataLoaders of the nested fields, see dataLoaders.
+	LoaderSource loaders.Source
+
+	// TrashRetention is the age after which purgeTrash removes deleted rows,
+	// config.Config.TrashRetention.
+	TrashRetention time.Duration
+}
+
+// NewResolver returns a Resolver whose services store their data in db.
+func NewResolver(db *gorm.DB) *Resolver {
+	return NewResolverOn(db, repository.New(db))
+}
+
+// NewResolverOn returns a Resolver whose services use repos, and the rest db.
+func NewResolverOn(db *gorm.DB, repos *repository.Repositories) *Resolver {
+	return &Resolver{
+		DB:               db,
+		Users:            service.NewUserService(repos.Users, repos.Teams),
+		Teams:            service.NewTeamService(repos.Teams, repos.Users),
+		Organizations:    service.NewOrganizationService(repos.Organizations),
+		Campaigns:        service.NewCampaignService(repos.Campaigns, repos.Users),
+		Leads:            service.NewLeadService(repos.Leads, repos.Users, repos.Organizations, repos.Campaigns),
+		Activities:       service.NewActivityService(repos.Activities, repos.Leads),
+		Deals:            service.NewDealService(repos.Deals, repos.Leads),
+		Tasks:            service.NewTaskService(repos.Tasks),
+		ResourceProfiles: service.NewResourceProfileService(repos.ResourceProfiles, repos.Skills),
+		CaseStudies:      service.NewCaseStudyService(repos.CaseStudies),
+		Skills:           service.NewSkillService(repos.Skills),
+		Vendors:          service.NewVendorService(repos.Vendors, repos.Skills),
+		ExchangeRates:    service.NewExchangeRateService(repos.ExchangeRates),
+		AssignmentRules:  service.NewAssignmentRuleService(repos.AssignmentRules, repos.Leads, repos.Campaigns, repos.Teams, repos.Users),
+		ScoringRules:     service.NewScoringRuleService(repos.ScoringRules),
+		ImportJobs:       service.NewImportJobService(repos.ImportJobs),
+		ExportJobs:       service.NewExportJobService(repos.ExportJobs),
+		Webhooks:         service.NewWebhookService(repos.Webhooks),
+		Audit:            service.NewAuditService(repos.AuditEvents),
+		Trash:            service.NewTrashService(repos.Trash),
+		LoaderSource:     repos.LoaderSource(),
+	}
+}
+
+// ImportServices returns the services imported rows are created through.
+func (r *Resolver) ImportServices() importer.Services {
+	return importer.Services{
+		Leads:            r.Leads,
+		Organizations:    r.Organizations,
+		ResourceProfiles: r.ResourceProfiles,
+	}
+}
+
+// dataLoaders returns the loaders of the response, see loaders.For.
+func (r *Resolver) dataLoaders(ctx context.Context) *loaders.Loaders {
+	return loaders.For(ctx, r.LoaderSource)
+}
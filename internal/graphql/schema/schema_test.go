@@ -0,0 +1,699 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/schema"
+	"github.com/Zenithive/it-crm-backend/internal/loaders"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/repository/fake"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// testServer runs the GraphQL schema on in-memory repositories, without a database.
+type testServer struct {
+	t      *testing.T
+	repos  *repository.Repositories
+	client *client.Client
+
+	organization models.Organization
+	campaign     models.Campaign
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	repos := fake.New()
+	resolver := schema.NewResolverOn(nil, repos)
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: resolver,
+		Directives: generated.DirectiveRoot{
+			HasPermission: auth.HasPermissionDirective,
+		},
+	}))
+	srv.AddTransport(transport.POST{})
+	srv.SetErrorPresenter(apperr.Presenter)
+	srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		return next(loaders.NewContext(ctx, resolver.LoaderSource))
+	})
+
+	s := &testServer{t: t, repos: repos, client: client.New(srv)}
+	s.organization = models.Organization{
+		OrganizationName:  "Acme",
+		OrganizationEmail: "info@acme.example",
+		AnnualRevenue:     models.Money{Amount: decimal.NewFromInt(1000), Currency: "USD"},
+	}
+	if err := repos.Organizations.Create(context.Background(), &s.organization); err != nil {
+		t.Fatal(err)
+	}
+	s.campaign = models.Campaign{ID: uuid.New(), CampaignName: "Spring", CampaignCountry: "India"}
+	if err := repos.Campaigns.Create(context.Background(), &s.campaign); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// user adds a user with role.
+func (s *testServer) user(name, role string) models.User {
+	s.t.Helper()
+	user := models.User{ID: uuid.New(), Name: name, Email: name + "@crm.example", Role: role}
+	if err := s.repos.Users.Create(context.Background(), &user); err != nil {
+		s.t.Fatal(err)
+	}
+	return user
+}
+
+// responseError is an error of a GraphQL response.
+type responseError struct {
+	Message    string
+	Extensions struct {
+		Code   string
+		Fields map[string]string
+	}
+}
+
+// do runs query as user, or anonymously if user is nil, and decodes the data
+// of the response into data.
+func (s *testServer) do(user *models.User, query string, variables map[string]any, data any) []responseError {
+	s.t.Helper()
+	options := []client.Option{func(request *client.Request) {
+		request.Variables = variables
+		if user != nil {
+			claims := jwt.MapClaims{"user_id": user.ID.String(), "name": user.Name, "role": user.Role}
+			request.HTTP = request.HTTP.WithContext(context.WithValue(request.HTTP.Context(), auth.UserCtxKey, claims))
+		}
+	}}
+	response, err := s.client.RawPost(query, options...)
+	if err != nil {
+		s.t.Fatalf("post: %v", err)
+	}
+	var errs []responseError
+	if response.Errors != nil {
+		if err := json.Unmarshal(response.Errors, &errs); err != nil {
+			s.t.Fatalf("decode errors: %v", err)
+		}
+	}
+	if data != nil && response.Data != nil {
+		raw, err := json.Marshal(response.Data)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		if err := json.Unmarshal(raw, data); err != nil {
+			s.t.Fatalf("decode data: %v", err)
+		}
+	}
+	return errs
+}
+
+// mustDo runs query like do and fails the test on errors.
+func (s *testServer) mustDo(user *models.User, query string, variables map[string]any, data any) {
+	s.t.Helper()
+	if errs := s.do(user, query, variables, data); len(errs) > 0 {
+		s.t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+const createLead = `mutation($input: CreateLeadInput!) {
+	createLead(input: $input) {
+		leadID
+		leadCreatedBy { userID }
+		leadAssignedTo { userID }
+		organization { organizationName }
+	}
+}`
+
+type lead struct {
+	LeadID         string
+	FirstName      string
+	LeadCreatedBy  struct{ UserID string }
+	LeadAssignedTo struct{ UserID string }
+	Organization   struct{ OrganizationName string }
+}
+
+// createLead adds a lead named firstName as user.
+func (s *testServer) createLead(user models.User, firstName string) lead {
+	s.t.Helper()
+	var data struct{ CreateLead lead }
+	s.mustDo(&user, createLead, map[string]any{"input": map[string]any{
+		"firstName":          firstName,
+		"lastName":           "Doe",
+		"email":              firstName + "@lead.example",
+		"linkedIn":           "",
+		"country":            "India",
+		"phone":              "+919876543210",
+		"leadSource":         "Referral",
+		"initialContactDate": "2024-01-15",
+		"leadStage":          "NEW",
+		"leadNotes":          "",
+		"leadPriority":       "HIGH",
+		"leadType":           "SMALL",
+		"organizationID":     s.organization.ID.String(),
+		"campaignID":         s.campaign.ID.String(),
+	}}, &data)
+	return data.CreateLead
+}
+
+const getLeads = `query {
+	getLeads(pagination: {page: 1, pageSize: 10}, sort: {field: FIRST_NAME, order: ASC}) {
+		items { leadID firstName }
+		totalCount
+	}
+}`
+
+type leadPage struct {
+	GetLeads struct {
+		Items      []lead
+		TotalCount int
+	}
+}
+
+func firstNames(leads []lead) []string {
+	names := make([]string, 0, len(leads))
+	for _, lead := range leads {
+		names = append(names, lead.FirstName)
+	}
+	return names
+}
+
+func TestCreateLeadWithoutAssigneeGoesToCreator(t *testing.T) {
+	s := newTestServer(t)
+	seller := s.user("seller", "SALES_EXECUTIVE")
+
+	created := s.createLead(seller, "Ann")
+	if created.LeadCreatedBy.UserID != seller.ID.String() || created.LeadAssignedTo.UserID != seller.ID.String() {
+		t.Errorf("lead created by %s and assigned to %s, want both %s",
+			created.LeadCreatedBy.UserID, created.LeadAssignedTo.UserID, seller.ID)
+	}
+	if created.Organization.OrganizationName != "Acme" {
+		t.Errorf("organization = %q, want Acme", created.Organization.OrganizationName)
+	}
+}
+
+func TestLeadsAreScopedToTheCaller(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.user("admin", "ADMIN")
+	ann := s.user("ann", "SALES_EXECUTIVE")
+	bob := s.user("bob", "SALES_EXECUTIVE")
+	manager := s.user("manager", "MANAGER")
+
+	s.createLead(ann, "Alice")
+	bobs := s.createLead(bob, "Bella")
+
+	tests := []struct {
+		name string
+		user models.User
+		want []string
+	}{
+		{"admin sees every lead", admin, []string{"Alice", "Bella"}},
+		{"sales executive sees their own leads", ann, []string{"Alice"}},
+		{"manager outside the campaign sees none", manager, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var page leadPage
+			s.mustDo(&test.user, getLeads, nil, &page)
+			if got := firstNames(page.GetLeads.Items); !slices.Equal(got, test.want) || page.GetLeads.TotalCount != len(test.want) {
+				t.Errorf("leads = %v (total %d), want %v", got, page.GetLeads.TotalCount, test.want)
+			}
+		})
+	}
+
+	t.Run("manager sees the leads of their campaigns", func(t *testing.T) {
+		if err := s.repos.Campaigns.AddMember(context.Background(), &s.campaign, &manager); err != nil {
+			t.Fatal(err)
+		}
+		var page leadPage
+		s.mustDo(&manager, getLeads, nil, &page)
+		if got := firstNames(page.GetLeads.Items); !slices.Equal(got, []string{"Alice", "Bella"}) {
+			t.Errorf("leads = %v, want [Alice Bella]", got)
+		}
+	})
+
+	t.Run("a lead out of scope is not found", func(t *testing.T) {
+		errs := s.do(&ann, `query($id: ID!) { getLead(leadID: $id) { leadID } }`, map[string]any{"id": bobs.LeadID}, nil)
+		if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+			t.Errorf("errors = %+v, want one NOT_FOUND", errs)
+		}
+	})
+}
+
+func TestLeadsConnectionPages(t *testing.T) {
+	s := newTestServer(t)
+	seller := s.user("seller", "SALES_EXECUTIVE")
+	for _, name := range []string{"First", "Second", "Third"} {
+		s.createLead(seller, name)
+	}
+
+	const query = `query($after: String) {
+		getLeadsConnection(first: 2, after: $after) {
+			edges { node { firstName } cursor }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+	type connection struct {
+		GetLeadsConnection struct {
+			Edges []struct {
+				Node   lead
+				Cursor string
+			}
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   string
+			}
+		}
+	}
+	names := func(data connection) []string {
+		var names []string
+		for _, edge := range data.GetLeadsConnection.Edges {
+			names = append(names, edge.Node.FirstName)
+		}
+		return names
+	}
+
+	var first connection
+	s.mustDo(&seller, query, nil, &first)
+	if got := names(first); !slices.Equal(got, []string{"Third", "Second"}) || !first.GetLeadsConnection.PageInfo.HasNextPage {
+		t.Fatalf("first page = %v (next %v), want [Third Second] and a next page", got, first.GetLeadsConnection.PageInfo.HasNextPage)
+	}
+
+	var second connection
+	s.mustDo(&seller, query, map[string]any{"after": first.GetLeadsConnection.PageInfo.EndCursor}, &second)
+	if got := names(second); !slices.Equal(got, []string{"First"}) || second.GetLeadsConnection.PageInfo.HasNextPage {
+		t.Errorf("second page = %v (next %v), want [First] and no next page", got, second.GetLeadsConnection.PageInfo.HasNextPage)
+	}
+}
+
+func TestUpdateLeadRecordsStageHistory(t *testing.T) {
+	s := newTestServer(t)
+	seller := s.user("seller", "SALES_EXECUTIVE")
+	created := s.createLead(seller, "Ann")
+
+	s.mustDo(&seller, `mutation($id: ID!, $input: UpdateLeadInput!) { updateLead(leadID: $id, input: $input) { leadID } }`,
+		map[string]any{"id": created.LeadID, "input": map[string]any{
+			"email":              "ann@lead.example",
+			"leadSource":         "Referral",
+			"initialContactDate": "2024-01-15",
+			"leadAssignedTo":     seller.ID.String(),
+			"leadStage":          "IN_PROGRESS",
+			"leadNotes":          "",
+			"leadPriority":       "HIGH",
+			"leadType":           "SMALL",
+			"organizationID":     s.organization.ID.String(),
+			"campaignID":         s.campaign.ID.String(),
+		}}, nil)
+
+	var data struct {
+		GetLead struct {
+			StageHistory []struct {
+				OldStage  string
+				NewStage  string
+				ChangedBy struct{ UserID string }
+			}
+			DurationInCurrentStage int
+		}
+	}
+	s.mustDo(&seller, `query($id: ID!) { getLead(leadID: $id) { stageHistory { oldStage newStage changedBy { userID } } durationInCurrentStage } }`,
+		map[string]any{"id": created.LeadID}, &data)
+	history := data.GetLead.StageHistory
+	if len(history) != 1 || history[0].OldStage != "NEW" || history[0].NewStage != "IN_PROGRESS" || history[0].ChangedBy.UserID != seller.ID.String() {
+		t.Errorf("stage history = %+v, want NEW to IN_PROGRESS by %s", history, seller.ID)
+	}
+	if duration := data.GetLead.DurationInCurrentStage; duration < 0 || duration > 60 {
+		t.Errorf("durationInCurrentStage = %d, want the seconds since the update", duration)
+	}
+}
+
+func TestMergeLeadsChecksTheIDs(t *testing.T) {
+	s := newTestServer(t)
+	seller := s.user("seller", "SALES_EXECUTIVE")
+	survivor := s.createLead(seller, "Ann")
+	duplicate := s.createLead(seller, "Anne")
+
+	const mergeLeads = `mutation($survivor: ID!, $duplicates: [ID!]!) { mergeLeads(survivorID: $survivor, duplicateIDs: $duplicates) { leadID } }`
+	errs := s.do(&seller, mergeLeads, map[string]any{"survivor": survivor.LeadID, "duplicates": []string{duplicate.LeadID, strings.ToUpper(survivor.LeadID)}}, nil)
+	if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeValidationFailed) {
+		t.Errorf("merging a lead into itself: errors = %+v, want one %s", errs, apperr.CodeValidationFailed)
+	}
+
+	var data struct{ MergeLeads lead }
+	s.mustDo(&seller, mergeLeads, map[string]any{"survivor": survivor.LeadID, "duplicates": []string{duplicate.LeadID, strings.ToUpper(duplicate.LeadID)}}, &data)
+	if data.MergeLeads.LeadID != survivor.LeadID {
+		t.Errorf("merged lead = %s, want the survivor %s", data.MergeLeads.LeadID, survivor.LeadID)
+	}
+	errs = s.do(&seller, `query($id: ID!) { getLead(leadID: $id) { leadID } }`, map[string]any{"id": duplicate.LeadID}, nil)
+	if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+		t.Errorf("fetching the merged duplicate: errors = %+v, want one %s", errs, apperr.CodeNotFound)
+	}
+}
+
+func TestServicesValidateInput(t *testing.T) {
+	s := newTestServer(t)
+	seller := s.user("seller", "SALES_EXECUTIVE")
+
+	// The test server runs without validation.Extension, so only the service checks the input
+	errs := s.do(&seller, createLead, map[string]any{"input": map[string]any{
+		"firstName":          "",
+		"lastName":           "Doe",
+		"email":              "not an email",
+		"linkedIn":           "",
+		"country":            "India",
+		"phone":              "",
+		"leadSource":         "Referral",
+		"initialContactDate": "2024-01-15",
+		"leadStage":          "NEW",
+		"leadNotes":          "",
+		"leadPriority":       "HIGH",
+		"leadType":           "SMALL",
+		"organizationID":     s.organization.ID.String(),
+		"campaignID":         s.campaign.ID.String(),
+	}}, nil)
+	if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeValidationFailed) {
+		t.Fatalf("errors = %+v, want one %s", errs, apperr.CodeValidationFailed)
+	}
+	want := map[string]string{"firstName": "is required", "email": "must be a valid email address"}
+	if fields := errs[0].Extensions.Fields; !maps.Equal(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
+
+func TestPermissionsAreEnforced(t *testing.T) {
+	s := newTestServer(t)
+	seller := s.user("seller", "SALES_EXECUTIVE")
+
+	const query = `mutation { createUser(input: {name: "Eve", email: "eve@crm.example", password: "Secret1!", role: ADMIN}) { userID } }`
+	tests := []struct {
+		name string
+		user *models.User
+		code apperr.Code
+	}{
+		{"anonymous", nil, apperr.CodeUnauthenticated},
+		{"missing permission", &seller, apperr.CodeForbidden},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := s.do(test.user, query, nil, nil)
+			if len(errs) != 1 || errs[0].Extensions.Code != string(test.code) {
+				t.Errorf("errors = %+v, want one %s", errs, test.code)
+			}
+		})
+	}
+}
+
+func TestActivitiesAndDealsAreScopedToTheirLead(t *testing.T) {
+	s := newTestServer(t)
+	ann := s.user("ann", "SALES_EXECUTIVE")
+	bob := s.user("bob", "SALES_EXECUTIVE")
+	anns := s.createLead(ann, "Alice")
+	bobs := s.createLead(bob, "Bella")
+
+	var activity struct{ CreateActivity struct{ ActivityID string } }
+	s.mustDo(&ann, `mutation($input: CreateActivityInput!) { createActivity(input: $input) { activityID } }`,
+		map[string]any{"input": map[string]any{
+			"activityType":         "Call",
+			"dateTime":             "2024-01-15T10:00:00Z",
+			"communicationChannel": "Phone",
+			"contentNotes":         "Discussed pricing",
+			"participantDetails":   "",
+			"followUpActions":      "",
+			"leadID":               anns.LeadID,
+		}}, &activity)
+	deal := map[string]any{
+		"dealName":            "Website",
+		"leadID":              anns.LeadID,
+		"dealStartDate":       "2024-02-01T00:00:00Z",
+		"dealEndDate":         "2024-06-01T00:00:00Z",
+		"projectRequirements": "",
+		"dealAmount":          "1000 USD",
+		"dealStatus":          "STARTED",
+	}
+	var created struct{ CreateDeal struct{ DealID string } }
+	s.mustDo(&ann, `mutation($input: CreateDealInput!) { createDeal(input: $input) { dealID } }`, map[string]any{"input": deal}, &created)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+	}{
+		{"updating another's activity", `mutation($id: ID!) { updateActivity(activityID: $id, input: {contentNotes: "x"}) { activityID } }`,
+			map[string]any{"id": activity.CreateActivity.ActivityID}},
+		{"adding an activity to another's lead", `mutation($input: CreateActivityInput!) { createActivity(input: $input) { activityID } }`,
+			map[string]any{"input": map[string]any{
+				"activityType": "Call", "dateTime": "2024-01-15T10:00:00Z", "communicationChannel": "Phone",
+				"contentNotes": "", "participantDetails": "", "followUpActions": "", "leadID": anns.LeadID,
+			}}},
+		{"reading another's deal", `query($id: ID!) { getDeal(dealID: $id) { dealID } }`,
+			map[string]any{"id": created.CreateDeal.DealID}},
+		{"updating another's deal", `mutation($id: ID!, $input: UpdateDealInput!) { updateDeal(dealID: $id, input: $input) { dealID } }`,
+			map[string]any{"id": created.CreateDeal.DealID, "input": deal}},
+		{"adding a deal to another's lead", `mutation($input: CreateDealInput!) { createDeal(input: $input) { dealID } }`,
+			map[string]any{"input": deal}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := s.do(&bob, test.query, test.variables, nil)
+			if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+				t.Errorf("errors = %+v, want one %s", errs, apperr.CodeNotFound)
+			}
+		})
+	}
+
+	t.Run("moving a deal to another's lead", func(t *testing.T) {
+		moved := maps.Clone(deal)
+		moved["leadID"] = bobs.LeadID
+		errs := s.do(&ann, `mutation($id: ID!, $input: UpdateDealInput!) { updateDeal(dealID: $id, input: $input) { dealID } }`,
+			map[string]any{"id": created.CreateDeal.DealID, "input": moved}, nil)
+		if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+			t.Errorf("errors = %+v, want one %s", errs, apperr.CodeNotFound)
+		}
+	})
+
+	s.mustDo(&ann, `mutation($id: ID!) { updateActivity(activityID: $id, input: {contentNotes: "Sent a quote"}) { activityID } }`,
+		map[string]any{"id": activity.CreateActivity.ActivityID}, nil)
+}
+
+func TestExchangeRates(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.user("admin", "ADMIN")
+
+	const setRate = `mutation($currency: String!, $rate: String!) { setExchangeRate(currency: $currency, rateToBase: $rate) { currency rateToBase } }`
+	s.mustDo(&admin, setRate, map[string]any{"currency": "eur", "rate": "1.1"}, nil)
+	s.mustDo(&admin, setRate, map[string]any{"currency": "EUR", "rate": "1.2"}, nil)
+	s.mustDo(&admin, setRate, map[string]any{"currency": "INR", "rate": "0.012"}, nil)
+
+	var rates struct {
+		GetExchangeRates []struct{ Currency, RateToBase string }
+	}
+	s.mustDo(&admin, `query { getExchangeRates { currency rateToBase } }`, nil, &rates)
+	got := make([]string, 0, len(rates.GetExchangeRates))
+	for _, rate := range rates.GetExchangeRates {
+		got = append(got, rate.Currency+" "+rate.RateToBase)
+	}
+	if want := []string{"EUR 1.2", "INR 0.012"}; !slices.Equal(got, want) {
+		t.Errorf("rates = %v, want %v", got, want)
+	}
+
+	errs := s.do(&admin, setRate, map[string]any{"currency": "USD", "rate": "1"}, nil)
+	if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeValidationFailed) {
+		t.Errorf("setting the base currency: errors = %+v, want one %s", errs, apperr.CodeValidationFailed)
+	}
+
+	const deleteRate = `mutation { deleteExchangeRate(currency: "EUR") { currency } }`
+	s.mustDo(&admin, deleteRate, nil, nil)
+	errs = s.do(&admin, deleteRate, nil, nil)
+	if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+		t.Errorf("deleting again: errors = %+v, want one %s", errs, apperr.CodeNotFound)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.user("admin", "ADMIN")
+
+	var created struct {
+		CreateWebhook struct {
+			WebhookID string
+			Secret    string
+		}
+	}
+	s.mustDo(&admin, `mutation { createWebhook(input: {url: "https://hooks.example/crm", events: ["lead.created"]}) { webhookID secret } }`, nil, &created)
+	if created.CreateWebhook.Secret == "" {
+		t.Fatal("createWebhook returned no secret")
+	}
+	id := map[string]any{"id": created.CreateWebhook.WebhookID}
+
+	var rotated struct{ RotateWebhookSecret struct{ Secret string } }
+	s.mustDo(&admin, `mutation($id: ID!) { rotateWebhookSecret(webhookID: $id) { secret } }`, id, &rotated)
+	if rotated.RotateWebhookSecret.Secret == "" || rotated.RotateWebhookSecret.Secret == created.CreateWebhook.Secret {
+		t.Errorf("rotated secret = %q, want a new one", rotated.RotateWebhookSecret.Secret)
+	}
+
+	s.mustDo(&admin, `mutation($id: ID!) { updateWebhook(webhookID: $id, input: {active: false}) { webhookID } }`, id, nil)
+	var webhooks struct {
+		GetWebhooks []struct {
+			URL       string
+			Active    bool
+			Secret    *string
+			CreatedBy struct{ UserID string }
+		}
+	}
+	s.mustDo(&admin, `query { getWebhooks { url active secret createdBy { userID } } }`, nil, &webhooks)
+	if len(webhooks.GetWebhooks) != 1 {
+		t.Fatalf("webhooks = %+v, want one", webhooks.GetWebhooks)
+	}
+	if webhook := webhooks.GetWebhooks[0]; webhook.URL != "https://hooks.example/crm" || webhook.Active || webhook.Secret != nil || webhook.CreatedBy.UserID != admin.ID.String() {
+		t.Errorf("webhook = %+v, want the inactive webhook of admin without its secret", webhook)
+	}
+
+	s.mustDo(&admin, `mutation($id: ID!) { deleteWebhook(webhookID: $id) { webhookID } }`, id, nil)
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+	}{
+		{"reading a deleted webhook", `query($id: ID!) { getWebhook(webhookID: $id) { webhookID } }`, id},
+		{"redelivering an unknown delivery", `mutation($id: ID!) { redeliverWebhook(deliveryID: $id) { deliveryID } }`,
+			map[string]any{"id": uuid.NewString()}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := s.do(&admin, test.query, test.variables, nil)
+			if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+				t.Errorf("errors = %+v, want one %s", errs, apperr.CodeNotFound)
+			}
+		})
+	}
+}
+
+func TestAssignmentRules(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.user("admin", "ADMIN")
+	seller := s.user("seller", "SALES_EXECUTIVE")
+
+	const createRule = `mutation($input: AssignmentRuleInput!) { createAssignmentRule(input: $input) { ruleID } }`
+	var created struct{ CreateAssignmentRule struct{ RuleID string } }
+	s.mustDo(&admin, createRule, map[string]any{"input": map[string]any{
+		"name": "India", "priority": 1, "strategy": "ROUND_ROBIN", "countries": []string{"India"}, "userIDs": []string{seller.ID.String()},
+	}}, &created)
+	s.mustDo(&admin, createRule, map[string]any{"input": map[string]any{
+		"name": "Campaign", "priority": 0, "strategy": "LEAST_LOADED", "campaignID": s.campaign.ID.String(), "userIDs": []string{admin.ID.String()},
+	}}, nil)
+
+	var rules struct {
+		GetAssignmentRules []struct {
+			Name  string
+			Users []struct{ UserID string }
+		}
+	}
+	s.mustDo(&admin, `query { getAssignmentRules { name users { userID } } }`, nil, &rules)
+	var names []string
+	for _, rule := range rules.GetAssignmentRules {
+		names = append(names, rule.Name)
+	}
+	if want := []string{"Campaign", "India"}; !slices.Equal(names, want) {
+		t.Errorf("rules = %v, want %v by priority", names, want)
+	}
+
+	tests := []struct {
+		name  string
+		input map[string]any
+		code  apperr.Code
+	}{
+		{"unknown user", map[string]any{"name": "x", "priority": 0, "strategy": "ROUND_ROBIN", "userIDs": []string{uuid.NewString()}}, apperr.CodeNotFound},
+		{"unknown team", map[string]any{"name": "x", "priority": 0, "strategy": "ROUND_ROBIN", "teamID": uuid.NewString()}, apperr.CodeNotFound},
+		{"no candidates", map[string]any{"name": "x", "priority": 0, "strategy": "ROUND_ROBIN"}, apperr.CodeValidationFailed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := s.do(&admin, `mutation($id: ID!, $input: AssignmentRuleInput!) { updateAssignmentRule(ruleID: $id, input: $input) { ruleID } }`,
+				map[string]any{"id": created.CreateAssignmentRule.RuleID, "input": test.input}, nil)
+			if len(errs) != 1 || errs[0].Extensions.Code != string(test.code) {
+				t.Errorf("errors = %+v, want one %s", errs, test.code)
+			}
+		})
+	}
+
+	t.Run("log of another's lead", func(t *testing.T) {
+		other := s.user("other", "SALES_EXECUTIVE")
+		lead := s.createLead(other, "Olga")
+		errs := s.do(&seller, `query($id: ID!) { getLeadAssignmentLog(leadID: $id) { logID } }`, map[string]any{"id": lead.LeadID}, nil)
+		if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeNotFound) {
+			t.Errorf("errors = %+v, want one %s", errs, apperr.CodeNotFound)
+		}
+	})
+}
+
+func TestScoringRulesAreReplaced(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.user("admin", "ADMIN")
+
+	const setRules = `mutation($rules: [ScoringRuleInput!]!) { setScoringRules(rules: $rules) { attribute } }`
+	rule := func(attribute, operator, value string) map[string]any {
+		return map[string]any{"attribute": attribute, "operator": operator, "value": value, "weight": 10}
+	}
+	s.mustDo(&admin, setRules, map[string]any{"rules": []any{rule("LEAD_SOURCE", "EQUALS", "Referral")}}, nil)
+	s.mustDo(&admin, setRules, map[string]any{"rules": []any{rule("LEAD_PRIORITY", "EQUALS", "HIGH"), rule("EMPLOYEES", "GTE", "50")}}, nil)
+
+	errs := s.do(&admin, setRules, map[string]any{"rules": []any{rule("EMPLOYEES", "GTE", "many")}}, nil)
+	if len(errs) != 1 || errs[0].Extensions.Code != string(apperr.CodeValidationFailed) {
+		t.Errorf("invalid rule: errors = %+v, want one %s", errs, apperr.CodeValidationFailed)
+	}
+
+	var rules struct{ GetScoringRules []struct{ Attribute string } }
+	s.mustDo(&admin, `query { getScoringRules { attribute } }`, nil, &rules)
+	var attributes []string
+	for _, rule := range rules.GetScoringRules {
+		attributes = append(attributes, rule.Attribute)
+	}
+	if want := []string{"EMPLOYEES", "LEAD_PRIORITY"}; !slices.Equal(attributes, want) {
+		t.Errorf("rules = %v, want %v", attributes, want)
+	}
+}
+
+func TestJobsAuditLogAndTrashCheckTheirInput(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.user("admin", "ADMIN")
+
+	var jobs struct {
+		GetImportJobs []struct{ JobID string }
+		GetExportJobs []struct{ JobID string }
+	}
+	s.mustDo(&admin, `query { getImportJobs { jobID } getExportJobs { jobID } }`, nil, &jobs)
+	if len(jobs.GetImportJobs) != 0 || len(jobs.GetExportJobs) != 0 {
+		t.Errorf("jobs = %+v, want none", jobs)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		code  apperr.Code
+	}{
+		{"unknown import job", `query { getImportJob(jobID: "` + uuid.NewString() + `") { jobID } }`, apperr.CodeNotFound},
+		{"committing an unknown import job", `mutation { commitImportJob(jobID: "` + uuid.NewString() + `") { jobID } }`, apperr.CodeNotFound},
+		{"unknown export job", `query { getExportJob(jobID: "` + uuid.NewString() + `") { jobID } }`, apperr.CodeNotFound},
+		{"invalid audit date", `query { getAuditLog(filter: {from: "yesterday"}) { totalCount } }`, apperr.CodeValidationFailed},
+		{"restoring a record that is not deleted", `mutation { restore(entityType: LEAD, id: "` + uuid.NewString() + `") { id } }`, apperr.CodeNotFound},
+		{"purging with a negative age", `mutation { purgeTrash(olderThanDays: -1) }`, apperr.CodeValidationFailed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := s.do(&admin, test.query, nil, nil)
+			if len(errs) != 1 || errs[0].Extensions.Code != string(test.code) {
+				t.Errorf("errors = %+v, want one %s", errs, test.code)
+			}
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	initializers "github.com/Zenithive/it-crm-backend/Initializers"
 	"github.com/Zenithive/it-crm-backend/auth"
 	"github.com/Zenithive/it-crm-backend/models"
 	"github.com/google/uuid"
@@ -26,7 +25,7 @@ var allowedExtensions = map[string]bool{
 	".docx": true,
 }
 
-func uploadFileHandler(w http.ResponseWriter, r *http.Request) {
+func (s *server) uploadFileHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		http.Error(w, "Only POST requests allowed", http.StatusMethodNotAllowed)
 		return
@@ -127,7 +126,7 @@ func uploadFileHandler(w http.ResponseWriter, r *http.Request) {
 		ReferenceType: referenceType,
 	}
 
-	if err := initializers.DB.Create(&docDetails).Error; err != nil {
+	if err := s.db.WithContext(r.Context()).Create(&docDetails).Error; err != nil {
 		http.Error(w, "Failed to store document in database", http.StatusInternalServerError)
 		return
 	}
@@ -147,7 +146,7 @@ func saveUploadedFile(file multipart.File, filePath string) error {
 	_, err = io.Copy(dst, file)
 	return err
 }
-func downloadFileHandler(w http.ResponseWriter, r *http.Request) {
+func (s *server) downloadFileHandler(w http.ResponseWriter, r *http.Request) {
 	// Get file ID from URL (e.g., /download?id=1234)
 	fileID := r.URL.Query().Get("id")
 	if fileID == "" {
@@ -157,10 +156,18 @@ func downloadFileHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Fetch file details from DB
 	var document models.Document
-	if err := initializers.DB.First(&document, "id = ?", fileID).Error; err != nil {
+	if err := s.db.First(&document, "id = ?", fileID).Error; err != nil {
 		http.Error(w, "File not found", http.StatusNotFound)
 		return
 	}
+	// Export files hold whole lists, only the user who ran the export gets them
+	if document.ReferenceType == models.ExportDocumentType {
+		userID, err := auth.CurrentUserID(r.Context())
+		if err != nil || userID != document.UserID.String() {
+			http.Error(w, "File not found", http.StatusNotFound)
+			return
+		}
+	}
 	filePath := document.FilePath
 	fmt.Println("File Path:", filePath)
 	// Check if file exists
@@ -186,11 +193,11 @@ func getFileSize(path string) int64 {
 	return file.Size()
 }
 
-func listDocuments(w http.ResponseWriter, r *http.Request) {
+func (s *server) listDocuments(w http.ResponseWriter, r *http.Request) {
 	var documents []models.Document
 
-	// Fetch all documents using GORM
-	result := initializers.DB.Find(&documents)
+	// Fetch all documents using GORM, except export files, see downloadFileHandler
+	result := s.db.Where("reference_type <> ?", models.ExportDocumentType).Find(&documents)
 	if result.Error != nil {
 		http.Error(w, "Database error", http.StatusInternalServerError)
 		return
@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// Field is a value a column can be mapped to.
+type Field struct {
+	Name        string
+	Required    bool
+	Description string
+}
+
+// entityFields lists the fields of each entity. Names follow the GraphQL create inputs;
+// the extra name-based fields (organization, campaign, vendor, skills) are looked up.
+var entityFields = map[string][]Field{
+	models.ImportEntityLead: {
+		{Name: "firstName", Required: true},
+		{Name: "lastName", Required: true},
+		{Name: "email", Required: true},
+		{Name: "linkedIn"},
+		{Name: "country", Required: true},
+		{Name: "phone"},
+		{Name: "leadSource", Required: true},
+		{Name: "initialContactDate", Required: true, Description: "YYYY-MM-DD, also accepts M/D/YYYY"},
+		{Name: "leadAssignedTo", Description: "User ID or email; leave empty to let the assignment rules decide"},
+		{Name: "leadStage", Description: "Defaults to NEW"},
+		{Name: "leadNotes"},
+		{Name: "leadPriority", Description: "Defaults to MEDIUM"},
+		{Name: "leadType", Description: "Defaults to SMALL"},
+		{Name: "organizationID", Description: "Required unless organization is mapped"},
+		{Name: "organization", Description: "Organization name, used when organizationID is empty"},
+		{Name: "campaignID", Description: "Required unless campaign is mapped"},
+		{Name: "campaign", Description: "Campaign name, used when campaignID is empty"},
+	},
+	models.ImportEntityOrganization: {
+		{Name: "organizationName", Required: true},
+		{Name: "organizationEmail"},
+		{Name: "organizationWebsite"},
+		{Name: "city"},
+		{Name: "country"},
+		{Name: "noOfEmployees"},
+		{Name: "annualRevenue", Description: "Amount with an optional currency, e.g. \"1,200,000 EUR\"; defaults to the base currency"},
+	},
+	models.ImportEntityResourceProfile: {
+		{Name: "type", Required: true, Description: "CONSULTANT, FREELANCER, CONTRACTOR or EMPLOYEE"},
+		{Name: "firstName", Required: true},
+		{Name: "lastName", Required: true},
+		{Name: "totalExperience", Required: true, Description: "Years"},
+		{Name: "contactInformation", Description: "JSON object or plain text"},
+		{Name: "googleDriveLink"},
+		{Name: "status", Description: "Defaults to ACTIVE"},
+		{Name: "vendorID"},
+		{Name: "vendor", Description: "Vendor company name, used when vendorID is empty"},
+		{Name: "skills", Description: "Skill names or IDs with years, e.g. \"Go:5; React:2\""},
+	},
+}
+
+// entityPermissions is what creating each entity requires, same as the create mutations.
+var entityPermissions = map[string]string{
+	models.ImportEntityLead:            "lead:write:own",
+	models.ImportEntityOrganization:    "organization:write",
+	models.ImportEntityResourceProfile: "resource:write",
+}
+
+// Fields returns the fields an entity's columns can be mapped to.
+func Fields(entity string) ([]Field, error) {
+	fields, ok := entityFields[entity]
+	if !ok {
+		return nil, apperr.Invalid("unknown import entity %q", entity)
+	}
+	return fields, nil
+}
+
+// Permission returns the permission needed to import entity.
+func Permission(entity string) (string, error) {
+	permission, ok := entityPermissions[entity]
+	if !ok {
+		return "", fmt.Errorf("unknown import entity %q", entity)
+	}
+	return permission, nil
+}
+
+// normalizeKey makes "Lead Source", "lead_source" and "leadSource" compare equal.
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, key)
+}
+
+// resolveMapping returns the field each column index feeds. mapping goes from
+// column header to field name; when empty, headers are matched to field names.
+// Unmapped columns are ignored.
+func resolveMapping(entity string, header []string, mapping map[string]string) (map[int]string, error) {
+	fields, err := Fields(entity)
+	if err != nil {
+		return nil, err
+	}
+	fieldsByKey := make(map[string]string, len(fields))
+	for _, field := range fields {
+		fieldsByKey[normalizeKey(field.Name)] = field.Name
+	}
+	columnsByKey := make(map[string]int, len(header))
+	for i, column := range header {
+		columnsByKey[normalizeKey(column)] = i
+	}
+
+	columns := map[int]string{}
+	if len(mapping) == 0 {
+		for i, column := range header {
+			if field, ok := fieldsByKey[normalizeKey(column)]; ok {
+				columns[i] = field
+			}
+		}
+	} else {
+		for column, fieldName := range mapping {
+			index, ok := columnsByKey[normalizeKey(column)]
+			if !ok {
+				return nil, fmt.Errorf("column %q is not in the file", column)
+			}
+			field, ok := fieldsByKey[normalizeKey(fieldName)]
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q for %s", fieldName, entity)
+			}
+			columns[index] = field
+		}
+	}
+
+	mapped := make(map[string]bool, len(columns))
+	for _, field := range columns {
+		mapped[field] = true
+	}
+	var missing []string
+	for _, field := range fields {
+		if field.Required && !mapped[field.Name] {
+			missing = append(missing, field.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no column is mapped to the required fields: %s", strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
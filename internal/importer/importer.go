@@ -0,0 +1,349 @@
+// Package importer creates leads, organizations and resource profiles from CSV
+// and XLSX files. Each upload is tracked as a models.ImportJob with one
+// models.ImportJobRow per data row; a dry run validates every row and stops at
+// PREVIEW_READY until it is committed.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Services create the records, so imported rows are checked and saved by the
+// same services as those of the create mutations (see internal/service).
+type Services struct {
+	Leads interface {
+		Create(ctx context.Context, input generated.CreateLeadInput) (*generated.Lead, error)
+	}
+	Organizations interface {
+		Create(ctx context.Context, input generated.CreateOrganizationInput) (*generated.Organization, error)
+	}
+	ResourceProfiles interface {
+		Create(ctx context.Context, input generated.CreateResourceProfileInput) (*generated.ResourceProfile, error)
+	}
+}
+
+// progressEvery is how many rows are processed between job counter updates.
+const progressEvery = 50
+
+// Dir is where uploaded files are kept while their job may still run. It is
+// outside the served uploads directory, as the files hold personal data.
+var Dir = filepath.Join("storage", "imports")
+
+// PreviewExpiry is how long a previewed dry run can be committed. After that
+// ExpirePreviews fails the job and removes its file.
+const PreviewExpiry = 24 * time.Hour
+
+// Start runs job in the background on behalf of the user in ctx.
+func Start(ctx context.Context, db *gorm.DB, services Services, jobID uuid.UUID) {
+	// The request ends before the job does, keep only the caller's identity
+	background := context.WithValue(context.Background(), auth.UserCtxKey, ctx.Value(auth.UserCtxKey))
+	go func() {
+		if err := Run(background, db, services, jobID); err != nil {
+			log.Printf("Error running import job %s: %v", jobID, err)
+		}
+	}()
+}
+
+// Commit runs a previewed dry-run job for real. Only the user who uploaded the file may commit it.
+func Commit(ctx context.Context, db *gorm.DB, services Services, jobID uuid.UUID) (*models.ImportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var job models.ImportJob
+	if err := db.First(&job, "id = ? AND created_by = ?", jobID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("import job not found")
+		}
+		return nil, err
+	}
+	if !job.DryRun || job.Status != models.ImportStatusPreviewReady {
+		return nil, apperr.Conflict("only a dry run with status %s can be committed, this job is %s", models.ImportStatusPreviewReady, job.Status)
+	}
+
+	// Guard against a double commit
+	result := db.Model(&models.ImportJob{}).
+		Where("id = ? AND status = ?", job.ID, models.ImportStatusPreviewReady).
+		Updates(map[string]interface{}{"dry_run": false, "status": models.ImportStatusPending})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperr.Conflict("the import job is already being committed")
+	}
+	job.DryRun = false
+	job.Status = models.ImportStatusPending
+
+	Start(ctx, db, services, job.ID)
+	return &job, nil
+}
+
+// Run processes every row of the job's file and records the outcome.
+func Run(ctx context.Context, db *gorm.DB, services Services, jobID uuid.UUID) (err error) {
+	var job models.ImportJob
+	if err := db.First(&job, "id = ?", jobID).Error; err != nil {
+		return err
+	}
+
+	fail := func(cause error) error {
+		finished := time.Now()
+		job.Status = models.ImportStatusFailed
+		job.Error = cause.Error()
+		job.FinishedAt = &finished
+		// A failed job cannot be committed, so its file is no longer needed
+		removeFile(&job)
+		if err := db.Select("status", "error", "finished_at", "file_path").Save(&job).Error; err != nil {
+			log.Printf("Error saving failed import job %s: %v", job.ID, err)
+		}
+		return cause
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fail(fmt.Errorf("internal error: %v", recovered))
+		}
+	}()
+
+	started := time.Now()
+	job.Status = models.ImportStatusRunning
+	job.StartedAt = &started
+	job.TotalRows, job.ValidRows, job.CreatedRows, job.FailedRows = 0, 0, 0, 0
+	if err := db.Select("status", "started_at", "total_rows", "valid_rows", "created_rows", "failed_rows").Save(&job).Error; err != nil {
+		return err
+	}
+	// A commit replaces the preview rows
+	if err := db.Where("job_id = ?", job.ID).Delete(&models.ImportJobRow{}).Error; err != nil {
+		return fail(fmt.Errorf("failed to clear previous rows: %w", err))
+	}
+
+	// --- Read and map the file ---
+	file, err := os.Open(job.FilePath)
+	if err != nil {
+		return fail(errors.New("the uploaded file is no longer available"))
+	}
+	header, records, err := ReadTable(job.FileName, file)
+	file.Close()
+	if err != nil {
+		return fail(err)
+	}
+	columns, err := resolveMapping(job.Entity, header, job.Mapping)
+	if err != nil {
+		return fail(err)
+	}
+	job.TotalRows = len(records)
+
+	// --- Validate and create row by row ---
+	v := newValidator(ctx, db, job.DryRun)
+	rows := make([]models.ImportJobRow, 0, progressEvery)
+	flush := func() error {
+		if len(rows) > 0 {
+			if err := db.Create(&rows).Error; err != nil {
+				return fmt.Errorf("failed to save row results: %w", err)
+			}
+			rows = rows[:0]
+		}
+		return db.Select("total_rows", "valid_rows", "created_rows", "failed_rows").Save(&job).Error
+	}
+	for _, rec := range records {
+		values := map[string]string{}
+		for index, field := range columns {
+			if index < len(rec.Cells) {
+				if cell := strings.TrimSpace(rec.Cells[index]); cell != "" {
+					values[field] = cell
+				}
+			}
+		}
+		row := processRow(ctx, v, services, job.Entity, job.DryRun, rec.Line, values)
+		row.JobID = job.ID
+		switch row.Status {
+		case models.ImportRowValid:
+			job.ValidRows++
+		case models.ImportRowCreated:
+			job.ValidRows++
+			job.CreatedRows++
+		default:
+			job.FailedRows++
+		}
+		rows = append(rows, row)
+		if len(rows) == progressEvery {
+			if err := flush(); err != nil {
+				return fail(err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return fail(err)
+	}
+
+	// --- Finish ---
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Status = models.ImportStatusPreviewReady
+	if !job.DryRun {
+		job.Status = models.ImportStatusCompleted
+		removeFile(&job)
+	}
+	return db.Select("status", "finished_at", "file_path").Save(&job).Error
+}
+
+// ExpirePreviews removes, every interval, the files of failed jobs and of dry
+// runs previewed more than PreviewExpiry ago, which then fail.
+func ExpirePreviews(db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := expirePreviews(db, time.Now()); err != nil {
+			log.Printf("Error expiring import previews: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+func expirePreviews(db *gorm.DB, now time.Time) error {
+	var jobs []models.ImportJob
+	if err := db.Where("file_path <> '' AND (status = ? OR (status = ? AND finished_at < ?))",
+		models.ImportStatusFailed, models.ImportStatusPreviewReady, now.Add(-PreviewExpiry)).
+		Find(&jobs).Error; err != nil {
+		return fmt.Errorf("failed to load expired import jobs: %w", err)
+	}
+	for _, job := range jobs {
+		updates := map[string]interface{}{"file_path": ""}
+		if job.Status == models.ImportStatusPreviewReady {
+			updates["status"] = models.ImportStatusFailed
+			updates["error"] = "the preview expired, upload the file again"
+		}
+		// Guard against a commit started meanwhile
+		result := db.Model(&models.ImportJob{}).Where("id = ? AND status = ?", job.ID, job.Status).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("failed to expire import job %s: %w", job.ID, result.Error)
+		}
+		if result.RowsAffected == 1 {
+			removeFile(&job)
+		}
+	}
+	return nil
+}
+
+// removeFile deletes the uploaded file of job and clears its path.
+func removeFile(job *models.ImportJob) {
+	if job.FilePath == "" {
+		return
+	}
+	if err := os.Remove(job.FilePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Error removing import file %s: %v", job.FilePath, err)
+	}
+	job.FilePath = ""
+}
+
+// processRow validates one row and, unless dryRun, creates the record. The
+// input is checked like the service will before it is saved, so a dry run
+// reports the same violations.
+func processRow(ctx context.Context, v *validator, services Services, entity string, dryRun bool, line int, values map[string]string) models.ImportJobRow {
+	row := models.ImportJobRow{
+		ID:       uuid.New(),
+		Row:      line,
+		Values:   values,
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+	var input any
+	var create func() (string, error)
+	var errs, warnings []string
+	switch entity {
+	case models.ImportEntityLead:
+		var leadInput generated.CreateLeadInput
+		leadInput, warnings, errs = v.lead(line, values)
+		input = leadInput
+		create = func() (string, error) {
+			lead, err := services.Leads.Create(ctx, leadInput)
+			if err != nil {
+				return "", err
+			}
+			return lead.LeadID, nil
+		}
+	case models.ImportEntityOrganization:
+		var organizationInput generated.CreateOrganizationInput
+		organizationInput, warnings, errs = v.organization(line, values)
+		input = organizationInput
+		create = func() (string, error) {
+			organization, err := services.Organizations.Create(ctx, organizationInput)
+			if err != nil {
+				return "", err
+			}
+			return organization.OrganizationID, nil
+		}
+	case models.ImportEntityResourceProfile:
+		var profileInput generated.CreateResourceProfileInput
+		profileInput, errs = v.resourceProfile(values)
+		input = profileInput
+		create = func() (string, error) {
+			profile, err := services.ResourceProfiles.Create(ctx, profileInput)
+			if err != nil {
+				return "", err
+			}
+			return profile.ResourceProfileID, nil
+		}
+	default:
+		errs = []string{fmt.Sprintf("unknown import entity %q", entity)}
+	}
+	if input != nil {
+		errs = append(errs, violations(validation.Input(input))...)
+	}
+	row.Warnings = append(row.Warnings, warnings...)
+	if len(errs) > 0 {
+		row.Status = models.ImportRowFailed
+		row.Errors = append(row.Errors, errs...)
+		return row
+	}
+	if dryRun {
+		row.Status = models.ImportRowValid
+		return row
+	}
+
+	recordID, err := create()
+	if err != nil {
+		row.Status = models.ImportRowFailed
+		row.Errors = append(row.Errors, err.Error())
+		return row
+	}
+	row.Status = models.ImportRowCreated
+	if id, err := uuid.Parse(recordID); err == nil {
+		row.RecordID = &id
+	}
+	return row
+}
+
+// violations lists the field violations of err as row errors, e.g. "email must
+// be a valid email address".
+func violations(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) || len(appErr.Fields) == 0 {
+		return []string{err.Error()}
+	}
+	fields := slices.Sorted(maps.Keys(appErr.Fields))
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, field+" "+appErr.Fields[field])
+	}
+	return result
+}
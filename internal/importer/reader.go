@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxRows bounds the data rows of one file, so a job finishes in reasonable time.
+const MaxRows = 5000
+
+// Extensions lists the file types ReadTable understands.
+var Extensions = map[string]bool{
+	".csv":  true,
+	".xlsx": true,
+}
+
+// record is one data row. Line is the 1-based spreadsheet row, the header being line 1.
+type record struct {
+	Line  int
+	Cells []string
+}
+
+// ReadTable reads the header and data rows of a CSV file or of the first sheet of an
+// XLSX workbook. Blank rows are skipped.
+func ReadTable(fileName string, r io.Reader) ([]string, []record, error) {
+	var rows [][]string
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		var err error
+		if rows, err = reader.ReadAll(); err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+	case ".xlsx":
+		workbook, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid XLSX: %w", err)
+		}
+		defer workbook.Close()
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil, errors.New("the workbook has no sheets")
+		}
+		if rows, err = workbook.GetRows(sheets[0]); err != nil {
+			return nil, nil, fmt.Errorf("failed to read sheet %q: %w", sheets[0], err)
+		}
+	default:
+		return nil, nil, errors.New("invalid file type, only CSV and XLSX are allowed")
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, errors.New("the file is empty")
+	}
+	header := make([]string, len(rows[0]))
+	for i, column := range rows[0] {
+		header[i] = strings.TrimSpace(column)
+	}
+	// Excel writes a byte order mark in front of UTF-8 CSV files
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	records := []record{}
+	for i, cells := range rows[1:] {
+		if isBlank(cells) {
+			continue
+		}
+		if len(records) == MaxRows {
+			return nil, nil, fmt.Errorf("the file has more than %d rows, split it", MaxRows)
+		}
+		records = append(records, record{Line: i + 2, Cells: cells})
+	}
+	return header, records, nil
+}
+
+func isBlank(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,393 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/assignment"
+	"github.com/Zenithive/it-crm-backend/internal/dedupe"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// dateLayouts are tried in order for initialContactDate; the first is what CreateLead expects.
+var dateLayouts = []string{"2006-01-02", time.RFC3339, "1/2/2006", "2006/01/02", "02-Jan-2006"}
+
+// validator turns mapped rows into create inputs: it parses the cells, resolves
+// references given by name and looks for duplicates. The inputs are checked by
+// validation.Input like the services check them. It caches the lookups made
+// while going through a file.
+type validator struct {
+	ctx context.Context
+	db  *gorm.DB
+
+	ids           map[string]map[string]bool // table -> ID -> exists
+	names         map[string]map[string]*uuid.UUID
+	seen          map[string]int // normalized key -> first row, for duplicates within the file
+	assignPreview bool
+}
+
+func newValidator(ctx context.Context, db *gorm.DB, dryRun bool) *validator {
+	return &validator{
+		ctx:           ctx,
+		db:            db,
+		ids:           map[string]map[string]bool{},
+		names:         map[string]map[string]*uuid.UUID{},
+		seen:          map[string]int{},
+		assignPreview: dryRun,
+	}
+}
+
+// exists reports whether a row with id exists in model's table.
+func (v *validator) exists(model interface{}, table, id string) (bool, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return false, nil
+	}
+	if v.ids[table] == nil {
+		v.ids[table] = map[string]bool{}
+	}
+	if found, ok := v.ids[table][id]; ok {
+		return found, nil
+	}
+	var count int64
+	if err := v.db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	v.ids[table][id] = count > 0
+	return count > 0, nil
+}
+
+// lookup finds the ID of the row of model whose column matches value case-insensitively.
+func (v *validator) lookup(model interface{}, table, column, value string) (*uuid.UUID, error) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	if v.names[table] == nil {
+		v.names[table] = map[string]*uuid.UUID{}
+	}
+	if id, ok := v.names[table][key]; ok {
+		return id, nil
+	}
+	var ids []uuid.UUID
+	if err := v.db.Model(model).Where("LOWER(TRIM("+column+")) = ?", key).Limit(2).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	var id *uuid.UUID
+	if len(ids) > 1 {
+		return nil, fmt.Errorf("%q matches several %s, use the ID instead", value, table)
+	}
+	if len(ids) == 1 {
+		id = &ids[0]
+	}
+	v.names[table][key] = id
+	return id, nil
+}
+
+// reference resolves a column given either as an ID or, failing that, as a name.
+func (v *validator) reference(model interface{}, table, nameColumn, id, name string) (string, error) {
+	if id != "" {
+		found, err := v.exists(model, table, id)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			return "", fmt.Errorf("%s not found", strings.TrimSuffix(table, "s"))
+		}
+		return id, nil
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s is required", strings.TrimSuffix(table, "s"))
+	}
+	found, err := v.lookup(model, table, nameColumn, name)
+	if err != nil {
+		return "", err
+	}
+	if found == nil {
+		return "", fmt.Errorf("%s %q not found", strings.TrimSuffix(table, "s"), name)
+	}
+	return found.String(), nil
+}
+
+// firstSeen remembers key for line and returns the line it was first seen on, or 0.
+func (v *validator) firstSeen(key string, line int) int {
+	if key == "" {
+		return 0
+	}
+	if first, ok := v.seen[key]; ok {
+		return first
+	}
+	v.seen[key] = line
+	return 0
+}
+
+// enumValue upper-cases a cell and turns spaces and dashes into underscores, so
+// "In progress" reads as IN_PROGRESS.
+func enumValue(cell, fallback string) string {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return fallback
+	}
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToUpper(cell))
+}
+
+// --- Leads ---
+
+func (v *validator) lead(line int, values map[string]string) (generated.CreateLeadInput, []string, []string) {
+	var errs, warnings []string
+	input := generated.CreateLeadInput{
+		FirstName:    values["firstName"],
+		LastName:     values["lastName"],
+		Email:        values["email"],
+		LinkedIn:     values["linkedIn"],
+		Country:      values["country"],
+		Phone:        values["phone"],
+		LeadSource:   values["leadSource"],
+		LeadNotes:    values["leadNotes"],
+		LeadStage:    generated.LeadStage(enumValue(values["leadStage"], string(generated.LeadStageNew))),
+		LeadPriority: generated.LeadPriority(enumValue(values["leadPriority"], string(generated.LeadPriorityMedium))),
+		LeadType:     generated.LeadType(enumValue(values["leadType"], string(generated.LeadTypeSmall))),
+	}
+	if !input.LeadStage.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid leadStage %q", values["leadStage"]))
+	}
+	if !input.LeadPriority.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid leadPriority %q", values["leadPriority"]))
+	}
+	if !input.LeadType.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid leadType %q", values["leadType"]))
+	}
+
+	if date, ok := parseDate(values["initialContactDate"]); ok {
+		input.InitialContactDate = date.Format(dateLayouts[0])
+	} else {
+		errs = append(errs, fmt.Sprintf("invalid initialContactDate %q, expected YYYY-MM-DD", values["initialContactDate"]))
+	}
+
+	organizationID, err := v.reference(&models.Organization{}, "organizations", "organization_name", values["organizationID"], values["organization"])
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	input.OrganizationID = organizationID
+	campaignID, err := v.reference(&models.Campaign{}, "campaigns", "campaign_name", values["campaignID"], values["campaign"])
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	input.CampaignID = campaignID
+
+	// Owner: a user ID or email, checked against the caller's lead:assign scope
+	if assignee := values["leadAssignedTo"]; assignee != "" {
+		userID, err := v.user(assignee)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			input.LeadAssignedTo = &userID
+		}
+	} else if v.assignPreview && len(errs) == 0 {
+		if preview, err := v.previewAssignment(input); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			warnings = append(warnings, preview)
+		}
+	}
+
+	// --- Duplicates ---
+	if first := v.firstSeen("lead:email:"+dedupe.NormalizeEmail(input.Email), line); first != 0 {
+		warnings = append(warnings, fmt.Sprintf("same email as row %d", first))
+	}
+	candidate := dedupe.Lead{
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+		Email:     input.Email,
+		Phone:     input.Phone,
+	}
+	candidate.OrganizationID, _ = uuid.Parse(organizationID)
+	matches, err := dedupe.FindLeadDuplicates(v.db.Scopes(auth.LeadScope(v.ctx, auth.LeadActionRead)), candidate)
+	if err != nil {
+		return input, warnings, append(errs, err.Error())
+	}
+	for _, match := range matches {
+		warnings = append(warnings, fmt.Sprintf("possible duplicate of lead %s (%s %s): %s",
+			match.Lead.ID, match.Lead.FirstName, match.Lead.LastName, strings.Join(match.Reasons, ", ")))
+	}
+	return input, warnings, errs
+}
+
+// user resolves a user ID or email and checks the caller may assign leads to them.
+func (v *validator) user(value string) (string, error) {
+	var user models.User
+	query := v.db.Select("id")
+	if _, err := uuid.Parse(value); err == nil {
+		query = query.Where("id = ?", value)
+	} else {
+		query = query.Where("LOWER(email) = ?", strings.ToLower(value))
+	}
+	if err := query.First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("assigned user %q not found", value)
+		}
+		return "", err
+	}
+	canAssign, err := auth.CanAssignLeadTo(v.ctx, user.ID, func(ctx context.Context, userID string) ([]uuid.UUID, error) {
+		return auth.TeamUserIDs(ctx, v.db, userID)
+	})
+	if err != nil {
+		return "", err
+	}
+	if !canAssign {
+		return "", fmt.Errorf("unauthorized to assign leads to %q", value)
+	}
+	return user.ID.String(), nil
+}
+
+// previewAssignment runs the assignment rules in a transaction that is rolled back,
+// so the round-robin position does not move.
+func (v *validator) previewAssignment(input generated.CreateLeadInput) (string, error) {
+	campaignID, _ := uuid.Parse(input.CampaignID)
+	var preview string
+	errPreview := errors.New("rollback")
+	err := v.db.Transaction(func(tx *gorm.DB) error {
+		decision, err := assignment.Assign(tx, assignment.Lead{Country: input.Country, CampaignID: campaignID})
+		if err != nil {
+			return err
+		}
+		preview = fmt.Sprintf("would be assigned to %s (%s)", decision.User.Name, decision.Reason)
+		return errPreview
+	})
+	if !errors.Is(err, errPreview) {
+		return "", err
+	}
+	return preview, nil
+}
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// --- Organizations ---
+
+func (v *validator) organization(line int, values map[string]string) (generated.CreateOrganizationInput, []string, []string) {
+	var errs, warnings []string
+	website := values["organizationWebsite"]
+	input := generated.CreateOrganizationInput{
+		OrganizationName:    values["organizationName"],
+		OrganizationEmail:   values["organizationEmail"],
+		OrganizationWebsite: &website,
+		City:                values["city"],
+		Country:             values["country"],
+		NoOfEmployees:       values["noOfEmployees"],
+		AnnualRevenue:       models.Money{Currency: models.BaseCurrency()},
+	}
+	if revenue := values["annualRevenue"]; revenue != "" {
+		money, err := models.ParseMoney(revenue, models.BaseCurrency())
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid annualRevenue %q: %v", revenue, err))
+		}
+		input.AnnualRevenue = money
+	}
+
+	if first := v.firstSeen("organization:name:"+dedupe.NormalizeCompanyName(input.OrganizationName), line); first != 0 {
+		warnings = append(warnings, fmt.Sprintf("same name as row %d", first))
+	}
+	if first := v.firstSeen("organization:domain:"+dedupe.WebsiteDomain(website), line); first != 0 {
+		warnings = append(warnings, fmt.Sprintf("same website domain as row %d", first))
+	}
+	matches, err := dedupe.FindOrganizationDuplicates(v.db, dedupe.Organization{
+		Name:    input.OrganizationName,
+		Email:   input.OrganizationEmail,
+		Website: website,
+	})
+	if err != nil {
+		return input, warnings, append(errs, err.Error())
+	}
+	for _, match := range matches {
+		warnings = append(warnings, fmt.Sprintf("possible duplicate of organization %s (%s): %s",
+			match.Organization.ID, match.Organization.OrganizationName, strings.Join(match.Reasons, ", ")))
+	}
+	return input, warnings, errs
+}
+
+// --- Resource profiles ---
+
+func (v *validator) resourceProfile(values map[string]string) (generated.CreateResourceProfileInput, []string) {
+	var errs []string
+	input := generated.CreateResourceProfileInput{
+		Type:        generated.ResourceType(enumValue(values["type"], "")),
+		FirstName:   values["firstName"],
+		LastName:    values["lastName"],
+		Status:      generated.ResourceStatus(enumValue(values["status"], string(generated.ResourceStatusActive))),
+		SkillInputs: []*generated.ResourceSkillInput{},
+	}
+	if !input.Type.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid type %q", values["type"]))
+	}
+	if !input.Status.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid status %q", values["status"]))
+	}
+	experience, err := strconv.ParseFloat(strings.TrimSpace(values["totalExperience"]), 64)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("invalid totalExperience %q", values["totalExperience"]))
+	}
+	input.TotalExperience = experience
+
+	// contactInformation is stored as JSON, plain text becomes a JSON string
+	contact := strings.TrimSpace(values["contactInformation"])
+	if contact != "" && !json.Valid([]byte(contact)) {
+		encoded, _ := json.Marshal(contact)
+		contact = string(encoded)
+	}
+	if contact == "" {
+		contact = "{}"
+	}
+	input.ContactInformation = contact
+
+	if link := values["googleDriveLink"]; link != "" {
+		input.GoogleDriveLink = &link
+	}
+	if values["vendorID"] != "" || values["vendor"] != "" {
+		vendorID, err := v.reference(&models.Vendor{}, "vendors", "company_name", values["vendorID"], values["vendor"])
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			input.VendorID = &vendorID
+		}
+	}
+
+	// skills: "Go:5; React:2", years default to 0
+	for _, entry := range strings.FieldsFunc(values["skills"], func(r rune) bool { return r == ';' || r == '\n' }) {
+		name, yearsText, _ := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		years := 0.0
+		if strings.TrimSpace(yearsText) != "" {
+			if years, err = strconv.ParseFloat(strings.TrimSpace(yearsText), 64); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid years for skill %q", name))
+				continue
+			}
+		}
+		var skillID string
+		if _, parseErr := uuid.Parse(name); parseErr == nil {
+			skillID, err = v.reference(&models.Skill{}, "skills", "name", name, "")
+		} else {
+			skillID, err = v.reference(&models.Skill{}, "skills", "name", "", name)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		input.SkillInputs = append(input.SkillInputs, &generated.ResourceSkillInput{SkillID: skillID, ExperienceYears: years})
+	}
+	return input, errs
+}
@@ -0,0 +1,104 @@
+// Package loaders batches the database lookups of nested GraphQL fields.
+//
+// gqlgen resolves the fields of list items concurrently, so a field resolver
+// that loads through a Loader has its key collected with those of the other
+// items and fetched in one query instead of one per item.
+package loaders
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// wait is how long a batch stays open for more keys after its first one.
+	wait = 2 * time.Millisecond
+	// maxBatch dispatches a batch early once it holds this many keys.
+	maxBatch = 500
+)
+
+// Loader collects the keys requested within wait of each other into one call
+// of fetch, and remembers the results for its lifetime.
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	batch   *batch[K]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable] struct {
+	ctx  context.Context
+	keys []K
+}
+
+// NewLoader returns a Loader that fetches through fetch. Keys missing from the
+// map fetch returns load as the zero value.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, results: make(map[K]*result[V])}
+}
+
+// Load returns the value of key, fetching it with the other keys of its batch.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		if l.batch == nil {
+			b := &batch[K]{ctx: ctx}
+			l.batch = b
+			time.AfterFunc(wait, func() { l.dispatch(b) })
+		}
+		l.batch.keys = append(l.batch.keys, key)
+		if len(l.batch.keys) >= maxBatch {
+			b := l.batch
+			l.batch = nil
+			go l.run(b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch runs b unless it was already run for being full.
+func (l *Loader[K, V]) dispatch(b *batch[K]) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+	l.run(b)
+}
+
+func (l *Loader[K, V]) run(b *batch[K]) {
+	values, err := l.fetch(b.ctx, b.keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range b.keys {
+		r := l.results[key]
+		r.value, r.err = values[key], err
+		if err != nil {
+			// Let a later load try again
+			delete(l.results, key)
+		}
+		close(r.done)
+	}
+}
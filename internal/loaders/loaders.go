@@ -0,0 +1,59 @@
+package loaders
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// Loaders are the loaders of one GraphQL response. They are not shared between
+// responses, so their caches never serve data older than the request.
+type Loaders struct {
+	Users           *Loader[uuid.UUID, models.User]
+	Organizations   *Loader[uuid.UUID, models.Organization]
+	CampaignUsers   *Loader[uuid.UUID, []models.User]
+	VendorResources *Loader[uuid.UUID, []models.ResourceProfile]
+	ResourceSkills  *Loader[uuid.UUID, []models.ResourceSkill]
+	StageHistory    *Loader[uuid.UUID, []models.LeadStageHistory]
+}
+
+// Source fetches the batches of the loaders, each keyed by the id the field
+// resolver has: a user, an organization, or the campaign, vendor, resource
+// profile or lead whose users, resources, skills or stage history are loaded.
+// Stage history comes oldest change first.
+type Source struct {
+	Users           func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.User, error)
+	Organizations   func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.Organization, error)
+	CampaignUsers   func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.User, error)
+	VendorResources func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ResourceProfile, error)
+	ResourceSkills  func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ResourceSkill, error)
+	StageHistory    func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.LeadStageHistory, error)
+}
+
+type ctxKey struct{}
+
+// New returns loaders fetching from source.
+func New(source Source) *Loaders {
+	return &Loaders{
+		Users:           NewLoader(source.Users),
+		Organizations:   NewLoader(source.Organizations),
+		CampaignUsers:   NewLoader(source.CampaignUsers),
+		VendorResources: NewLoader(source.VendorResources),
+		ResourceSkills:  NewLoader(source.ResourceSkills),
+		StageHistory:    NewLoader(source.StageHistory),
+	}
+}
+
+// NewContext returns ctx carrying a fresh set of loaders fetching from source.
+func NewContext(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, ctxKey{}, New(source))
+}
+
+// For returns the loaders of ctx, or unshared ones fetching from source if it carries none.
+func For(ctx context.Context, source Source) *Loaders {
+	if l, ok := ctx.Value(ctxKey{}).(*Loaders); ok {
+		return l
+	}
+	return New(source)
+}
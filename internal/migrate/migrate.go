@@ -0,0 +1,321 @@
+// Package migrate applies the versioned SQL migrations in migrations/ and
+// records which have run in the schema_migrations table.
+//
+// A migration is a pair of files, <version>_<name>.up.sql and
+// <version>_<name>.down.sql, where version is a number that orders it. Each
+// file runs in one transaction together with the update of schema_migrations,
+// so a failing migration leaves nothing behind.
+//
+// Databases created by AutoMigrate, before there were migrations, have the
+// baseline schema or that of an earlier release: the first run brings the
+// latter to the baseline with upgrade.sql, then records the baseline as applied
+// instead of running it.
+package migrate
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var files embed.FS
+
+// upgrade brings a database AutoMigrate created before the baseline to it.
+//
+//go:embed upgrade.sql
+var upgrade string
+
+// Dir is where Create writes new migrations, relative to the repository root.
+const Dir = "internal/migrate/migrations"
+
+// baselineVersion is the migration holding the schema AutoMigrate created.
+const baselineVersion = 1
+
+// lockID keys the advisory lock that keeps two instances from migrating at once.
+const lockID = 7_245_001
+
+var (
+	fileName      = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	migrationName = regexp.MustCompile(`^\w+$`)
+	createTable   = regexp.MustCompile(`(?m)^CREATE TABLE "(\w+)"`)
+)
+
+// Migration is one schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, if it was.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrations returns the embedded migrations by version.
+func Migrations() ([]Migration, error) {
+	return load(files, "migrations")
+}
+
+// load reads the migrations in dir of fsys.
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	paths, err := fs.Glob(fsys, dir+"/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, path := range paths {
+		match := fileName.FindStringSubmatch(filepath.Base(path))
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s is not named <version>_<name>.up.sql or .down.sql", path)
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		migration := byVersion[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", migration.Name, match[2], version)
+		}
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies up to steps pending migrations in order, or all of them if steps
+// is 0, and returns those it applied.
+func Up(db *gorm.DB, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := locked(db, func(conn *gorm.DB) error {
+		statuses, err := status(conn)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			if s.AppliedAt != nil {
+				continue
+			}
+			if steps > 0 && len(applied) == steps {
+				break
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(s.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: s.Version, Name: s.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", s.Version, s.Name, err)
+			}
+			log.Printf("Applied migration %04d_%s", s.Version, s.Name)
+			applied = append(applied, s.Migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// those it reverted.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := locked(db, func(conn *gorm.DB) error {
+		statuses, err := status(conn)
+		if err != nil {
+			return err
+		}
+		for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+			s := statuses[i]
+			if s.AppliedAt == nil {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(s.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, "version = ?", s.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %04d_%s failed: %w", s.Version, s.Name, err)
+			}
+			log.Printf("Reverted migration %04d_%s", s.Version, s.Name)
+			reverted = append(reverted, s.Migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// List returns every migration with when it was applied.
+func List(db *gorm.DB) ([]Status, error) {
+	var statuses []Status
+	err := locked(db, func(conn *gorm.DB) error {
+		var err error
+		statuses, err = status(conn)
+		return err
+	})
+	return statuses, err
+}
+
+// Create writes the files of a new, empty migration called name to dir,
+// numbered after the last migration there, and returns their paths.
+func Create(dir, name string) (string, string, error) {
+	if !migrationName.MatchString(name) {
+		return "", "", fmt.Errorf("migration name %q may only contain letters, digits and underscores", name)
+	}
+	existing, err := load(os.DirFS(dir), ".")
+	if err != nil {
+		return "", "", err
+	}
+	version := int64(1)
+	if len(existing) > 0 {
+		version = existing[len(existing)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", version, name))
+	up, down := base+".up.sql", base+".down.sql"
+	if err := os.WriteFile(up, []byte("-- "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte("-- Reverts "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
+
+// status creates schema_migrations if needed and returns every migration with
+// when it was applied.
+func status(conn *gorm.DB) ([]Status, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := prepare(conn); err != nil {
+		return nil, err
+	}
+
+	var rows []schemaMigration
+	if err := conn.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		s := Status{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			s.AppliedAt = &at
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// prepare creates schema_migrations. A database that has the users table but
+// no schema_migrations was created by AutoMigrate, so it gets the baseline
+// recorded as applied. If it lacks any table of the baseline, AutoMigrate ran
+// the models of an earlier release, and upgrade.sql first adds what the
+// baseline has on top of them. It all happens in one transaction, so a failed
+// upgrade is tried again on the next run.
+func prepare(conn *gorm.DB) error {
+	if conn.Migrator().HasTable(&schemaMigration{}) {
+		return nil
+	}
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	var baseline *Migration
+	for i := range migrations {
+		if migrations[i].Version == baselineVersion {
+			baseline = &migrations[i]
+		}
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+		existing := migrator.HasTable("users")
+		if err := migrator.CreateTable(&schemaMigration{}); err != nil {
+			return err
+		}
+		if !existing || baseline == nil {
+			return nil
+		}
+		for _, table := range baselineTables(*baseline) {
+			if !migrator.HasTable(table) {
+				log.Printf("Upgrading the existing schema, which has no %s table, to migration %04d_%s", table, baseline.Version, baseline.Name)
+				if err := tx.Exec(upgrade).Error; err != nil {
+					return fmt.Errorf("failed to upgrade the existing schema to migration %04d_%s: %w", baseline.Version, baseline.Name, err)
+				}
+				break
+			}
+		}
+		log.Printf("Recording migration %04d_%s as applied to the existing schema", baseline.Version, baseline.Name)
+		return tx.Create(&schemaMigration{Version: baseline.Version, Name: baseline.Name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// baselineTables returns the tables the baseline creates.
+func baselineTables(baseline Migration) []string {
+	var tables []string
+	for _, match := range createTable.FindAllStringSubmatch(baseline.Up, -1) {
+		tables = append(tables, match[1])
+	}
+	return tables
+}
+
+// locked runs fn on a single connection holding the migration lock.
+func locked(db *gorm.DB, fn func(conn *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockID).Error; err != nil {
+			return fmt.Errorf("failed to lock migrations: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", lockID)
+		return fn(conn)
+	})
+}
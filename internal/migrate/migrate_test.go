@@ -0,0 +1,48 @@
+package migrate
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// releaseTables are the tables AutoMigrate created in the release before
+// versioned migrations.
+var releaseTables = []string{
+	"users", "campaigns", "campaign_users", "organizations", "leads", "activities",
+	"deals", "resource_profiles", "vendors", "skills", "vendor_skills",
+	"past_projects", "contacts", "performance_ratings", "tasks", "resource_skills",
+	"lead_stage_histories", "documents", "refresh_tokens", "user_demos",
+}
+
+func TestUpgradeCreatesTheBaselineTables(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrations[0].Version != baselineVersion {
+		t.Fatalf("first migration is %04d_%s, want the baseline", migrations[0].Version, migrations[0].Name)
+	}
+	tables := baselineTables(migrations[0])
+	for _, table := range []string{"users", "teams", "role_permissions", "audit_events"} {
+		if !slices.Contains(tables, table) {
+			t.Errorf("baselineTables() = %v, missing %s", tables, table)
+		}
+	}
+
+	created := map[string]bool{}
+	for _, match := range regexp.MustCompile(`CREATE TABLE IF NOT EXISTS "(\w+)"`).FindAllStringSubmatch(upgrade, -1) {
+		created[match[1]] = true
+	}
+	for _, table := range tables {
+		if !slices.Contains(releaseTables, table) && !created[table] {
+			t.Errorf("upgrade.sql does not create the baseline table %s", table)
+		}
+	}
+	for _, column := range []string{`"deal_amount" TO "deal_amount_legacy"`, `"annual_revenue" TO "annual_revenue_legacy"`} {
+		if !strings.Contains(upgrade, column) {
+			t.Errorf("upgrade.sql does not rename %s", column)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+-- Drops everything the baseline created.
+
+DROP TABLE IF EXISTS "audit_events";
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhooks";
+DROP TABLE IF EXISTS "export_jobs";
+DROP TABLE IF EXISTS "import_job_rows";
+DROP TABLE IF EXISTS "import_jobs";
+DROP TABLE IF EXISTS "scoring_rules";
+DROP TABLE IF EXISTS "lead_assignment_logs";
+DROP TABLE IF EXISTS "assignment_rule_users";
+DROP TABLE IF EXISTS "assignment_rules";
+DROP TABLE IF EXISTS "role_permissions";
+DROP TABLE IF EXISTS "exchange_rates";
+DROP TABLE IF EXISTS "user_demos";
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "documents";
+DROP TABLE IF EXISTS "lead_stage_histories";
+DROP TABLE IF EXISTS "resource_skills";
+DROP TABLE IF EXISTS "tasks";
+DROP TABLE IF EXISTS "performance_ratings";
+DROP TABLE IF EXISTS "contacts";
+DROP TABLE IF EXISTS "past_projects";
+DROP TABLE IF EXISTS "vendor_skills";
+DROP TABLE IF EXISTS "skills";
+DROP TABLE IF EXISTS "resource_profiles";
+DROP TABLE IF EXISTS "vendors";
+DROP TABLE IF EXISTS "deals";
+DROP TABLE IF EXISTS "activities";
+DROP TABLE IF EXISTS "leads";
+DROP TABLE IF EXISTS "organizations";
+DROP TABLE IF EXISTS "campaign_users";
+DROP TABLE IF EXISTS "campaigns";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "teams";
+
+DROP TYPE IF EXISTS skill_type;
+DROP TYPE IF EXISTS task_priority;
+DROP TYPE IF EXISTS task_status;
+DROP TYPE IF EXISTS payment_terms;
+DROP TYPE IF EXISTS vendor_status;
+DROP TYPE IF EXISTS resource_status;
+DROP TYPE IF EXISTS resource_type;
@@ -0,0 +1,559 @@
+-- Baseline: the schema as AutoMigrate created it before versioned migrations.
+-- Databases created by AutoMigrate have this recorded as applied without running
+-- it, after upgrade.sql if they predate some of these tables.
+
+CREATE TYPE resource_type AS ENUM ('CONSULTANT', 'FREELANCER', 'CONTRACTOR', 'EMPLOYEE');
+CREATE TYPE resource_status AS ENUM ('ACTIVE', 'INACTIVE', 'ON_BENCH');
+CREATE TYPE vendor_status AS ENUM ('ACTIVE', 'INACTIVE', 'PREFERRED');
+CREATE TYPE payment_terms AS ENUM ('NET_30', 'NET_60', 'NET_90');
+CREATE TYPE task_status AS ENUM ('TODO', 'IN_PROGRESS', 'COMPLETED', 'ON_HOLD');
+CREATE TYPE task_priority AS ENUM ('LOW', 'MEDIUM', 'HIGH', 'URGENT');
+CREATE TYPE skill_type AS ENUM ('FRONTEND', 'BACKEND', 'DESIGN', 'OTHER');
+
+CREATE TABLE "teams" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "description" text,
+    "manager_id" uuid,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_teams_manager_id" ON "teams" ("manager_id");
+CREATE UNIQUE INDEX "idx_teams_name" ON "teams" ("name");
+CREATE INDEX "idx_teams_deleted_at" ON "teams" ("deleted_at");
+
+CREATE TABLE "users" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "google_id" text,
+    "name" text,
+    "email" text,
+    "phone" text,
+    "role" text,
+    "password" text,
+    "team_id" uuid,
+    "manager_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_teams_members" FOREIGN KEY ("team_id") REFERENCES "teams"("id") ON DELETE SET NULL,
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+CREATE INDEX "idx_users_manager_id" ON "users" ("manager_id");
+CREATE INDEX "idx_users_team_id" ON "users" ("team_id");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE "campaigns" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "campaign_name" text,
+    "campaign_country" text,
+    "campaign_region" text,
+    "industry_targeted" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_campaigns_deleted_at" ON "campaigns" ("deleted_at");
+
+CREATE TABLE "campaign_users" (
+    "campaign_id" uuid,
+    "user_id" uuid,
+    PRIMARY KEY ("campaign_id", "user_id"),
+    CONSTRAINT "fk_campaign_users_campaign" FOREIGN KEY ("campaign_id") REFERENCES "campaigns"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_campaign_users_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "organizations" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "organization_name" text,
+    "organization_email" text,
+    "organization_website" text,
+    "city" text,
+    "country" text,
+    "no_of_employees" text,
+    "annual_revenue_amount" numeric(20,2) NOT NULL DEFAULT '0',
+    "annual_revenue_currency" varchar(3) NOT NULL DEFAULT '',
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_organizations_deleted_at" ON "organizations" ("deleted_at");
+
+CREATE TABLE "leads" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "first_name" text,
+    "last_name" text,
+    "email" text,
+    "linked_in" text,
+    "country" text,
+    "phone" text,
+    "lead_source" text,
+    "initial_contact_date" timestamptz,
+    "lead_created_by" uuid,
+    "lead_assigned_to" uuid,
+    "lead_stage" text,
+    "lead_notes" text,
+    "lead_priority" text,
+    "score" bigint NOT NULL DEFAULT 0,
+    "organization_id" uuid,
+    "campaign_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leads_assignee" FOREIGN KEY ("lead_assigned_to") REFERENCES "users"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_organizations_leads" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id"),
+    CONSTRAINT "fk_campaigns_leads" FOREIGN KEY ("campaign_id") REFERENCES "campaigns"("id"),
+    CONSTRAINT "fk_leads_creator" FOREIGN KEY ("lead_created_by") REFERENCES "users"("id") ON DELETE SET NULL
+);
+CREATE INDEX "idx_leads_campaign_id" ON "leads" ("campaign_id");
+CREATE INDEX "idx_leads_organization_id" ON "leads" ("organization_id");
+CREATE INDEX "idx_leads_score" ON "leads" ("score");
+CREATE INDEX "idx_leads_lead_assigned_to" ON "leads" ("lead_assigned_to");
+CREATE INDEX "idx_leads_lead_created_by" ON "leads" ("lead_created_by");
+CREATE INDEX "idx_leads_deleted_at" ON "leads" ("deleted_at");
+
+CREATE TABLE "activities" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "lead_id" uuid,
+    "activity_type" text,
+    "date_time" timestamptz,
+    "communication_channel" text,
+    "content_notes" text,
+    "participant_details" text,
+    "follow_up_actions" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leads_activities" FOREIGN KEY ("lead_id") REFERENCES "leads"("id")
+);
+CREATE INDEX "idx_activities_lead_id" ON "activities" ("lead_id");
+CREATE INDEX "idx_activities_deleted_at" ON "activities" ("deleted_at");
+
+CREATE TABLE "deals" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "deal_name" text,
+    "lead_id" text,
+    "deal_start_date" timestamptz,
+    "deal_end_date" timestamptz,
+    "project_requirements" text,
+    "deal_amount_amount" numeric(20,2) NOT NULL DEFAULT '0',
+    "deal_amount_currency" varchar(3) NOT NULL DEFAULT '',
+    "deal_status" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_deals_deleted_at" ON "deals" ("deleted_at");
+CREATE INDEX "idx_deals_lead_id" ON "deals" ("lead_id");
+
+CREATE TABLE "vendors" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "company_name" varchar(100) NOT NULL,
+    "status" vendor_status NOT NULL,
+    "payment_terms" payment_terms NOT NULL,
+    "address" text NOT NULL,
+    "gst_or_vat_details" varchar(50),
+    "notes" text,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_vendors_company_name" ON "vendors" ("company_name");
+CREATE INDEX "idx_vendors_deleted_at" ON "vendors" ("deleted_at");
+
+CREATE TABLE "resource_profiles" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "type" resource_type NOT NULL,
+    "first_name" varchar(50) NOT NULL,
+    "last_name" varchar(50) NOT NULL,
+    "total_experience" decimal NOT NULL,
+    "contact_information" jsonb NOT NULL,
+    "google_drive_link" varchar(255),
+    "status" resource_status NOT NULL,
+    "vendor_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vendors_resources" FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id")
+);
+CREATE INDEX "idx_resource_profiles_vendor_id" ON "resource_profiles" ("vendor_id");
+CREATE INDEX "idx_resource_profiles_deleted_at" ON "resource_profiles" ("deleted_at");
+
+CREATE TABLE "skills" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(50) NOT NULL,
+    "description" text,
+    "skill_type" varchar(50) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_skills_name" ON "skills" ("name");
+CREATE INDEX "idx_skills_deleted_at" ON "skills" ("deleted_at");
+
+CREATE TABLE "vendor_skills" (
+    "vendor_id" uuid DEFAULT gen_random_uuid(),
+    "skill_id" uuid DEFAULT gen_random_uuid(),
+    PRIMARY KEY ("vendor_id", "skill_id"),
+    CONSTRAINT "fk_vendor_skills_vendor" FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id"),
+    CONSTRAINT "fk_vendor_skills_skill" FOREIGN KEY ("skill_id") REFERENCES "skills"("id")
+);
+
+CREATE TABLE "past_projects" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "resource_profile_id" uuid,
+    "project_name" varchar(100) NOT NULL,
+    "description" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_resource_profiles_past_projects" FOREIGN KEY ("resource_profile_id") REFERENCES "resource_profiles"("id")
+);
+CREATE INDEX "idx_past_projects_resource_profile_id" ON "past_projects" ("resource_profile_id");
+CREATE INDEX "idx_past_projects_deleted_at" ON "past_projects" ("deleted_at");
+
+CREATE TABLE "contacts" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "vendor_id" uuid,
+    "name" varchar(100) NOT NULL,
+    "email" varchar(100),
+    "phone_number" varchar(20),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vendors_contact_list" FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id")
+);
+CREATE INDEX "idx_contacts_vendor_id" ON "contacts" ("vendor_id");
+CREATE INDEX "idx_contacts_deleted_at" ON "contacts" ("deleted_at");
+
+CREATE TABLE "performance_ratings" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "vendor_id" uuid,
+    "rating" bigint NOT NULL,
+    "review" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vendors_performance_ratings" FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id")
+);
+CREATE INDEX "idx_performance_ratings_vendor_id" ON "performance_ratings" ("vendor_id");
+CREATE INDEX "idx_performance_ratings_deleted_at" ON "performance_ratings" ("deleted_at");
+
+CREATE TABLE "tasks" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" uuid NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "status" task_status NOT NULL,
+    "priority" task_priority NOT NULL,
+    "due_date" timestamptz,
+    "reminded_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_tasks_user" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX "idx_tasks_deleted_at" ON "tasks" ("deleted_at");
+
+CREATE TABLE "resource_skills" (
+    "resource_profile_id" uuid,
+    "skill_id" uuid,
+    "experience_years" decimal NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("resource_profile_id", "skill_id"),
+    CONSTRAINT "fk_resource_profiles_resource_skills" FOREIGN KEY ("resource_profile_id") REFERENCES "resource_profiles"("id"),
+    CONSTRAINT "fk_resource_skills_skill" FOREIGN KEY ("skill_id") REFERENCES "skills"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "lead_stage_histories" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "lead_id" uuid NOT NULL,
+    "old_stage" text,
+    "new_stage" text,
+    "changed_at" timestamptz,
+    "changed_by" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_stage_histories_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_lead_stage_histories_changed_by_user" FOREIGN KEY ("changed_by") REFERENCES "users"("id") ON DELETE SET NULL
+);
+CREATE INDEX "idx_lead_stage_histories_changed_by" ON "lead_stage_histories" ("changed_by");
+CREATE INDEX "idx_lead_stage_histories_lead_id" ON "lead_stage_histories" ("lead_id");
+CREATE INDEX "idx_lead_stage_histories_deleted_at" ON "lead_stage_histories" ("deleted_at");
+
+CREATE TABLE "documents" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "title" varchar(255) NOT NULL,
+    "user_id" uuid NOT NULL,
+    "file_path" varchar(255) NOT NULL,
+    "file_size" varchar(50) NOT NULL,
+    "file_type" text,
+    "reference_id" uuid NOT NULL,
+    "reference_type" varchar(50) NOT NULL,
+    "tags" text[],
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_documents_deleted_at" ON "documents" ("deleted_at");
+
+CREATE TABLE "refresh_tokens" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "token" text NOT NULL,
+    "created_at" timestamptz,
+    "expires_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_refresh_tokens_token" UNIQUE ("token")
+);
+CREATE INDEX "idx_refresh_tokens_deleted_at" ON "refresh_tokens" ("deleted_at");
+
+CREATE TABLE "user_demos" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "google_id" text,
+    "name" text,
+    "email" text NOT NULL,
+    "phone" text,
+    "role" text,
+    "password" text,
+    "google_refresh_token" text,
+    "provider" text,
+    "backend_refresh_token" text,
+    "backend_token_expiry" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_user_demos_google_id" UNIQUE ("google_id"),
+    CONSTRAINT "uni_user_demos_email" UNIQUE ("email"),
+    CONSTRAINT "uni_user_demos_backend_refresh_token" UNIQUE ("backend_refresh_token")
+);
+CREATE INDEX "idx_user_demos_deleted_at" ON "user_demos" ("deleted_at");
+
+CREATE TABLE "exchange_rates" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "currency" varchar(3) NOT NULL,
+    "rate_to_base" numeric(20,8) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_exchange_rates_currency" ON "exchange_rates" ("currency");
+CREATE INDEX "idx_exchange_rates_deleted_at" ON "exchange_rates" ("deleted_at");
+
+CREATE TABLE "role_permissions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "role" varchar(50) NOT NULL,
+    "permission" varchar(100) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_role_permission" ON "role_permissions" ("role", "permission");
+CREATE INDEX "idx_role_permissions_deleted_at" ON "role_permissions" ("deleted_at");
+
+CREATE TABLE "assignment_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "priority" bigint NOT NULL DEFAULT 0,
+    "enabled" boolean NOT NULL,
+    "strategy" varchar(20) NOT NULL,
+    "countries" text,
+    "campaign_id" uuid,
+    "team_id" uuid,
+    "last_assigned_user_id" uuid,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_assignment_rules_team_id" ON "assignment_rules" ("team_id");
+CREATE INDEX "idx_assignment_rules_campaign_id" ON "assignment_rules" ("campaign_id");
+CREATE INDEX "idx_assignment_rules_priority" ON "assignment_rules" ("priority");
+CREATE INDEX "idx_assignment_rules_deleted_at" ON "assignment_rules" ("deleted_at");
+
+CREATE TABLE "assignment_rule_users" (
+    "assignment_rule_id" uuid DEFAULT gen_random_uuid(),
+    "user_id" uuid,
+    PRIMARY KEY ("assignment_rule_id", "user_id"),
+    CONSTRAINT "fk_assignment_rule_users_assignment_rule" FOREIGN KEY ("assignment_rule_id") REFERENCES "assignment_rules"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_assignment_rule_users_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "lead_assignment_logs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "lead_id" uuid NOT NULL,
+    "assigned_to" uuid NOT NULL,
+    "assigned_by" uuid,
+    "rule_id" uuid,
+    "rule_name" text,
+    "strategy" varchar(20) NOT NULL,
+    "reason" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_assignment_logs_assigned_to_user" FOREIGN KEY ("assigned_to") REFERENCES "users"("id"),
+    CONSTRAINT "fk_lead_assignment_logs_assigned_by_user" FOREIGN KEY ("assigned_by") REFERENCES "users"("id")
+);
+CREATE INDEX "idx_lead_assignment_logs_rule_id" ON "lead_assignment_logs" ("rule_id");
+CREATE INDEX "idx_lead_assignment_logs_lead_id" ON "lead_assignment_logs" ("lead_id");
+
+CREATE TABLE "scoring_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "attribute" varchar(30) NOT NULL,
+    "operator" varchar(10) NOT NULL,
+    "value" text,
+    "weight" bigint NOT NULL,
+    "description" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_scoring_rules_deleted_at" ON "scoring_rules" ("deleted_at");
+
+CREATE TABLE "import_jobs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "entity" varchar(20) NOT NULL,
+    "file_name" text,
+    "file_path" text,
+    "mapping" text,
+    "dry_run" boolean NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "error" text,
+    "total_rows" bigint NOT NULL DEFAULT 0,
+    "valid_rows" bigint NOT NULL DEFAULT 0,
+    "created_rows" bigint NOT NULL DEFAULT 0,
+    "failed_rows" bigint NOT NULL DEFAULT 0,
+    "created_by" uuid NOT NULL,
+    "started_at" timestamptz,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_import_jobs_created_by_user" FOREIGN KEY ("created_by") REFERENCES "users"("id")
+);
+CREATE INDEX "idx_import_jobs_deleted_at" ON "import_jobs" ("deleted_at");
+CREATE INDEX "idx_import_jobs_created_by" ON "import_jobs" ("created_by");
+CREATE INDEX "idx_import_jobs_status" ON "import_jobs" ("status");
+
+CREATE TABLE "import_job_rows" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "job_id" uuid NOT NULL,
+    "row" bigint NOT NULL,
+    "status" varchar(10) NOT NULL,
+    "record_id" uuid,
+    "values" text,
+    "errors" text,
+    "warnings" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_import_jobs_rows" FOREIGN KEY ("job_id") REFERENCES "import_jobs"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_import_job_rows_job_id" ON "import_job_rows" ("job_id");
+
+CREATE TABLE "export_jobs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "query" text NOT NULL,
+    "filter" text,
+    "sort" text,
+    "columns" text,
+    "format" varchar(10) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "error" text,
+    "row_count" bigint NOT NULL DEFAULT 0,
+    "document_id" uuid,
+    "created_by" uuid NOT NULL,
+    "started_at" timestamptz,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_export_jobs_created_by_user" FOREIGN KEY ("created_by") REFERENCES "users"("id"),
+    CONSTRAINT "fk_export_jobs_document" FOREIGN KEY ("document_id") REFERENCES "documents"("id")
+);
+CREATE INDEX "idx_export_jobs_created_by" ON "export_jobs" ("created_by");
+CREATE INDEX "idx_export_jobs_status" ON "export_jobs" ("status");
+CREATE INDEX "idx_export_jobs_deleted_at" ON "export_jobs" ("deleted_at");
+
+CREATE TABLE "webhooks" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "url" text NOT NULL,
+    "events" text NOT NULL,
+    "secret" text NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "description" text,
+    "created_by" uuid NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhooks_created_by_user" FOREIGN KEY ("created_by") REFERENCES "users"("id")
+);
+CREATE INDEX "idx_webhooks_deleted_at" ON "webhooks" ("deleted_at");
+
+CREATE TABLE "webhook_deliveries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "webhook_id" uuid NOT NULL,
+    "event" text NOT NULL,
+    "payload" text NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "next_attempt_at" timestamptz NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_attempt_at" timestamptz,
+    "response_status" bigint,
+    "response_body" text,
+    "error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_webhook_deliveries_queue" ON "webhook_deliveries" ("status", "next_attempt_at");
+CREATE INDEX "idx_webhook_deliveries_event" ON "webhook_deliveries" ("event");
+CREATE INDEX "idx_webhook_deliveries_webhook_id" ON "webhook_deliveries" ("webhook_id");
+CREATE INDEX "idx_webhook_deliveries_deleted_at" ON "webhook_deliveries" ("deleted_at");
+
+CREATE TABLE "audit_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL,
+    "actor_id" uuid,
+    "actor_role" text,
+    "entity_type" text NOT NULL,
+    "entity_id" text NOT NULL,
+    "action" varchar(10) NOT NULL,
+    "changes" jsonb,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_audit_events_actor" FOREIGN KEY ("actor_id") REFERENCES "users"("id")
+);
+CREATE INDEX "idx_audit_events_entity" ON "audit_events" ("entity_type", "entity_id");
+CREATE INDEX "idx_audit_events_actor_id" ON "audit_events" ("actor_id");
+CREATE INDEX "idx_audit_events_created_at" ON "audit_events" ("created_at");
+
+-- Cursor pagination (utils/cursor.go) walks these tables by (created_at, id)
+CREATE INDEX "idx_leads_created_at_id" ON "leads" ("created_at", "id");
+CREATE INDEX "idx_activities_created_at_id" ON "activities" ("created_at", "id");
+CREATE INDEX "idx_tasks_created_at_id" ON "tasks" ("created_at", "id");
+CREATE INDEX "idx_vendors_created_at_id" ON "vendors" ("created_at", "id");
+CREATE INDEX "idx_resource_profiles_created_at_id" ON "resource_profiles" ("created_at", "id");
@@ -0,0 +1,18 @@
+-- AutoMigrate never created case_studies, although the case study resolvers use it.
+
+CREATE TABLE IF NOT EXISTS "case_studies" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "project_name" text,
+    "client_name" text,
+    "tech_stack" text,
+    "project_duration" text,
+    "key_outcomes" text,
+    "industry_target" text,
+    "tags" text,
+    "document" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_case_studies_deleted_at" ON "case_studies" ("deleted_at");
@@ -0,0 +1,14 @@
+-- Sessions cannot be turned back into plain tokens, so their users sign in again.
+DROP TABLE "refresh_tokens";
+
+CREATE TABLE "refresh_tokens" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "token" text NOT NULL,
+    "created_at" timestamptz,
+    "expires_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_refresh_tokens_token" UNIQUE ("token")
+);
+CREATE INDEX "idx_refresh_tokens_deleted_at" ON "refresh_tokens" ("deleted_at");
@@ -0,0 +1,24 @@
+-- Refresh tokens are stored as hashes, one family of rotating tokens per
+-- sign-in. The plain tokens stored so far cannot be carried over, so their
+-- users sign in again.
+DROP TABLE "refresh_tokens";
+
+CREATE TABLE "refresh_tokens" (
+    "id" uuid,
+    "user_id" uuid NOT NULL,
+    "family_id" uuid NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "device" text,
+    "auth_provider" text,
+    "created_at" timestamptz,
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_refresh_tokens_token_hash" UNIQUE ("token_hash")
+);
+CREATE INDEX "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+
+-- Copies of refresh tokens kept with Google sign-ins, now in refresh_tokens
+UPDATE "user_demos" SET "backend_refresh_token" = NULL;
@@ -0,0 +1,23 @@
+-- Lead permissions are scoped: managers act on the leads of their campaigns and
+-- sales executives on their own. Roles seeded before still hold the unscoped
+-- grants, which reach every lead, so they are narrowed here. An empty
+-- role_permissions table is seeded with the scoped defaults on first use.
+CREATE TEMPORARY TABLE "scoped_lead_permissions" ("role", "unscoped", "scoped") ON COMMIT DROP AS
+VALUES
+    ('MANAGER', 'lead:read', 'lead:read:campaign'),
+    ('MANAGER', 'lead:write', 'lead:write:campaign'),
+    ('MANAGER', 'lead:delete', 'lead:delete:campaign'),
+    ('SALES_EXECUTIVE', 'lead:read', 'lead:read:own');
+
+INSERT INTO "role_permissions" ("role", "permission", "created_at", "updated_at")
+SELECT "role_permissions"."role", "scoped_lead_permissions"."scoped", NOW(), NOW()
+FROM "role_permissions"
+JOIN "scoped_lead_permissions"
+    ON "scoped_lead_permissions"."role" = "role_permissions"."role"
+    AND "scoped_lead_permissions"."unscoped" = "role_permissions"."permission"
+ON CONFLICT ("role", "permission") DO NOTHING;
+
+DELETE FROM "role_permissions"
+USING "scoped_lead_permissions"
+WHERE "scoped_lead_permissions"."role" = "role_permissions"."role"
+    AND "scoped_lead_permissions"."unscoped" = "role_permissions"."permission";
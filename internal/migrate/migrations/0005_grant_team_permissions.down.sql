@@ -0,0 +1,19 @@
+DELETE FROM "role_permissions"
+USING (VALUES
+    ('ADMIN', 'lead:read:team'),
+    ('ADMIN', 'lead:write:team'),
+    ('ADMIN', 'lead:delete:team'),
+    ('ADMIN', 'lead:assign'),
+    ('ADMIN', 'lead:assign:team'),
+    ('ADMIN', 'lead:assign:own'),
+    ('ADMIN', 'team:read'),
+    ('ADMIN', 'team:write'),
+    ('ADMIN', 'team:delete'),
+    ('MANAGER', 'lead:assign:team'),
+    ('MANAGER', 'team:read'),
+    ('MANAGER', 'team:write'),
+    ('SALES_EXECUTIVE', 'lead:assign:own'),
+    ('SALES_EXECUTIVE', 'team:read')
+) AS "granted" ("role", "permission")
+WHERE "granted"."role" = "role_permissions"."role"
+    AND "granted"."permission" = "role_permissions"."permission";
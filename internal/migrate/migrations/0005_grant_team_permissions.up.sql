@@ -0,0 +1,23 @@
+-- Grants the team and lead assignment permissions to roles seeded before
+-- they existed. An empty role_permissions table is left to be seeded with the
+-- defaults on first use.
+INSERT INTO "role_permissions" ("role", "permission", "created_at", "updated_at")
+SELECT "granted"."role", "granted"."permission", NOW(), NOW()
+FROM (VALUES
+    ('ADMIN', 'lead:read:team'),
+    ('ADMIN', 'lead:write:team'),
+    ('ADMIN', 'lead:delete:team'),
+    ('ADMIN', 'lead:assign'),
+    ('ADMIN', 'lead:assign:team'),
+    ('ADMIN', 'lead:assign:own'),
+    ('ADMIN', 'team:read'),
+    ('ADMIN', 'team:write'),
+    ('ADMIN', 'team:delete'),
+    ('MANAGER', 'lead:assign:team'),
+    ('MANAGER', 'team:read'),
+    ('MANAGER', 'team:write'),
+    ('SALES_EXECUTIVE', 'lead:assign:own'),
+    ('SALES_EXECUTIVE', 'team:read')
+) AS "granted" ("role", "permission")
+WHERE EXISTS (SELECT 1 FROM "role_permissions")
+ON CONFLICT ("role", "permission") DO NOTHING;
@@ -0,0 +1,7 @@
+DELETE FROM "role_permissions"
+USING (VALUES
+    ('ADMIN', 'assignment:manage'),
+    ('MANAGER', 'assignment:manage')
+) AS "granted" ("role", "permission")
+WHERE "granted"."role" = "role_permissions"."role"
+    AND "granted"."permission" = "role_permissions"."permission";
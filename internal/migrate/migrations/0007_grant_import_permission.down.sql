@@ -0,0 +1,8 @@
+DELETE FROM "role_permissions"
+USING (VALUES
+    ('ADMIN', 'import:run'),
+    ('MANAGER', 'import:run'),
+    ('SALES_EXECUTIVE', 'import:run')
+) AS "granted" ("role", "permission")
+WHERE "granted"."role" = "role_permissions"."role"
+    AND "granted"."permission" = "role_permissions"."permission";
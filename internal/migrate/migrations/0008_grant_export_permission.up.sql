@@ -0,0 +1,11 @@
+-- Grants export:run to roles seeded before exports existed. An empty
+-- role_permissions table is left to be seeded with the defaults on first use.
+INSERT INTO "role_permissions" ("role", "permission", "created_at", "updated_at")
+SELECT "granted"."role", "granted"."permission", NOW(), NOW()
+FROM (VALUES
+    ('ADMIN', 'export:run'),
+    ('MANAGER', 'export:run'),
+    ('SALES_EXECUTIVE', 'export:run')
+) AS "granted" ("role", "permission")
+WHERE EXISTS (SELECT 1 FROM "role_permissions")
+ON CONFLICT ("role", "permission") DO NOTHING;
@@ -0,0 +1,10 @@
+DELETE FROM "role_permissions"
+USING (VALUES
+    ('ADMIN', 'trash:read'),
+    ('ADMIN', 'trash:restore'),
+    ('ADMIN', 'trash:purge'),
+    ('MANAGER', 'trash:read'),
+    ('MANAGER', 'trash:restore')
+) AS "granted" ("role", "permission")
+WHERE "granted"."role" = "role_permissions"."role"
+    AND "granted"."permission" = "role_permissions"."permission";
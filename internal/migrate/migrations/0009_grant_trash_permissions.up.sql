@@ -0,0 +1,14 @@
+-- Grants the trash permissions to roles seeded before the trash existed. An
+-- empty role_permissions table is left to be seeded with the defaults on
+-- first use.
+INSERT INTO "role_permissions" ("role", "permission", "created_at", "updated_at")
+SELECT "granted"."role", "granted"."permission", NOW(), NOW()
+FROM (VALUES
+    ('ADMIN', 'trash:read'),
+    ('ADMIN', 'trash:restore'),
+    ('ADMIN', 'trash:purge'),
+    ('MANAGER', 'trash:read'),
+    ('MANAGER', 'trash:restore')
+) AS "granted" ("role", "permission")
+WHERE EXISTS (SELECT 1 FROM "role_permissions")
+ON CONFLICT ("role", "permission") DO NOTHING;
@@ -0,0 +1,2 @@
+-- pg_trgm stays installed, other databases objects may have come to use it.
+DROP INDEX IF EXISTS "idx_organizations_name_trgm";
@@ -0,0 +1,4 @@
+-- Duplicate organizations are looked up by trigram similarity of their names.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX "idx_organizations_name_trgm" ON "organizations" USING gin (LOWER("organization_name") gin_trgm_ops);
@@ -0,0 +1,266 @@
+-- Brings a database of the release before versioned migrations, whose schema
+-- AutoMigrate created from the models of that release, to 0001_baseline. See
+-- prepare in migrate.go. Every statement can run again, so databases AutoMigrate
+-- created from later models, which have part of this already, upgrade as well.
+
+CREATE TABLE IF NOT EXISTS "teams" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "description" text,
+    "manager_id" uuid,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_teams_manager_id" ON "teams" ("manager_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_teams_name" ON "teams" ("name");
+CREATE INDEX IF NOT EXISTS "idx_teams_deleted_at" ON "teams" ("deleted_at");
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "team_id" uuid;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "manager_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_users_manager_id" ON "users" ("manager_id");
+CREATE INDEX IF NOT EXISTS "idx_users_team_id" ON "users" ("team_id");
+
+ALTER TABLE "leads" ADD COLUMN IF NOT EXISTS "score" bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS "idx_leads_score" ON "leads" ("score");
+
+ALTER TABLE "lead_stage_histories" ADD COLUMN IF NOT EXISTS "changed_by" uuid;
+CREATE INDEX IF NOT EXISTS "idx_lead_stage_histories_changed_by" ON "lead_stage_histories" ("changed_by");
+
+ALTER TABLE "tasks" ADD COLUMN IF NOT EXISTS "reminded_at" timestamptz;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teams_members') THEN
+        ALTER TABLE "users" ADD CONSTRAINT "fk_teams_members" FOREIGN KEY ("team_id") REFERENCES "teams"("id") ON DELETE SET NULL;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_lead_stage_histories_changed_by_user') THEN
+        ALTER TABLE "lead_stage_histories" ADD CONSTRAINT "fk_lead_stage_histories_changed_by_user" FOREIGN KEY ("changed_by") REFERENCES "users"("id") ON DELETE SET NULL;
+    END IF;
+END $$;
+
+-- Amounts were free text. The text columns are kept as <column>_legacy, and
+-- initializers.convertLegacyMoneyColumns fills the money columns from them.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'deals' AND column_name = 'deal_amount') THEN
+        ALTER TABLE "deals" RENAME COLUMN "deal_amount" TO "deal_amount_legacy";
+    END IF;
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'organizations' AND column_name = 'annual_revenue') THEN
+        ALTER TABLE "organizations" RENAME COLUMN "annual_revenue" TO "annual_revenue_legacy";
+    END IF;
+END $$;
+ALTER TABLE "deals" ADD COLUMN IF NOT EXISTS "deal_amount_amount" numeric(20,2) NOT NULL DEFAULT '0';
+ALTER TABLE "deals" ADD COLUMN IF NOT EXISTS "deal_amount_currency" varchar(3) NOT NULL DEFAULT '';
+ALTER TABLE "organizations" ADD COLUMN IF NOT EXISTS "annual_revenue_amount" numeric(20,2) NOT NULL DEFAULT '0';
+ALTER TABLE "organizations" ADD COLUMN IF NOT EXISTS "annual_revenue_currency" varchar(3) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "exchange_rates" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "currency" varchar(3) NOT NULL,
+    "rate_to_base" numeric(20,8) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_exchange_rates_currency" ON "exchange_rates" ("currency");
+CREATE INDEX IF NOT EXISTS "idx_exchange_rates_deleted_at" ON "exchange_rates" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "role_permissions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "role" varchar(50) NOT NULL,
+    "permission" varchar(100) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_role_permission" ON "role_permissions" ("role", "permission");
+CREATE INDEX IF NOT EXISTS "idx_role_permissions_deleted_at" ON "role_permissions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "assignment_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "priority" bigint NOT NULL DEFAULT 0,
+    "enabled" boolean NOT NULL,
+    "strategy" varchar(20) NOT NULL,
+    "countries" text,
+    "campaign_id" uuid,
+    "team_id" uuid,
+    "last_assigned_user_id" uuid,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_assignment_rules_team_id" ON "assignment_rules" ("team_id");
+CREATE INDEX IF NOT EXISTS "idx_assignment_rules_campaign_id" ON "assignment_rules" ("campaign_id");
+CREATE INDEX IF NOT EXISTS "idx_assignment_rules_priority" ON "assignment_rules" ("priority");
+CREATE INDEX IF NOT EXISTS "idx_assignment_rules_deleted_at" ON "assignment_rules" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "assignment_rule_users" (
+    "assignment_rule_id" uuid DEFAULT gen_random_uuid(),
+    "user_id" uuid,
+    PRIMARY KEY ("assignment_rule_id", "user_id"),
+    CONSTRAINT "fk_assignment_rule_users_assignment_rule" FOREIGN KEY ("assignment_rule_id") REFERENCES "assignment_rules"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_assignment_rule_users_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "lead_assignment_logs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "lead_id" uuid NOT NULL,
+    "assigned_to" uuid NOT NULL,
+    "assigned_by" uuid,
+    "rule_id" uuid,
+    "rule_name" text,
+    "strategy" varchar(20) NOT NULL,
+    "reason" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_assignment_logs_assigned_to_user" FOREIGN KEY ("assigned_to") REFERENCES "users"("id"),
+    CONSTRAINT "fk_lead_assignment_logs_assigned_by_user" FOREIGN KEY ("assigned_by") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_lead_assignment_logs_rule_id" ON "lead_assignment_logs" ("rule_id");
+CREATE INDEX IF NOT EXISTS "idx_lead_assignment_logs_lead_id" ON "lead_assignment_logs" ("lead_id");
+
+CREATE TABLE IF NOT EXISTS "scoring_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "attribute" varchar(30) NOT NULL,
+    "operator" varchar(10) NOT NULL,
+    "value" text,
+    "weight" bigint NOT NULL,
+    "description" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_scoring_rules_deleted_at" ON "scoring_rules" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "import_jobs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "entity" varchar(20) NOT NULL,
+    "file_name" text,
+    "file_path" text,
+    "mapping" text,
+    "dry_run" boolean NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "error" text,
+    "total_rows" bigint NOT NULL DEFAULT 0,
+    "valid_rows" bigint NOT NULL DEFAULT 0,
+    "created_rows" bigint NOT NULL DEFAULT 0,
+    "failed_rows" bigint NOT NULL DEFAULT 0,
+    "created_by" uuid NOT NULL,
+    "started_at" timestamptz,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_import_jobs_created_by_user" FOREIGN KEY ("created_by") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_import_jobs_deleted_at" ON "import_jobs" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_import_jobs_created_by" ON "import_jobs" ("created_by");
+CREATE INDEX IF NOT EXISTS "idx_import_jobs_status" ON "import_jobs" ("status");
+
+CREATE TABLE IF NOT EXISTS "import_job_rows" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "job_id" uuid NOT NULL,
+    "row" bigint NOT NULL,
+    "status" varchar(10) NOT NULL,
+    "record_id" uuid,
+    "values" text,
+    "errors" text,
+    "warnings" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_import_jobs_rows" FOREIGN KEY ("job_id") REFERENCES "import_jobs"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_import_job_rows_job_id" ON "import_job_rows" ("job_id");
+
+CREATE TABLE IF NOT EXISTS "export_jobs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "query" text NOT NULL,
+    "filter" text,
+    "sort" text,
+    "columns" text,
+    "format" varchar(10) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "error" text,
+    "row_count" bigint NOT NULL DEFAULT 0,
+    "document_id" uuid,
+    "created_by" uuid NOT NULL,
+    "started_at" timestamptz,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_export_jobs_created_by_user" FOREIGN KEY ("created_by") REFERENCES "users"("id"),
+    CONSTRAINT "fk_export_jobs_document" FOREIGN KEY ("document_id") REFERENCES "documents"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_export_jobs_created_by" ON "export_jobs" ("created_by");
+CREATE INDEX IF NOT EXISTS "idx_export_jobs_status" ON "export_jobs" ("status");
+CREATE INDEX IF NOT EXISTS "idx_export_jobs_deleted_at" ON "export_jobs" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "url" text NOT NULL,
+    "events" text NOT NULL,
+    "secret" text NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "description" text,
+    "created_by" uuid NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhooks_created_by_user" FOREIGN KEY ("created_by") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhooks_deleted_at" ON "webhooks" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "webhook_id" uuid NOT NULL,
+    "event" text NOT NULL,
+    "payload" text NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "next_attempt_at" timestamptz NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_attempt_at" timestamptz,
+    "response_status" bigint,
+    "response_body" text,
+    "error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_queue" ON "webhook_deliveries" ("status", "next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_event" ON "webhook_deliveries" ("event");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_webhook_id" ON "webhook_deliveries" ("webhook_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_deleted_at" ON "webhook_deliveries" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "audit_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL,
+    "actor_id" uuid,
+    "actor_role" text,
+    "entity_type" text NOT NULL,
+    "entity_id" text NOT NULL,
+    "action" varchar(10) NOT NULL,
+    "changes" jsonb,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_audit_events_actor" FOREIGN KEY ("actor_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_events_entity" ON "audit_events" ("entity_type", "entity_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_actor_id" ON "audit_events" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_created_at" ON "audit_events" ("created_at");
+
+-- Cursor pagination (utils/cursor.go) walks these tables by (created_at, id)
+CREATE INDEX IF NOT EXISTS "idx_leads_created_at_id" ON "leads" ("created_at", "id");
+CREATE INDEX IF NOT EXISTS "idx_activities_created_at_id" ON "activities" ("created_at", "id");
+CREATE INDEX IF NOT EXISTS "idx_tasks_created_at_id" ON "tasks" ("created_at", "id");
+CREATE INDEX IF NOT EXISTS "idx_vendors_created_at_id" ON "vendors" ("created_at", "id");
+CREATE INDEX IF NOT EXISTS "idx_resource_profiles_created_at_id" ON "resource_profiles" ("created_at", "id");
@@ -0,0 +1,85 @@
+package querylimit
+
+import (
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+const maxInt = int(^uint(0) >> 1)
+
+// costSchema prices fields by their @cost directive in the schema. A field with
+// one costs weight plus the cost of its selections once per list item; others
+// keep gqlgen's default of 1 plus their selections.
+type costSchema struct {
+	graphql.ExecutableSchema
+}
+
+func (s costSchema) Complexity(typeName, fieldName string, childComplexity int, args map[string]any) (int, bool) {
+	def := s.Schema().Types[typeName]
+	if def == nil {
+		return 0, false
+	}
+	field := def.Fields.ForName(fieldName)
+	if field == nil {
+		return 0, false
+	}
+	directive := field.Directives.ForName("cost")
+	if directive == nil {
+		return s.ExecutableSchema.Complexity(typeName, fieldName, childComplexity, args)
+	}
+
+	costArgs := directive.ArgumentMap(nil)
+	weight := toInt(costArgs["weight"], 1)
+	size := toInt(costArgs["listSize"], 1)
+	if sizeArg, ok := costArgs["sizeArg"].(string); ok {
+		if n := toInt(lookup(args, sizeArg), 0); n > 0 {
+			size = n
+		}
+	}
+	return add(weight, multiply(size, childComplexity)), true
+}
+
+// lookup reads a dotted path such as "pagination.pageSize" from field arguments.
+func lookup(args map[string]any, path string) any {
+	var value any = args
+	for _, name := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[name]
+	}
+	return value
+}
+
+func toInt(value any, fallback int) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return fallback
+}
+
+// add and multiply saturate instead of overflowing, so a huge list size cannot
+// wrap a cost around to a small one.
+func add(a, b int) int {
+	if a > maxInt-b {
+		return maxInt
+	}
+	return a + b
+}
+
+func multiply(a, b int) int {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	if a > maxInt/b {
+		return maxInt
+	}
+	return a * b
+}
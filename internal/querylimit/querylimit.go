@@ -0,0 +1,141 @@
+// Package querylimit rejects GraphQL operations that are nested too deeply or
+// would cost too much to resolve, with limits depending on the caller's role.
+//
+// Depth counts nested fields, skipping introspection. Complexity is gqlgen's
+// calculation with the weights of the @cost schema directive (see cost.go).
+package querylimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Error codes of rejected operations, in the "code" extension of the error.
+const (
+	ErrDepthLimit      = "DEPTH_LIMIT_EXCEEDED"
+	ErrComplexityLimit = "COMPLEXITY_LIMIT_EXCEEDED"
+)
+
+// Anonymous is the role used for limits of callers that are not logged in.
+const Anonymous = "ANONYMOUS"
+
+func init() {
+	// Rejected before execution, like validation errors, so answered with a 422
+	errcode.RegisterErrorType(ErrDepthLimit, errcode.KindProtocol)
+	errcode.RegisterErrorType(ErrComplexityLimit, errcode.KindProtocol)
+}
+
+// Limits bound a single operation.
+type Limits struct {
+	Depth      int
+	Complexity int
+}
+
+// DefaultLimits by role. config.Config.QueryLimits override them; unknown roles
+// get the SALES_EXECUTIVE limits.
+var DefaultLimits = map[string]Limits{
+	Anonymous:         {Depth: 5, Complexity: 100},
+	"SALES_EXECUTIVE": {Depth: 8, Complexity: 5000},
+	"MANAGER":         {Depth: 10, Complexity: 10000},
+	"ADMIN":           {Depth: 12, Complexity: 20000},
+}
+
+// For returns the limits of role, or of anonymous callers if it is empty, with
+// the nonzero limits of overrides[role] in place of the defaults.
+func For(role string, overrides map[string]config.QueryLimit) Limits {
+	if role == "" {
+		role = Anonymous
+	}
+	limits, ok := DefaultLimits[role]
+	if !ok {
+		limits = DefaultLimits["SALES_EXECUTIVE"]
+	}
+	override := overrides[role]
+	if override.Depth > 0 {
+		limits.Depth = override.Depth
+	}
+	if override.Complexity > 0 {
+		limits.Complexity = override.Complexity
+	}
+	return limits
+}
+
+// Extension enforces the limits of the caller's role on every operation.
+type Extension struct {
+	// Overrides by role, from config.Config.QueryLimits
+	Overrides map[string]config.QueryLimit
+
+	es graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = &Extension{}
+
+func (e *Extension) ExtensionName() string {
+	return "QueryLimit"
+}
+
+func (e *Extension) Validate(schema graphql.ExecutableSchema) error {
+	e.es = costSchema{schema}
+	return nil
+}
+
+func (e *Extension) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if op == nil {
+		return nil
+	}
+	role, _ := auth.GetUserRoleFromJWT(ctx)
+	limits := For(role, e.Overrides)
+
+	if d := depth(op.SelectionSet); d > limits.Depth {
+		return limitError(ErrDepthLimit, fmt.Sprintf("operation has depth %d, which exceeds the limit of %d", d, limits.Depth), "depth", d, limits.Depth)
+	}
+	if c := complexity.Calculate(e.es, op, opCtx.Variables); c > limits.Complexity {
+		return limitError(ErrComplexityLimit, fmt.Sprintf("operation has complexity %d, which exceeds the limit of %d", c, limits.Complexity), "complexity", c, limits.Complexity)
+	}
+	return nil
+}
+
+func limitError(code, message, measure string, value, limit int) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code":  code,
+			measure: value,
+			"limit": limit,
+		},
+	}
+}
+
+// depth returns how deeply fields nest in selections.
+func depth(selections ast.SelectionSet) int {
+	deepest := 0
+	for _, selection := range selections {
+		var d int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = 1 + depth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			d = depth(s.Definition.SelectionSet)
+		case *ast.InlineFragment:
+			d = depth(s.SelectionSet)
+		}
+		deepest = max(deepest, d)
+	}
+	return deepest
+}
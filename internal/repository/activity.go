@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ActivityRepository stores the activities of leads. Writes rescore the lead of
+// the activity.
+type ActivityRepository interface {
+	// Page returns the activities of the leads the caller may read, or of leadID
+	// if not empty, on the connection page cursor selects.
+	Page(ctx context.Context, leadID string, cursor Cursor) ([]models.Activity, error)
+	// Get returns the activity with id if the caller may perform action on its
+	// lead (see auth.LeadScope).
+	Get(ctx context.Context, action string, id string) (models.Activity, error)
+	Create(ctx context.Context, activity *models.Activity) error
+	Update(ctx context.Context, activity *models.Activity) error
+	Delete(ctx context.Context, activity *models.Activity) error
+}
+
+type activityRepository struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository returns an ActivityRepository on db.
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+func (r *activityRepository) Page(ctx context.Context, leadID string, cursor Cursor) ([]models.Activity, error) {
+	db := r.db.WithContext(ctx)
+	visibleLeads := db.Model(&models.Lead{}).Select("leads.id").Scopes(auth.LeadScope(ctx, auth.LeadActionRead))
+	query := db.Model(&models.Activity{}).Where("activities.lead_id IN (?)", visibleLeads)
+	if leadID != "" {
+		query = query.Where("activities.lead_id = ?", leadID)
+	}
+	var activities []models.Activity
+	err := query.Scopes(keyset("activities", cursor)).Find(&activities).Error
+	return activities, err
+}
+
+func (r *activityRepository) Get(ctx context.Context, action string, id string) (models.Activity, error) {
+	var activity models.Activity
+	err := r.db.WithContext(ctx).
+		Joins("JOIN leads ON leads.id = activities.lead_id AND leads.deleted_at IS NULL").
+		Scopes(auth.LeadScope(ctx, action)).
+		First(&activity, "activities.id = ?", id).Error
+	return activity, err
+}
+
+func (r *activityRepository) Create(ctx context.Context, activity *models.Activity) error {
+	if err := r.db.WithContext(ctx).Create(activity).Error; err != nil {
+		return err
+	}
+	r.rescore(activity.LeadID)
+	return nil
+}
+
+func (r *activityRepository) Update(ctx context.Context, activity *models.Activity) error {
+	if err := r.db.WithContext(ctx).Save(activity).Error; err != nil {
+		return err
+	}
+	r.rescore(activity.LeadID)
+	return nil
+}
+
+func (r *activityRepository) Delete(ctx context.Context, activity *models.Activity) error {
+	if err := r.db.WithContext(ctx).Delete(activity).Error; err != nil {
+		return err
+	}
+	r.rescore(activity.LeadID)
+	return nil
+}
+
+// rescore recalculates the score of a lead. Failures are only logged: the score
+// catches up on the next change or rescore.
+func (r *activityRepository) rescore(leadID uuid.UUID) {
+	if err := scoring.Recalculate(r.db, leadID); err != nil {
+		log.Printf("Error scoring lead %s: %v", leadID, err)
+	}
+}
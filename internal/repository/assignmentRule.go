@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssignmentRuleRepository stores the rules that assign new leads, see
+// internal/assignment, and reads the log of their decisions.
+type AssignmentRuleRepository interface {
+	// List returns every rule with its users, in the order they are tried.
+	List(ctx context.Context) ([]models.AssignmentRule, error)
+	// Get returns the rule with id and its users.
+	Get(ctx context.Context, id string) (models.AssignmentRule, error)
+	// Create inserts rule and links its users, which must exist.
+	Create(ctx context.Context, rule *models.AssignmentRule) error
+	// Update saves rule and replaces its users with rule.Users.
+	Update(ctx context.Context, rule *models.AssignmentRule) error
+	// Delete deletes rule. The log keeps the decisions it made.
+	Delete(ctx context.Context, rule *models.AssignmentRule) error
+	// Log returns the assignments of a lead, oldest first, with their users.
+	Log(ctx context.Context, leadID uuid.UUID) ([]models.LeadAssignmentLog, error)
+}
+
+type assignmentRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewAssignmentRuleRepository returns an AssignmentRuleRepository on db.
+func NewAssignmentRuleRepository(db *gorm.DB) AssignmentRuleRepository {
+	return &assignmentRuleRepository{db: db}
+}
+
+func (r *assignmentRuleRepository) List(ctx context.Context) ([]models.AssignmentRule, error) {
+	var rules []models.AssignmentRule
+	err := r.db.WithContext(ctx).Preload("Users").Order("priority ASC, created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *assignmentRuleRepository) Get(ctx context.Context, id string) (models.AssignmentRule, error) {
+	var rule models.AssignmentRule
+	err := r.db.WithContext(ctx).Preload("Users").First(&rule, "id = ?", id).Error
+	return rule, err
+}
+
+func (r *assignmentRuleRepository) Create(ctx context.Context, rule *models.AssignmentRule) error {
+	// Only link the existing users, never write them
+	return r.db.WithContext(ctx).Omit("Users.*").Create(rule).Error
+}
+
+func (r *assignmentRuleRepository) Update(ctx context.Context, rule *models.AssignmentRule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Users").Save(rule).Error; err != nil {
+			return err
+		}
+		return tx.Model(rule).Omit("Users.*").Association("Users").Replace(rule.Users)
+	})
+}
+
+func (r *assignmentRuleRepository) Delete(ctx context.Context, rule *models.AssignmentRule) error {
+	// Logs keep RuleID and RuleName, so past decisions stay explained
+	return r.db.WithContext(ctx).Delete(rule).Error
+}
+
+func (r *assignmentRuleRepository) Log(ctx context.Context, leadID uuid.UUID) ([]models.LeadAssignmentLog, error) {
+	var entries []models.LeadAssignmentLog
+	err := r.db.WithContext(ctx).
+		Preload("AssignedToUser").
+		Preload("AssignedByUser").
+		Where("lead_id = ?", leadID).
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
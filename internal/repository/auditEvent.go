@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// AuditEventFilter selects audit events. Empty fields match every event.
+type AuditEventFilter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	Action     string
+	From       *time.Time // created at or after
+	To         *time.Time // created at or before
+}
+
+// AuditEventRepository reads the audit log, which internal/audit writes.
+type AuditEventRepository interface {
+	// List returns a page of the events matching filter, newest first, with
+	// their actors, and how many match in all.
+	List(ctx context.Context, filter AuditEventFilter, page Page) ([]models.AuditEvent, int64, error)
+}
+
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository returns an AuditEventRepository on db.
+func NewAuditEventRepository(db *gorm.DB) AuditEventRepository {
+	return &auditEventRepository{db: db}
+}
+
+func (r *auditEventRepository) List(ctx context.Context, filter AuditEventFilter, page Page) ([]models.AuditEvent, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.AuditEvent{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var auditEvents []models.AuditEvent
+	if err := query.Scopes(paginate(page)).Preload("Actor").Order("created_at DESC").Find(&auditEvents).Error; err != nil {
+		return nil, 0, err
+	}
+	return auditEvents, totalCount, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CampaignFilter selects campaigns. Empty fields match every campaign.
+type CampaignFilter struct {
+	Name    string // part of the campaign name
+	Country string // exact country
+}
+
+// CampaignRepository stores campaigns and their members.
+type CampaignRepository interface {
+	// List returns a page of the campaigns matching filter and how many match in
+	// all. It sorts by CAMPAIGN_NAME or CREATED_AT.
+	List(ctx context.Context, filter CampaignFilter, sort Sort, page Page) ([]models.Campaign, int64, error)
+	Get(ctx context.Context, id string) (models.Campaign, error)
+	Create(ctx context.Context, campaign *models.Campaign) error
+	Update(ctx context.Context, campaign *models.Campaign) error
+	Delete(ctx context.Context, campaign *models.Campaign) error
+	// IsMember reports whether userID is a member of campaignID.
+	IsMember(ctx context.Context, campaignID, userID uuid.UUID) (bool, error)
+	AddMember(ctx context.Context, campaign *models.Campaign, user *models.User) error
+	RemoveMember(ctx context.Context, campaign *models.Campaign, user *models.User) error
+	// Members returns the members of each of campaignIDs by name.
+	Members(ctx context.Context, campaignIDs []uuid.UUID) (map[uuid.UUID][]models.User, error)
+}
+
+// campaignSortColumns are the columns List sorts by.
+var campaignSortColumns = map[string]string{
+	"CAMPAIGN_NAME": "campaigns.campaign_name",
+	"CREATED_AT":    "campaigns.created_at",
+}
+
+type campaignRepository struct {
+	db *gorm.DB
+}
+
+// NewCampaignRepository returns a CampaignRepository on db.
+func NewCampaignRepository(db *gorm.DB) CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+func (r *campaignRepository) List(ctx context.Context, filter CampaignFilter, sort Sort, page Page) ([]models.Campaign, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Campaign{})
+	if filter.Name != "" {
+		query = query.Where("campaigns.campaign_name ILIKE ?", contains(filter.Name))
+	}
+	if filter.Country != "" {
+		query = query.Where("campaigns.campaign_country = ?", filter.Country)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var campaigns []models.Campaign
+	if err := query.Scopes(order(sort, campaignSortColumns, ""), paginate(page)).Find(&campaigns).Error; err != nil {
+		return nil, 0, err
+	}
+	return campaigns, totalCount, nil
+}
+
+func (r *campaignRepository) Get(ctx context.Context, id string) (models.Campaign, error) {
+	var campaign models.Campaign
+	err := r.db.WithContext(ctx).First(&campaign, "id = ?", id).Error
+	return campaign, err
+}
+
+func (r *campaignRepository) Create(ctx context.Context, campaign *models.Campaign) error {
+	return r.db.WithContext(ctx).Create(campaign).Error
+}
+
+func (r *campaignRepository) Update(ctx context.Context, campaign *models.Campaign) error {
+	return r.db.WithContext(ctx).Omit("Leads", "Users").Save(campaign).Error
+}
+
+func (r *campaignRepository) Delete(ctx context.Context, campaign *models.Campaign) error {
+	return r.db.WithContext(ctx).Delete(campaign).Error
+}
+
+func (r *campaignRepository) IsMember(ctx context.Context, campaignID, userID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT EXISTS(SELECT 1 FROM campaign_users WHERE user_id = ? AND campaign_id = ?)", userID, campaignID,
+	).Scan(&exists).Error
+	return exists, err
+}
+
+func (r *campaignRepository) AddMember(ctx context.Context, campaign *models.Campaign, user *models.User) error {
+	return r.db.WithContext(ctx).Model(campaign).Association("Users").Append(user)
+}
+
+func (r *campaignRepository) RemoveMember(ctx context.Context, campaign *models.Campaign, user *models.User) error {
+	return r.db.WithContext(ctx).Model(campaign).Association("Users").Delete(user)
+}
+
+func (r *campaignRepository) Members(ctx context.Context, campaignIDs []uuid.UUID) (map[uuid.UUID][]models.User, error) {
+	var rows []struct {
+		CampaignID  uuid.UUID
+		models.User `gorm:"embedded"`
+	}
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Select("campaign_users.campaign_id, users.*").
+		Joins("JOIN campaign_users ON campaign_users.user_id = users.id").
+		Where("campaign_users.campaign_id IN ?", campaignIDs).
+		Order("users.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[uuid.UUID][]models.User, len(campaignIDs))
+	for _, row := range rows {
+		members[row.CampaignID] = append(members[row.CampaignID], row.User)
+	}
+	return members, nil
+}
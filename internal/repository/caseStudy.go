@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// CaseStudyFilter selects case studies. Empty fields match every case study.
+type CaseStudyFilter struct {
+	ProjectName    string // part of the project name
+	ClientName     string // part of the client name
+	TechStack      string // part of the tech stack
+	IndustryTarget string // part of the industry targeted
+	Tags           string // part of the tags
+	Search         string // part of any of the above
+}
+
+// CaseStudyRepository stores case studies.
+type CaseStudyRepository interface {
+	// List returns a page of the case studies matching filter and how many match
+	// in all. It sorts by createdAt, updatedAt, techStack or industryTarget.
+	List(ctx context.Context, filter CaseStudyFilter, sort Sort, page Page) ([]models.CaseStudy, int64, error)
+	Get(ctx context.Context, id string) (models.CaseStudy, error)
+	Create(ctx context.Context, caseStudy *models.CaseStudy) error
+	Update(ctx context.Context, caseStudy *models.CaseStudy) error
+	Delete(ctx context.Context, caseStudy *models.CaseStudy) error
+}
+
+// caseStudySortColumns are the columns List sorts by.
+var caseStudySortColumns = map[string]string{
+	"createdAt":      "case_studies.created_at",
+	"updatedAt":      "case_studies.updated_at",
+	"techStack":      "case_studies.tech_stack",
+	"industryTarget": "case_studies.industry_target",
+}
+
+type caseStudyRepository struct {
+	db *gorm.DB
+}
+
+// NewCaseStudyRepository returns a CaseStudyRepository on db.
+func NewCaseStudyRepository(db *gorm.DB) CaseStudyRepository {
+	return &caseStudyRepository{db: db}
+}
+
+// filterCaseStudies applies filter to a query on case_studies.
+func filterCaseStudies(filter CaseStudyFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if filter.ProjectName != "" {
+			query = query.Where("case_studies.project_name ILIKE ?", contains(filter.ProjectName))
+		}
+		if filter.ClientName != "" {
+			query = query.Where("case_studies.client_name ILIKE ?", contains(filter.ClientName))
+		}
+		if filter.TechStack != "" {
+			query = query.Where("case_studies.tech_stack ILIKE ?", contains(filter.TechStack))
+		}
+		if filter.IndustryTarget != "" {
+			query = query.Where("case_studies.industry_target ILIKE ?", contains(filter.IndustryTarget))
+		}
+		if filter.Tags != "" {
+			query = query.Where("case_studies.tags ILIKE ?", contains(filter.Tags))
+		}
+		if filter.Search != "" {
+			search := contains(filter.Search)
+			query = query.Where(`(case_studies.project_name ILIKE ? OR case_studies.client_name ILIKE ? OR
+				case_studies.tech_stack ILIKE ? OR case_studies.industry_target ILIKE ? OR case_studies.tags ILIKE ?)`,
+				search, search, search, search, search)
+		}
+		return query
+	}
+}
+
+func (r *caseStudyRepository) List(ctx context.Context, filter CaseStudyFilter, sort Sort, page Page) ([]models.CaseStudy, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.CaseStudy{}).Scopes(filterCaseStudies(filter))
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var caseStudies []models.CaseStudy
+	err := query.Scopes(order(sort, caseStudySortColumns, ""), paginate(page)).Find(&caseStudies).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return caseStudies, totalCount, nil
+}
+
+func (r *caseStudyRepository) Get(ctx context.Context, id string) (models.CaseStudy, error) {
+	var caseStudy models.CaseStudy
+	err := r.db.WithContext(ctx).First(&caseStudy, "id = ?", id).Error
+	return caseStudy, err
+}
+
+func (r *caseStudyRepository) Create(ctx context.Context, caseStudy *models.CaseStudy) error {
+	return r.db.WithContext(ctx).Create(caseStudy).Error
+}
+
+func (r *caseStudyRepository) Update(ctx context.Context, caseStudy *models.CaseStudy) error {
+	return r.db.WithContext(ctx).Save(caseStudy).Error
+}
+
+func (r *caseStudyRepository) Delete(ctx context.Context, caseStudy *models.CaseStudy) error {
+	return r.db.WithContext(ctx).Delete(caseStudy).Error
+}
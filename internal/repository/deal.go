@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DealFilter selects deals. Empty fields match every deal.
+type DealFilter struct {
+	Name      string        // part of the deal name
+	LeadID    string        // deal of the lead
+	Status    string        // exact status
+	Amount    *models.Money // exact amount
+	MinAmount *models.Money // at least this amount, in its currency
+	MaxAmount *models.Money // at most this amount, in its currency
+	Search    string        // part of the name or project requirements
+}
+
+// DealRepository stores deals.
+type DealRepository interface {
+	// List returns a page of the deals matching filter. It sorts by createdAt,
+	// updatedAt, dealStartDate, dealEndDate or dealAmount, the latter compared in
+	// the base currency.
+	List(ctx context.Context, filter DealFilter, sort Sort, page Page) ([]models.Deal, error)
+	// Get returns the deal with id if the caller may perform action on its lead
+	// (see auth.LeadScope).
+	Get(ctx context.Context, action string, id string) (models.Deal, error)
+	Create(ctx context.Context, deal *models.Deal) error
+	Update(ctx context.Context, deal *models.Deal) error
+	Delete(ctx context.Context, deal *models.Deal) error
+}
+
+// dealSortColumns are the columns List sorts by, but for dealAmount.
+var dealSortColumns = map[string]string{
+	"createdAt":     "deals.created_at",
+	"updatedAt":     "deals.updated_at",
+	"dealStartDate": "deals.deal_start_date",
+	"dealEndDate":   "deals.deal_end_date",
+}
+
+type dealRepository struct {
+	db *gorm.DB
+}
+
+// NewDealRepository returns a DealRepository on db.
+func NewDealRepository(db *gorm.DB) DealRepository {
+	return &dealRepository{db: db}
+}
+
+func (r *dealRepository) List(ctx context.Context, filter DealFilter, sort Sort, page Page) ([]models.Deal, error) {
+	query := r.db.WithContext(ctx).Model(&models.Deal{})
+	if filter.Name != "" {
+		query = query.Where("deals.deal_name ILIKE ?", contains(filter.Name))
+	}
+	if filter.LeadID != "" {
+		query = query.Where("deals.lead_id = ?", filter.LeadID)
+	}
+	if filter.Status != "" {
+		query = query.Where("deals.deal_status = ?", filter.Status)
+	}
+	if filter.Amount != nil {
+		query = query.Where("deals.deal_amount_amount = ? AND deals.deal_amount_currency = ?", filter.Amount.Amount, filter.Amount.Currency)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("deals.deal_amount_currency = ? AND deals.deal_amount_amount >= ?", filter.MinAmount.Currency, filter.MinAmount.Amount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("deals.deal_amount_currency = ? AND deals.deal_amount_amount <= ?", filter.MaxAmount.Currency, filter.MaxAmount.Amount)
+	}
+	if filter.Search != "" {
+		query = query.Where("(deals.deal_name ILIKE ? OR deals.project_requirements ILIKE ?)", contains(filter.Search), contains(filter.Search))
+	}
+
+	if sort.Field == "dealAmount" {
+		// Compare amounts in the base currency; currencies without a rate sort as-is
+		direction := " ASC"
+		if sort.Desc {
+			direction = " DESC"
+		}
+		query = query.
+			Joins("LEFT JOIN exchange_rates ON exchange_rates.currency = deals.deal_amount_currency AND exchange_rates.deleted_at IS NULL").
+			Order("deals.deal_amount_amount * COALESCE(exchange_rates.rate_to_base, 1)" + direction)
+	} else {
+		query = query.Scopes(order(sort, dealSortColumns, ""))
+	}
+
+	var deals []models.Deal
+	err := query.Scopes(paginate(page)).Find(&deals).Error
+	return deals, err
+}
+
+func (r *dealRepository) Get(ctx context.Context, action string, id string) (models.Deal, error) {
+	var deal models.Deal
+	err := r.db.WithContext(ctx).
+		// deals.lead_id is text in the baseline schema
+		Joins("JOIN leads ON leads.id::text = deals.lead_id::text AND leads.deleted_at IS NULL").
+		Scopes(auth.LeadScope(ctx, action)).
+		First(&deal, "deals.id = ?", id).Error
+	return deal, err
+}
+
+func (r *dealRepository) Create(ctx context.Context, deal *models.Deal) error {
+	return r.db.WithContext(ctx).Create(deal).Error
+}
+
+func (r *dealRepository) Update(ctx context.Context, deal *models.Deal) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(deal).Error
+}
+
+func (r *dealRepository) Delete(ctx context.Context, deal *models.Deal) error {
+	return r.db.WithContext(ctx).Delete(deal).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// ExchangeRateRepository stores the rates of the currencies other than the base
+// currency. Writes rescore every lead, since revenue rules compare in the base
+// currency.
+type ExchangeRateRepository interface {
+	// List returns every rate by currency.
+	List(ctx context.Context) ([]models.ExchangeRate, error)
+	// Get returns the rate of currency.
+	Get(ctx context.Context, currency string) (models.ExchangeRate, error)
+	// Save inserts rate, or updates it if it has an id.
+	Save(ctx context.Context, rate *models.ExchangeRate) error
+	// Delete removes rate for good, so its currency can be added again.
+	Delete(ctx context.Context, rate *models.ExchangeRate) error
+}
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository returns an ExchangeRateRepository on db.
+func NewExchangeRateRepository(db *gorm.DB) ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+func (r *exchangeRateRepository) List(ctx context.Context) ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	err := r.db.WithContext(ctx).Order("currency ASC").Find(&rates).Error
+	return rates, err
+}
+
+func (r *exchangeRateRepository) Get(ctx context.Context, currency string) (models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := r.db.WithContext(ctx).First(&rate, "currency = ?", currency).Error
+	return rate, err
+}
+
+func (r *exchangeRateRepository) Save(ctx context.Context, rate *models.ExchangeRate) error {
+	if err := r.db.WithContext(ctx).Save(rate).Error; err != nil {
+		return err
+	}
+	r.rescore()
+	return nil
+}
+
+func (r *exchangeRateRepository) Delete(ctx context.Context, rate *models.ExchangeRate) error {
+	// Hard delete so the currency can be added again under the unique index
+	if err := r.db.WithContext(ctx).Unscoped().Delete(rate).Error; err != nil {
+		return err
+	}
+	r.rescore()
+	return nil
+}
+
+// rescore recalculates the score of every lead. Failures are only logged: the
+// scores catch up on the next change or rescore.
+func (r *exchangeRateRepository) rescore() {
+	if err := scoring.RecalculateAll(r.db); err != nil {
+		log.Printf("Error rescoring leads: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// ExportJobRepository reads the export jobs of internal/exporter. Jobs are only
+// visible to the user who started them.
+type ExportJobRepository interface {
+	// List returns the 50 newest jobs of userID with their creator.
+	List(ctx context.Context, userID string) ([]models.ExportJob, error)
+	// Get returns the job with id of userID with its creator.
+	Get(ctx context.Context, id, userID string) (models.ExportJob, error)
+}
+
+type exportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewExportJobRepository returns an ExportJobRepository on db.
+func NewExportJobRepository(db *gorm.DB) ExportJobRepository {
+	return &exportJobRepository{db: db}
+}
+
+func (r *exportJobRepository) List(ctx context.Context, userID string) ([]models.ExportJob, error) {
+	var jobs []models.ExportJob
+	err := r.db.WithContext(ctx).Preload("CreatedByUser").
+		Where("created_by = ?", userID).
+		Order("created_at DESC").
+		Limit(50).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *exportJobRepository) Get(ctx context.Context, id, userID string) (models.ExportJob, error) {
+	var job models.ExportJob
+	err := r.db.WithContext(ctx).Preload("CreatedByUser").First(&job, "id = ? AND created_by = ?", id, userID).Error
+	return job, err
+}
@@ -0,0 +1,64 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func activityKey(activity models.Activity) (time.Time, uuid.UUID) {
+	return activity.CreatedAt, activity.ID
+}
+
+type activityRepository struct {
+	*store
+}
+
+func (r *activityRepository) Page(ctx context.Context, leadID string, cursor repository.Cursor) ([]models.Activity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scope := r.leadScope(ctx, auth.LeadActionRead)
+	activities := r.activities.filter(func(activity models.Activity) bool {
+		lead, ok := r.leads.get(activity.LeadID)
+		return ok && scope(lead) && (leadID == "" || activity.LeadID.String() == leadID)
+	})
+	return keyset(activities, cursor, activityKey), nil
+}
+
+func (r *activityRepository) Get(ctx context.Context, action string, id string) (models.Activity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	activity, ok := r.activities.get(parseID(id))
+	if ok {
+		lead, found := r.leads.get(activity.LeadID)
+		ok = found && r.leadScope(ctx, action)(lead)
+	}
+	if !ok {
+		return models.Activity{}, gorm.ErrRecordNotFound
+	}
+	return activity, nil
+}
+
+func (r *activityRepository) Create(ctx context.Context, activity *models.Activity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&activity.ID, &activity.Model)
+	r.activities.put(activity.ID, *activity)
+	return nil
+}
+
+func (r *activityRepository) Update(ctx context.Context, activity *models.Activity) error {
+	return r.Create(ctx, activity)
+}
+
+func (r *activityRepository) Delete(ctx context.Context, activity *models.Activity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activities.delete(activity.ID)
+	return nil
+}
@@ -0,0 +1,78 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type assignmentRuleRepository struct {
+	*store
+}
+
+func (r *assignmentRuleRepository) List(ctx context.Context) ([]models.AssignmentRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rules := r.rules.all()
+	slices.SortStableFunc(rules, func(a, b models.AssignmentRule) int {
+		if result := cmp.Compare(a.Priority, b.Priority); result != 0 {
+			return result
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return rules, nil
+}
+
+func (r *assignmentRuleRepository) Get(ctx context.Context, id string) (models.AssignmentRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, ok := r.rules.get(parseID(id))
+	if !ok {
+		return models.AssignmentRule{}, gorm.ErrRecordNotFound
+	}
+	return rule, nil
+}
+
+func (r *assignmentRuleRepository) Create(ctx context.Context, rule *models.AssignmentRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&rule.ID, &rule.Model)
+	stored := *rule
+	stored.Users = slices.Clone(rule.Users)
+	r.rules.put(rule.ID, stored)
+	return nil
+}
+
+func (r *assignmentRuleRepository) Update(ctx context.Context, rule *models.AssignmentRule) error {
+	return r.Create(ctx, rule)
+}
+
+func (r *assignmentRuleRepository) Delete(ctx context.Context, rule *models.AssignmentRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules.delete(rule.ID)
+	return nil
+}
+
+func (r *assignmentRuleRepository) Log(ctx context.Context, leadID uuid.UUID) ([]models.LeadAssignmentLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var entries []models.LeadAssignmentLog
+	for _, entry := range r.assignmentLog {
+		if entry.LeadID != leadID {
+			continue
+		}
+		entry.AssignedToUser, _ = r.users.get(entry.AssignedTo)
+		if entry.AssignedBy != nil {
+			if user, ok := r.users.get(*entry.AssignedBy); ok {
+				entry.AssignedByUser = &user
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
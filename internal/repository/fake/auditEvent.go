@@ -0,0 +1,45 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+type auditEventRepository struct {
+	*store
+}
+
+func (r *auditEventRepository) List(ctx context.Context, filter repository.AuditEventFilter, page repository.Page) ([]models.AuditEvent, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var auditEvents []models.AuditEvent
+	for _, event := range r.auditEvents {
+		actorID := ""
+		if event.ActorID != nil {
+			actorID = event.ActorID.String()
+		}
+		if (filter.EntityType == "" || event.EntityType == filter.EntityType) &&
+			(filter.EntityID == "" || event.EntityID == filter.EntityID) &&
+			(filter.ActorID == "" || actorID == filter.ActorID) &&
+			(filter.Action == "" || event.Action == filter.Action) &&
+			(filter.From == nil || !event.CreatedAt.Before(*filter.From)) &&
+			(filter.To == nil || !event.CreatedAt.After(*filter.To)) {
+			auditEvents = append(auditEvents, event)
+		}
+	}
+	totalCount := int64(len(auditEvents))
+	newestFirst(auditEvents, func(event models.AuditEvent) (time.Time, uuid.UUID) { return event.CreatedAt, event.ID })
+	auditEvents = paginate(auditEvents, page)
+	for i := range auditEvents {
+		if auditEvents[i].ActorID != nil {
+			if actor, ok := r.users.get(*auditEvents[i].ActorID); ok {
+				auditEvents[i].Actor = &actor
+			}
+		}
+	}
+	return auditEvents, totalCount, nil
+}
@@ -0,0 +1,102 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// campaignSortKeys are the keys List sorts by.
+var campaignSortKeys = map[string]func(models.Campaign) any{
+	"CAMPAIGN_NAME": func(campaign models.Campaign) any { return campaign.CampaignName },
+	"CREATED_AT":    func(campaign models.Campaign) any { return campaign.CreatedAt },
+}
+
+type campaignRepository struct {
+	*store
+}
+
+func (r *campaignRepository) List(ctx context.Context, filter repository.CampaignFilter, sort repository.Sort, page repository.Page) ([]models.Campaign, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	campaigns := r.campaigns.filter(func(campaign models.Campaign) bool {
+		return (filter.Name == "" || contains(campaign.CampaignName, filter.Name)) &&
+			(filter.Country == "" || campaign.CampaignCountry == filter.Country)
+	})
+	totalCount := int64(len(campaigns))
+	sortBy(campaigns, sort, campaignSortKeys)
+	return paginate(campaigns, page), totalCount, nil
+}
+
+func (r *campaignRepository) Get(ctx context.Context, id string) (models.Campaign, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	campaign, ok := r.campaigns.get(parseID(id))
+	if !ok {
+		return models.Campaign{}, gorm.ErrRecordNotFound
+	}
+	return campaign, nil
+}
+
+func (r *campaignRepository) Create(ctx context.Context, campaign *models.Campaign) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&campaign.ID, &campaign.Model)
+	stored := *campaign
+	stored.Leads, stored.Users = nil, nil
+	r.campaigns.put(campaign.ID, stored)
+	return nil
+}
+
+func (r *campaignRepository) Update(ctx context.Context, campaign *models.Campaign) error {
+	return r.Create(ctx, campaign)
+}
+
+func (r *campaignRepository) Delete(ctx context.Context, campaign *models.Campaign) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.campaigns.delete(campaign.ID)
+	delete(r.campaignUsers, campaign.ID)
+	return nil
+}
+
+func (r *campaignRepository) IsMember(ctx context.Context, campaignID, userID uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.campaignUsers[campaignID][userID], nil
+}
+
+func (r *campaignRepository) AddMember(ctx context.Context, campaign *models.Campaign, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.campaignUsers[campaign.ID] == nil {
+		r.campaignUsers[campaign.ID] = make(map[uuid.UUID]bool)
+	}
+	r.campaignUsers[campaign.ID][user.ID] = true
+	return nil
+}
+
+func (r *campaignRepository) RemoveMember(ctx context.Context, campaign *models.Campaign, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.campaignUsers[campaign.ID], user.ID)
+	return nil
+}
+
+func (r *campaignRepository) Members(ctx context.Context, campaignIDs []uuid.UUID) (map[uuid.UUID][]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	members := make(map[uuid.UUID][]models.User, len(campaignIDs))
+	for _, campaignID := range campaignIDs {
+		users := r.users.filter(func(user models.User) bool { return r.campaignUsers[campaignID][user.ID] })
+		if len(users) == 0 {
+			continue
+		}
+		sortBy(users, repository.Sort{Field: "name"}, userSortKeys)
+		members[campaignID] = users
+	}
+	return members, nil
+}
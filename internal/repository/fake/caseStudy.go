@@ -0,0 +1,71 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// caseStudySortKeys are the keys List sorts by.
+var caseStudySortKeys = map[string]func(models.CaseStudy) any{
+	"createdAt":      func(caseStudy models.CaseStudy) any { return caseStudy.CreatedAt },
+	"updatedAt":      func(caseStudy models.CaseStudy) any { return caseStudy.UpdatedAt },
+	"techStack":      func(caseStudy models.CaseStudy) any { return caseStudy.TechStack },
+	"industryTarget": func(caseStudy models.CaseStudy) any { return caseStudy.IndustryTarget },
+}
+
+type caseStudyRepository struct {
+	*store
+}
+
+func (r *caseStudyRepository) List(ctx context.Context, filter repository.CaseStudyFilter, sort repository.Sort, page repository.Page) ([]models.CaseStudy, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	caseStudies := r.caseStudies.filter(func(caseStudy models.CaseStudy) bool {
+		fields := []string{caseStudy.ProjectName, caseStudy.ClientName, caseStudy.TechStack, caseStudy.IndustryTarget, caseStudy.Tags}
+		search := filter.Search == ""
+		for _, field := range fields {
+			search = search || contains(field, filter.Search)
+		}
+		return search &&
+			(filter.ProjectName == "" || contains(caseStudy.ProjectName, filter.ProjectName)) &&
+			(filter.ClientName == "" || contains(caseStudy.ClientName, filter.ClientName)) &&
+			(filter.TechStack == "" || contains(caseStudy.TechStack, filter.TechStack)) &&
+			(filter.IndustryTarget == "" || contains(caseStudy.IndustryTarget, filter.IndustryTarget)) &&
+			(filter.Tags == "" || contains(caseStudy.Tags, filter.Tags))
+	})
+	totalCount := int64(len(caseStudies))
+	sortBy(caseStudies, sort, caseStudySortKeys)
+	return paginate(caseStudies, page), totalCount, nil
+}
+
+func (r *caseStudyRepository) Get(ctx context.Context, id string) (models.CaseStudy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	caseStudy, ok := r.caseStudies.get(parseID(id))
+	if !ok {
+		return models.CaseStudy{}, gorm.ErrRecordNotFound
+	}
+	return caseStudy, nil
+}
+
+func (r *caseStudyRepository) Create(ctx context.Context, caseStudy *models.CaseStudy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&caseStudy.ID, &caseStudy.Model)
+	r.caseStudies.put(caseStudy.ID, *caseStudy)
+	return nil
+}
+
+func (r *caseStudyRepository) Update(ctx context.Context, caseStudy *models.CaseStudy) error {
+	return r.Create(ctx, caseStudy)
+}
+
+func (r *caseStudyRepository) Delete(ctx context.Context, caseStudy *models.CaseStudy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.caseStudies.delete(caseStudy.ID)
+	return nil
+}
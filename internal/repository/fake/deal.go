@@ -0,0 +1,72 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// dealSortKeys are the keys List sorts by.
+var dealSortKeys = map[string]func(models.Deal) any{
+	"createdAt":     func(deal models.Deal) any { return deal.CreatedAt },
+	"updatedAt":     func(deal models.Deal) any { return deal.UpdatedAt },
+	"dealStartDate": func(deal models.Deal) any { return deal.DealStartDate },
+	"dealEndDate":   func(deal models.Deal) any { return deal.DealEndDate },
+	"dealAmount":    func(deal models.Deal) any { return deal.DealAmount.Amount },
+}
+
+type dealRepository struct {
+	*store
+}
+
+func (r *dealRepository) List(ctx context.Context, filter repository.DealFilter, sort repository.Sort, page repository.Page) ([]models.Deal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deals := r.deals.filter(func(deal models.Deal) bool {
+		amount := deal.DealAmount
+		return (filter.Name == "" || contains(deal.DealName, filter.Name)) &&
+			(filter.LeadID == "" || deal.LeadID.String() == filter.LeadID) &&
+			(filter.Status == "" || deal.DealStatus == filter.Status) &&
+			(filter.Amount == nil || amount.Currency == filter.Amount.Currency && amount.Amount.Equal(filter.Amount.Amount)) &&
+			(filter.MinAmount == nil || amount.Currency == filter.MinAmount.Currency && amount.Amount.GreaterThanOrEqual(filter.MinAmount.Amount)) &&
+			(filter.MaxAmount == nil || amount.Currency == filter.MaxAmount.Currency && amount.Amount.LessThanOrEqual(filter.MaxAmount.Amount)) &&
+			(filter.Search == "" || contains(deal.DealName, filter.Search) || contains(deal.ProjectRequirements, filter.Search))
+	})
+	sortBy(deals, sort, dealSortKeys)
+	return paginate(deals, page), nil
+}
+
+func (r *dealRepository) Get(ctx context.Context, action string, id string) (models.Deal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deal, ok := r.deals.get(parseID(id))
+	if ok {
+		lead, found := r.leads.get(deal.LeadID)
+		ok = found && r.leadScope(ctx, action)(lead)
+	}
+	if !ok {
+		return models.Deal{}, gorm.ErrRecordNotFound
+	}
+	return deal, nil
+}
+
+func (r *dealRepository) Create(ctx context.Context, deal *models.Deal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&deal.ID, &deal.Model)
+	r.deals.put(deal.ID, *deal)
+	return nil
+}
+
+func (r *dealRepository) Update(ctx context.Context, deal *models.Deal) error {
+	return r.Create(ctx, deal)
+}
+
+func (r *dealRepository) Delete(ctx context.Context, deal *models.Deal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deals.delete(deal.ID)
+	return nil
+}
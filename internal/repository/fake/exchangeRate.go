@@ -0,0 +1,48 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+type exchangeRateRepository struct {
+	*store
+}
+
+func (r *exchangeRateRepository) List(ctx context.Context) ([]models.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rates := r.exchangeRates.all()
+	slices.SortStableFunc(rates, func(a, b models.ExchangeRate) int { return strings.Compare(a.Currency, b.Currency) })
+	return rates, nil
+}
+
+func (r *exchangeRateRepository) Get(ctx context.Context, currency string) (models.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rate := range r.exchangeRates.all() {
+		if rate.Currency == currency {
+			return rate, nil
+		}
+	}
+	return models.ExchangeRate{}, gorm.ErrRecordNotFound
+}
+
+func (r *exchangeRateRepository) Save(ctx context.Context, rate *models.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&rate.ID, &rate.Model)
+	r.exchangeRates.put(rate.ID, *rate)
+	return nil
+}
+
+func (r *exchangeRateRepository) Delete(ctx context.Context, rate *models.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchangeRates.delete(rate.ID)
+	return nil
+}
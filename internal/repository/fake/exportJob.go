@@ -0,0 +1,37 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type exportJobRepository struct {
+	*store
+}
+
+func (r *exportJobRepository) List(ctx context.Context, userID string) ([]models.ExportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := r.exportJobs.filter(func(job models.ExportJob) bool { return job.CreatedBy == parseID(userID) })
+	newestFirst(jobs, func(job models.ExportJob) (time.Time, uuid.UUID) { return job.CreatedAt, job.ID })
+	jobs = jobs[:min(50, len(jobs))]
+	for i := range jobs {
+		jobs[i].CreatedByUser, _ = r.users.get(jobs[i].CreatedBy)
+	}
+	return jobs, nil
+}
+
+func (r *exportJobRepository) Get(ctx context.Context, id, userID string) (models.ExportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.exportJobs.get(parseID(id))
+	if !ok || job.CreatedBy != parseID(userID) {
+		return models.ExportJob{}, gorm.ErrRecordNotFound
+	}
+	job.CreatedByUser, _ = r.users.get(job.CreatedBy)
+	return job, nil
+}
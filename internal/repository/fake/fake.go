@@ -0,0 +1,263 @@
+// Package fake implements the repositories of internal/repository in memory,
+// so the services and resolvers can be tested without Postgres.
+//
+// The fakes keep the contracts the services rely on: lookups of a missing
+// record return gorm.ErrRecordNotFound, lists are filtered, sorted and paged,
+// connections walk records newest first by (created_at, id), and leads are
+// scoped like auth.LeadScope. What the GORM repositories leave to other
+// packages is not emulated: leads created without an assignee go to their
+// creator as there are no assignment rules, nothing is scored or audited,
+// deletes are not kept in the trash, committed imports are not run, no
+// webhook deliveries are queued, and amounts in other currencies are compared
+// as they are.
+package fake
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// store holds the records of every fake repository.
+type store struct {
+	mu   sync.Mutex
+	last time.Time
+
+	users         table[models.User]
+	teams         table[models.Team]
+	organizations table[models.Organization]
+	campaigns     table[models.Campaign]
+	leads         table[models.Lead]
+	activities    table[models.Activity]
+	deals         table[models.Deal]
+	tasks         table[models.Task]
+	profiles      table[models.ResourceProfile]
+	caseStudies   table[models.CaseStudy]
+	skills        table[models.Skill]
+	vendors       table[models.Vendor]
+	exchangeRates table[models.ExchangeRate]
+	rules         table[models.AssignmentRule]
+	importJobs    table[models.ImportJob]
+	exportJobs    table[models.ExportJob]
+	webhooks      table[models.Webhook]
+	deliveries    table[models.WebhookDelivery]
+
+	// campaignUsers are the members of each campaign
+	campaignUsers map[uuid.UUID]map[uuid.UUID]bool
+	// resourceSkills are the skills of each resource profile
+	resourceSkills map[uuid.UUID][]models.ResourceSkill
+	// vendorSkills are the ids of the skills of each vendor
+	vendorSkills map[uuid.UUID][]uuid.UUID
+	stageHistory []models.LeadStageHistory
+	// assignmentLog, scoringRules, importRows and auditEvents are in insertion order
+	assignmentLog []models.LeadAssignmentLog
+	scoringRules  []models.ScoringRule
+	importRows    []models.ImportJobRow
+	auditEvents   []models.AuditEvent
+}
+
+// New returns empty in-memory repositories sharing one store.
+func New() *repository.Repositories {
+	s := &store{
+		campaignUsers:  make(map[uuid.UUID]map[uuid.UUID]bool),
+		resourceSkills: make(map[uuid.UUID][]models.ResourceSkill),
+		vendorSkills:   make(map[uuid.UUID][]uuid.UUID),
+	}
+	return &repository.Repositories{
+		Users:            &userRepository{s},
+		Teams:            &teamRepository{s},
+		Organizations:    &organizationRepository{s},
+		Campaigns:        &campaignRepository{s},
+		Leads:            &leadRepository{s},
+		Activities:       &activityRepository{s},
+		Deals:            &dealRepository{s},
+		Tasks:            &taskRepository{s},
+		ResourceProfiles: &resourceProfileRepository{s},
+		CaseStudies:      &caseStudyRepository{s},
+		Skills:           &skillRepository{s},
+		Vendors:          &vendorRepository{s},
+		ExchangeRates:    &exchangeRateRepository{s},
+		AssignmentRules:  &assignmentRuleRepository{s},
+		ScoringRules:     &scoringRuleRepository{s},
+		ImportJobs:       &importJobRepository{s},
+		ExportJobs:       &exportJobRepository{s},
+		Webhooks:         &webhookRepository{s},
+		AuditEvents:      &auditEventRepository{s},
+		Trash:            &trashRepository{s},
+	}
+}
+
+// now returns the current time, always after the previous call, so records
+// created in a row keep their order by created_at.
+func (s *store) now() time.Time {
+	now := time.Now()
+	if !now.After(s.last) {
+		now = s.last.Add(time.Microsecond)
+	}
+	s.last = now
+	return now
+}
+
+// table holds the records of one entity in the order they were inserted.
+type table[V any] struct {
+	ids  []uuid.UUID
+	rows map[uuid.UUID]V
+}
+
+func (t *table[V]) get(id uuid.UUID) (V, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+// put inserts or replaces the record with id.
+func (t *table[V]) put(id uuid.UUID, row V) {
+	if t.rows == nil {
+		t.rows = make(map[uuid.UUID]V)
+	}
+	if _, ok := t.rows[id]; !ok {
+		t.ids = append(t.ids, id)
+	}
+	t.rows[id] = row
+}
+
+func (t *table[V]) delete(id uuid.UUID) {
+	if _, ok := t.rows[id]; !ok {
+		return
+	}
+	delete(t.rows, id)
+	t.ids = slices.DeleteFunc(t.ids, func(existing uuid.UUID) bool { return existing == id })
+}
+
+// all returns the records in insertion order.
+func (t *table[V]) all() []V {
+	rows := make([]V, 0, len(t.ids))
+	for _, id := range t.ids {
+		rows = append(rows, t.rows[id])
+	}
+	return rows
+}
+
+// filter returns the records keep accepts, in insertion order.
+func (t *table[V]) filter(keep func(V) bool) []V {
+	var rows []V
+	for _, id := range t.ids {
+		if row := t.rows[id]; keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// parseID parses the string id of a record. An invalid id matches no record.
+func parseID(id string) uuid.UUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil
+	}
+	return parsed
+}
+
+// contains reports whether s contains part, ignoring case, like ILIKE '%part%'.
+func contains(s, part string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(part))
+}
+
+// sortBy orders records by the key of keys named by sort.Field, or keeps
+// their order when there is none. Keys are strings, numbers, times or decimals.
+func sortBy[V any](records []V, sort repository.Sort, keys map[string]func(V) any) {
+	key, ok := keys[sort.Field]
+	if !ok {
+		return
+	}
+	slices.SortStableFunc(records, func(a, b V) int {
+		result := compare(key(a), key(b))
+		if sort.Desc {
+			return -result
+		}
+		return result
+	})
+}
+
+// orDefault returns sort, or fallback if sort names none of keys.
+func orDefault[V any](sort repository.Sort, keys map[string]func(V) any, fallback repository.Sort) repository.Sort {
+	if _, ok := keys[sort.Field]; ok {
+		return sort
+	}
+	return fallback
+}
+
+func compare(a, b any) int {
+	switch a := a.(type) {
+	case string:
+		return cmp.Compare(a, b.(string))
+	case int:
+		return cmp.Compare(a, b.(int))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case decimal.Decimal:
+		return a.Cmp(b.(decimal.Decimal))
+	}
+	panic("fake: unsupported sort key")
+}
+
+// newestFirst orders records by created_at and id, newest first.
+func newestFirst[V any](records []V, key func(V) (time.Time, uuid.UUID)) {
+	slices.SortStableFunc(records, func(a, b V) int {
+		aTime, aID := key(a)
+		bTime, bID := key(b)
+		if result := bTime.Compare(aTime); result != 0 {
+			return result
+		}
+		return bytes.Compare(bID[:], aID[:])
+	})
+}
+
+// paginate returns the records on page.
+func paginate[V any](records []V, page repository.Page) []V {
+	if page.Limit <= 0 {
+		return records
+	}
+	if page.Offset >= len(records) {
+		return nil
+	}
+	return records[page.Offset:min(page.Offset+page.Limit, len(records))]
+}
+
+// keyset returns the records on the connection page cursor selects, see repository.Cursor.
+func keyset[V any](records []V, cursor repository.Cursor, key func(V) (time.Time, uuid.UUID)) []V {
+	newestFirst(records, key)
+	if cursor.ID != uuid.Nil {
+		records = slices.DeleteFunc(records, func(record V) bool {
+			createdAt, id := key(record)
+			if !createdAt.Equal(cursor.CreatedAt) {
+				return createdAt.After(cursor.CreatedAt)
+			}
+			return bytes.Compare(id[:], cursor.ID[:]) >= 0
+		})
+	}
+	return records[:min(cursor.Size+1, len(records))]
+}
+
+// stamp sets the id of a record about to be inserted, unless it has one, and
+// its timestamps.
+func (s *store) stamp(id *uuid.UUID, model *gorm.Model) {
+	if *id == uuid.Nil {
+		*id = uuid.New()
+	}
+	now := s.now()
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	model.UpdatedAt = now
+}
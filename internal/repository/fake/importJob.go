@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type importJobRepository struct {
+	*store
+}
+
+func (r *importJobRepository) List(ctx context.Context, userID string) ([]models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := r.importJobs.filter(func(job models.ImportJob) bool { return job.CreatedBy == parseID(userID) })
+	newestFirst(jobs, func(job models.ImportJob) (time.Time, uuid.UUID) { return job.CreatedAt, job.ID })
+	jobs = jobs[:min(50, len(jobs))]
+	for i := range jobs {
+		jobs[i].CreatedByUser, _ = r.users.get(jobs[i].CreatedBy)
+	}
+	return jobs, nil
+}
+
+func (r *importJobRepository) Get(ctx context.Context, id, userID string) (models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.importJobs.get(parseID(id))
+	if !ok || job.CreatedBy != parseID(userID) {
+		return models.ImportJob{}, gorm.ErrRecordNotFound
+	}
+	job.CreatedByUser, _ = r.users.get(job.CreatedBy)
+	return job, nil
+}
+
+func (r *importJobRepository) Rows(ctx context.Context, id string, status string) ([]models.ImportJobRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rows []models.ImportJobRow
+	for _, row := range r.importRows {
+		if row.JobID == parseID(id) && (status == "" || row.Status == status) {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b models.ImportJobRow) int { return cmp.Compare(a.Row, b.Row) })
+	return rows, nil
+}
+
+// Commit marks the job pending like importer.Commit, but does not run it.
+func (r *importJobRepository) Commit(ctx context.Context, services importer.Services, id uuid.UUID) (*models.ImportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.importJobs.get(id)
+	if !ok || job.CreatedBy != parseID(userID) {
+		return nil, apperr.NotFound("import job not found")
+	}
+	if !job.DryRun || job.Status != models.ImportStatusPreviewReady {
+		return nil, apperr.Conflict("only a dry run with status %s can be committed, this job is %s", models.ImportStatusPreviewReady, job.Status)
+	}
+	job.DryRun = false
+	job.Status = models.ImportStatusPending
+	r.importJobs.put(job.ID, job)
+	return &job, nil
+}
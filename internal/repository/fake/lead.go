@@ -0,0 +1,305 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/dedupe"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// leadSortKeys are the keys List sorts by.
+var leadSortKeys = map[string]func(models.Lead) any{
+	"FIRST_NAME": func(lead models.Lead) any { return lead.FirstName },
+	"LAST_NAME":  func(lead models.Lead) any { return lead.LastName },
+	"EMAIL":      func(lead models.Lead) any { return lead.Email },
+	"CREATED_AT": func(lead models.Lead) any { return lead.CreatedAt },
+	"SCORE":      func(lead models.Lead) any { return lead.Score },
+}
+
+func leadKey(lead models.Lead) (time.Time, uuid.UUID) {
+	return lead.CreatedAt, lead.ID
+}
+
+type leadRepository struct {
+	*store
+}
+
+// leadScope reports which leads the user in ctx may perform action on, like
+// auth.LeadScope.
+func (s *store) leadScope(ctx context.Context, action string) func(models.Lead) bool {
+	permission := "lead:" + action
+	if auth.HasPermission(ctx, permission) {
+		return func(models.Lead) bool { return true }
+	}
+
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil || !auth.HasPermission(ctx, permission+":"+auth.PermissionScopeOwn) {
+		return func(models.Lead) bool { return false }
+	}
+	user := parseID(userID)
+	var team []uuid.UUID
+	if auth.HasPermission(ctx, permission+":"+auth.PermissionScopeTeam) {
+		team = s.teamUserIDs(user)
+	}
+	campaigns := auth.HasPermission(ctx, permission+":"+auth.PermissionScopeCampaign)
+	return func(lead models.Lead) bool {
+		return lead.LeadCreatedBy == user || lead.LeadAssignedTo == user ||
+			slices.Contains(team, lead.LeadCreatedBy) || slices.Contains(team, lead.LeadAssignedTo) ||
+			campaigns && s.campaignUsers[lead.CampaignID][user]
+	}
+}
+
+// filterLeads reports which leads match filter.
+func (s *store) filterLeads(filter repository.LeadFilter) func(models.Lead) bool {
+	return func(lead models.Lead) bool {
+		if filter.TeamID != "" {
+			assignee, _ := s.users.get(lead.LeadAssignedTo)
+			if assignee.TeamID == nil || assignee.TeamID.String() != filter.TeamID {
+				return false
+			}
+		}
+		return (filter.Name == "" || contains(lead.FirstName, filter.Name)) &&
+			(filter.Email == "" || contains(lead.Email, filter.Email))
+	}
+}
+
+// preload fills the associations of lead, the creator, assignee and
+// organization only if full is set.
+func (s *store) preload(lead *models.Lead, full bool) {
+	lead.Campaign, _ = s.campaigns.get(lead.CampaignID)
+	lead.Activities = s.activities.filter(func(activity models.Activity) bool { return activity.LeadID == lead.ID })
+	if full {
+		lead.Creator, _ = s.users.get(lead.LeadCreatedBy)
+		lead.Assignee, _ = s.users.get(lead.LeadAssignedTo)
+		lead.Organization, _ = s.organizations.get(lead.OrganizationID)
+	}
+}
+
+func (r *leadRepository) List(ctx context.Context, filter repository.LeadFilter, sort repository.Sort, page repository.Page) ([]models.Lead, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scope, match := r.leadScope(ctx, auth.LeadActionRead), r.filterLeads(filter)
+	leads := r.leads.filter(func(lead models.Lead) bool { return scope(lead) && match(lead) })
+	totalCount := int64(len(leads))
+	sortBy(leads, sort, leadSortKeys)
+	leads = paginate(leads, page)
+	for i := range leads {
+		r.preload(&leads[i], false)
+	}
+	return leads, totalCount, nil
+}
+
+func (r *leadRepository) Page(ctx context.Context, filter repository.LeadFilter, cursor repository.Cursor) ([]models.Lead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scope, match := r.leadScope(ctx, auth.LeadActionRead), r.filterLeads(filter)
+	leads := keyset(r.leads.filter(func(lead models.Lead) bool { return scope(lead) && match(lead) }), cursor, leadKey)
+	for i := range leads {
+		r.preload(&leads[i], false)
+	}
+	return leads, nil
+}
+
+func (r *leadRepository) Get(ctx context.Context, action string, id string) (models.Lead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lead, ok := r.leads.get(parseID(id))
+	if !ok || !r.leadScope(ctx, action)(lead) {
+		return models.Lead{}, gorm.ErrRecordNotFound
+	}
+	r.preload(&lead, true)
+	return lead, nil
+}
+
+func (r *leadRepository) Find(ctx context.Context, action string, ids []string) ([]models.Lead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scope := r.leadScope(ctx, action)
+	return r.leads.filter(func(lead models.Lead) bool {
+		return scope(lead) && slices.Contains(ids, lead.ID.String())
+	}), nil
+}
+
+func (r *leadRepository) ByCampaign(ctx context.Context, campaignID string) ([]models.Lead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scope := r.leadScope(ctx, auth.LeadActionRead)
+	leads := r.leads.filter(func(lead models.Lead) bool {
+		return scope(lead) && lead.CampaignID.String() == campaignID
+	})
+	newestFirst(leads, leadKey)
+	for i := range leads {
+		r.preload(&leads[i], false)
+	}
+	return leads, nil
+}
+
+func (r *leadRepository) Create(ctx context.Context, lead *models.Lead, assignee *models.User, activity *models.Activity) (models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Without assignment rules the creator owns the leads nobody was picked for
+	owner := models.User{ID: lead.LeadCreatedBy}
+	if assignee != nil {
+		owner = *assignee
+	} else if creator, ok := r.users.get(lead.LeadCreatedBy); ok {
+		owner = creator
+	}
+	lead.LeadAssignedTo = owner.ID
+
+	r.stamp(&lead.ID, &lead.Model)
+	r.putLead(*lead)
+	if activity != nil {
+		activity.LeadID = lead.ID
+		r.stamp(&activity.ID, &activity.Model)
+		r.activities.put(activity.ID, *activity)
+	}
+	return owner, nil
+}
+
+// putLead stores lead without its associations.
+func (s *store) putLead(lead models.Lead) {
+	lead.Creator, lead.Assignee = models.User{}, models.User{}
+	lead.Organization, lead.Campaign, lead.Activities = models.Organization{}, models.Campaign{}, nil
+	s.leads.put(lead.ID, lead)
+}
+
+func (r *leadRepository) Update(ctx context.Context, lead *models.Lead, change repository.LeadChange) (*models.Deal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.leads.get(lead.ID); !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	r.stamp(&lead.ID, &lead.Model)
+	r.putLead(*lead)
+	if change.OldStage != nil {
+		entry := models.LeadStageHistory{
+			LeadID:    lead.ID,
+			OldStage:  *change.OldStage,
+			NewStage:  lead.LeadStage,
+			ChangedAt: r.now(),
+			ChangedBy: change.ChangedBy,
+		}
+		r.stamp(&entry.ID, &entry.Model)
+		r.stageHistory = append(r.stageHistory, entry)
+	}
+	if !change.Won || len(r.deals.filter(func(deal models.Deal) bool { return deal.LeadID == lead.ID })) > 0 {
+		return nil, nil
+	}
+	deal := &models.Deal{
+		LeadID:        lead.ID,
+		DealName:      lead.FirstName + " " + lead.LastName,
+		DealAmount:    models.Money{Currency: models.BaseCurrency()},
+		DealStartDate: time.Now(),
+		DealEndDate:   time.Now().AddDate(0, 6, 0),
+		DealStatus:    "Active",
+	}
+	r.stamp(&deal.ID, &deal.Model)
+	r.deals.put(deal.ID, *deal)
+	return deal, nil
+}
+
+func (r *leadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.leads.get(id); !ok {
+		return gorm.ErrRecordNotFound
+	}
+	r.leads.delete(id)
+	for _, activity := range r.activities.all() {
+		if activity.LeadID == id {
+			r.activities.delete(activity.ID)
+		}
+	}
+	return nil
+}
+
+func (r *leadRepository) Merge(ctx context.Context, survivor *models.Lead, duplicates []models.Lead) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	merged := make(map[uuid.UUID]bool, len(duplicates))
+	for _, duplicate := range duplicates {
+		if duplicate.ID == survivor.ID {
+			return fmt.Errorf("lead %s cannot be merged into itself", survivor.ID)
+		}
+		merged[duplicate.ID] = true
+	}
+	for _, activity := range r.activities.all() {
+		if merged[activity.LeadID] {
+			activity.LeadID = survivor.ID
+			r.activities.put(activity.ID, activity)
+		}
+	}
+	for _, deal := range r.deals.all() {
+		if merged[deal.LeadID] {
+			deal.LeadID = survivor.ID
+			r.deals.put(deal.ID, deal)
+		}
+	}
+	for i, entry := range r.stageHistory {
+		if merged[entry.LeadID] {
+			r.stageHistory[i].LeadID = survivor.ID
+		}
+	}
+	dedupe.FillLead(survivor, duplicates)
+	r.putLead(*survivor)
+	for id := range merged {
+		r.leads.delete(id)
+	}
+	return nil
+}
+
+func (r *leadRepository) StageHistory(ctx context.Context, leadIDs []uuid.UUID) ([]models.LeadStageHistory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var history []models.LeadStageHistory
+	for _, entry := range r.stageHistory {
+		if !slices.Contains(leadIDs, entry.LeadID) {
+			continue
+		}
+		if entry.ChangedBy != nil {
+			if user, ok := r.users.get(*entry.ChangedBy); ok {
+				entry.ChangedByUser = &user
+			}
+		}
+		history = append(history, entry)
+	}
+	slices.SortStableFunc(history, func(a, b models.LeadStageHistory) int { return a.ChangedAt.Compare(b.ChangedAt) })
+	return history, nil
+}
+
+func (r *leadRepository) Duplicates(ctx context.Context, lead dedupe.Lead) ([]dedupe.LeadMatch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sameOrganization := map[uuid.UUID]bool{}
+	if lead.OrganizationID != uuid.Nil {
+		sameOrganization[lead.OrganizationID] = true
+	}
+	if domain := dedupe.EmailDomain(lead.Email); domain != "" {
+		for _, organization := range r.organizations.all() {
+			if dedupe.WebsiteDomain(organization.OrganizationWebsite) == domain {
+				sameOrganization[organization.ID] = true
+			}
+		}
+	}
+
+	scope := r.leadScope(ctx, auth.LeadActionRead)
+	matches := []dedupe.LeadMatch{}
+	for _, candidate := range r.leads.all() {
+		if candidate.ID == lead.ID || !scope(candidate) {
+			continue
+		}
+		if reasons := dedupe.LeadReasons(lead, sameOrganization, candidate); len(reasons) > 0 {
+			r.preload(&candidate, true)
+			candidate.Activities = nil
+			matches = append(matches, dedupe.LeadMatch{Lead: candidate, Reasons: reasons})
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,120 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/Zenithive/it-crm-backend/internal/dedupe"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// organizationSortKeys are the keys List sorts by.
+var organizationSortKeys = map[string]func(models.Organization) any{
+	"ORGANIZATION_NAME": func(organization models.Organization) any { return organization.OrganizationName },
+	"COUNTRY":           func(organization models.Organization) any { return organization.Country },
+	"NO_OF_EMPLOYEES":   func(organization models.Organization) any { return organization.NoOfEmployees },
+	"ANNUAL_REVENUE":    func(organization models.Organization) any { return organization.AnnualRevenue.Amount },
+}
+
+type organizationRepository struct {
+	*store
+}
+
+func (r *organizationRepository) List(ctx context.Context, filter repository.OrganizationFilter, sort repository.Sort, page repository.Page) ([]models.Organization, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	organizations := r.organizations.filter(func(organization models.Organization) bool {
+		employees, _ := strconv.Atoi(organization.NoOfEmployees)
+		return (filter.Search == "" || contains(organization.OrganizationName, filter.Search)) &&
+			(filter.Country == "" || organization.Country == filter.Country) &&
+			(filter.MinEmployees == nil || employees >= *filter.MinEmployees) &&
+			(filter.MaxEmployees == nil || employees <= *filter.MaxEmployees)
+	})
+	totalCount := int64(len(organizations))
+	sortBy(organizations, sort, organizationSortKeys)
+	return paginate(organizations, page), totalCount, nil
+}
+
+func (r *organizationRepository) Get(ctx context.Context, id string) (models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	organization, ok := r.organizations.get(parseID(id))
+	if !ok {
+		return models.Organization{}, gorm.ErrRecordNotFound
+	}
+	return organization, nil
+}
+
+func (r *organizationRepository) Find(ctx context.Context, ids []string) ([]models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.organizations.filter(func(organization models.Organization) bool {
+		return slices.Contains(ids, organization.ID.String())
+	}), nil
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *models.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&organization.ID, &organization.Model)
+	stored := *organization
+	stored.Leads = nil
+	r.organizations.put(organization.ID, stored)
+	return nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, organization *models.Organization) error {
+	return r.Create(ctx, organization)
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, organization *models.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.organizations.delete(organization.ID)
+	return nil
+}
+
+func (r *organizationRepository) Merge(ctx context.Context, survivor *models.Organization, duplicates []models.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	merged := make(map[uuid.UUID]bool, len(duplicates))
+	for _, duplicate := range duplicates {
+		if duplicate.ID == survivor.ID {
+			return fmt.Errorf("organization %s cannot be merged into itself", survivor.ID)
+		}
+		merged[duplicate.ID] = true
+	}
+	for _, lead := range r.leads.all() {
+		if merged[lead.OrganizationID] {
+			lead.OrganizationID = survivor.ID
+			r.leads.put(lead.ID, lead)
+		}
+	}
+	dedupe.FillOrganization(survivor, duplicates)
+	r.stamp(&survivor.ID, &survivor.Model)
+	r.organizations.put(survivor.ID, *survivor)
+	for id := range merged {
+		r.organizations.delete(id)
+	}
+	return nil
+}
+
+func (r *organizationRepository) Duplicates(ctx context.Context, organization dedupe.Organization) ([]dedupe.OrganizationMatch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matches := []dedupe.OrganizationMatch{}
+	for _, candidate := range r.organizations.all() {
+		if candidate.ID == organization.ID {
+			continue
+		}
+		if reasons := dedupe.OrganizationReasons(organization, candidate); len(reasons) > 0 {
+			matches = append(matches, dedupe.OrganizationMatch{Organization: candidate, Reasons: reasons})
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,190 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// resourceProfileSortKeys are the keys List sorts by.
+var resourceProfileSortKeys = map[string]func(models.ResourceProfile) any{
+	"createdAt":       func(profile models.ResourceProfile) any { return profile.CreatedAt },
+	"updatedAt":       func(profile models.ResourceProfile) any { return profile.UpdatedAt },
+	"firstName":       func(profile models.ResourceProfile) any { return profile.FirstName },
+	"lastName":        func(profile models.ResourceProfile) any { return profile.LastName },
+	"totalExperience": func(profile models.ResourceProfile) any { return profile.TotalExperience },
+	"status":          func(profile models.ResourceProfile) any { return string(profile.Status) },
+}
+
+func resourceProfileKey(profile models.ResourceProfile) (time.Time, uuid.UUID) {
+	return profile.CreatedAt, profile.ID
+}
+
+type resourceProfileRepository struct {
+	*store
+}
+
+// filterResourceProfiles reports which resource profiles match filter.
+func (s *store) filterResourceProfiles(filter repository.ResourceProfileFilter) func(models.ResourceProfile) bool {
+	return func(profile models.ResourceProfile) bool {
+		skills := s.resourceSkills[profile.ID]
+		search := filter.Search == "" || contains(profile.FirstName, filter.Search) || contains(profile.LastName, filter.Search) ||
+			slices.ContainsFunc(skills, func(resourceSkill models.ResourceSkill) bool {
+				skill, _ := s.skills.get(resourceSkill.SkillID)
+				return contains(skill.Name, filter.Search)
+			})
+		return search &&
+			(filter.Type == "" || string(profile.Type) == filter.Type) &&
+			(filter.FirstName == "" || contains(profile.FirstName, filter.FirstName)) &&
+			(filter.LastName == "" || contains(profile.LastName, filter.LastName)) &&
+			(filter.TotalExperienceMin == nil || profile.TotalExperience >= *filter.TotalExperienceMin) &&
+			(filter.TotalExperienceMax == nil || profile.TotalExperience <= *filter.TotalExperienceMax) &&
+			(filter.Status == "" || string(profile.Status) == filter.Status) &&
+			(filter.VendorID == "" || profile.VendorID.String() == filter.VendorID) &&
+			(len(filter.SkillIDs) == 0 || slices.ContainsFunc(skills, func(resourceSkill models.ResourceSkill) bool {
+				return slices.Contains(filter.SkillIDs, resourceSkill.SkillID.String())
+			}))
+	}
+}
+
+// preloadProfileVendor loads the vendor of profile.
+func (s *store) preloadProfileVendor(profile *models.ResourceProfile) {
+	profile.Vendor = nil
+	if vendor, ok := s.vendors.get(profile.VendorID); ok {
+		profile.Vendor = &vendor
+	}
+}
+
+// skillsOf returns the skills of the profile with id, with the skill they are of.
+func (s *store) skillsOf(id uuid.UUID) []models.ResourceSkill {
+	skills := slices.Clone(s.resourceSkills[id])
+	for i := range skills {
+		skills[i].Skill, _ = s.skills.get(skills[i].SkillID)
+	}
+	return skills
+}
+
+func (r *resourceProfileRepository) List(ctx context.Context, filter repository.ResourceProfileFilter, sort repository.Sort, page repository.Page) ([]models.ResourceProfile, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profiles := r.profiles.filter(r.filterResourceProfiles(filter))
+	totalCount := int64(len(profiles))
+	sortBy(profiles, orDefault(sort, resourceProfileSortKeys, repository.Sort{Field: "createdAt", Desc: true}), resourceProfileSortKeys)
+	profiles = paginate(profiles, page)
+	for i := range profiles {
+		r.preloadProfileVendor(&profiles[i])
+	}
+	return profiles, totalCount, nil
+}
+
+func (r *resourceProfileRepository) Page(ctx context.Context, filter repository.ResourceProfileFilter, cursor repository.Cursor) ([]models.ResourceProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profiles := keyset(r.profiles.filter(r.filterResourceProfiles(filter)), cursor, resourceProfileKey)
+	for i := range profiles {
+		r.preloadProfileVendor(&profiles[i])
+	}
+	return profiles, nil
+}
+
+func (r *resourceProfileRepository) Get(ctx context.Context, id string) (models.ResourceProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, ok := r.profiles.get(parseID(id))
+	if !ok {
+		return models.ResourceProfile{}, gorm.ErrRecordNotFound
+	}
+	r.preloadProfileVendor(&profile)
+	profile.ResourceSkills = r.skillsOf(profile.ID)
+	return profile, nil
+}
+
+func (r *resourceProfileRepository) Create(ctx context.Context, profile *models.ResourceProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&profile.ID, &profile.Model)
+	for i := range profile.PastProjects {
+		profile.PastProjects[i].ResourceProfileID = profile.ID
+		r.stamp(&profile.PastProjects[i].ID, &profile.PastProjects[i].Model)
+	}
+	for i := range profile.ResourceSkills {
+		profile.ResourceSkills[i].ResourceProfileID = profile.ID
+	}
+	r.setResourceSkills(profile.ID, profile.ResourceSkills)
+	r.putProfile(*profile)
+	return nil
+}
+
+// setResourceSkills makes skills the skills of the profile with id.
+func (s *store) setResourceSkills(id uuid.UUID, skills []models.ResourceSkill) {
+	stored := make([]models.ResourceSkill, 0, len(skills))
+	for _, skill := range skills {
+		skill.Skill = models.Skill{}
+		if skill.CreatedAt.IsZero() {
+			skill.CreatedAt = s.now()
+		}
+		skill.UpdatedAt = skill.CreatedAt
+		stored = append(stored, skill)
+	}
+	s.resourceSkills[id] = stored
+}
+
+// putProfile stores profile with its past projects, but not its vendor and skills.
+func (s *store) putProfile(profile models.ResourceProfile) {
+	profile.Vendor, profile.ResourceSkills = nil, nil
+	s.profiles.put(profile.ID, profile)
+}
+
+func (r *resourceProfileRepository) Update(ctx context.Context, profile *models.ResourceProfile, skills []models.ResourceSkill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.profiles.get(profile.ID)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if skills != nil {
+		r.setResourceSkills(profile.ID, skills)
+	}
+	// The associations are not saved with the profile
+	updated := *profile
+	updated.PastProjects = stored.PastProjects
+	r.stamp(&updated.ID, &updated.Model)
+	r.putProfile(updated)
+	return nil
+}
+
+func (r *resourceProfileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.profiles.get(id); !ok {
+		return gorm.ErrRecordNotFound
+	}
+	r.profiles.delete(id)
+	delete(r.resourceSkills, id)
+	return nil
+}
+
+func (r *resourceProfileRepository) ByVendors(ctx context.Context, vendorIDs []uuid.UUID) ([]models.ResourceProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profiles := r.profiles.filter(func(profile models.ResourceProfile) bool {
+		return slices.Contains(vendorIDs, profile.VendorID)
+	})
+	sortBy(profiles, repository.Sort{Field: "createdAt"}, resourceProfileSortKeys)
+	return profiles, nil
+}
+
+func (r *resourceProfileRepository) Skills(ctx context.Context, profileIDs []uuid.UUID) ([]models.ResourceSkill, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var skills []models.ResourceSkill
+	for _, id := range profileIDs {
+		skills = append(skills, r.skillsOf(id)...)
+	}
+	return skills, nil
+}
@@ -0,0 +1,37 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+type scoringRuleRepository struct {
+	*store
+}
+
+func (r *scoringRuleRepository) List(ctx context.Context) ([]models.ScoringRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sortedScoringRules(), nil
+}
+
+func (r *scoringRuleRepository) Replace(ctx context.Context, rules []models.ScoringRule) ([]models.ScoringRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scoringRules = make([]models.ScoringRule, 0, len(rules))
+	for _, rule := range rules {
+		r.stamp(&rule.ID, &rule.Model)
+		r.scoringRules = append(r.scoringRules, rule)
+	}
+	return r.sortedScoringRules(), nil
+}
+
+// sortedScoringRules returns the scoring rules by attribute, then as inserted.
+func (s *store) sortedScoringRules() []models.ScoringRule {
+	rules := slices.Clone(s.scoringRules)
+	slices.SortStableFunc(rules, func(a, b models.ScoringRule) int { return strings.Compare(a.Attribute, b.Attribute) })
+	return rules
+}
@@ -0,0 +1,72 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// skillSortKeys are the keys List sorts by.
+var skillSortKeys = map[string]func(models.Skill) any{
+	"name":      func(skill models.Skill) any { return skill.Name },
+	"skillType": func(skill models.Skill) any { return string(skill.SkillType) },
+	"createdAt": func(skill models.Skill) any { return skill.CreatedAt },
+}
+
+type skillRepository struct {
+	*store
+}
+
+func (r *skillRepository) List(ctx context.Context, filter repository.SkillFilter, sort repository.Sort, page repository.Page) ([]models.Skill, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	skills := r.skills.filter(func(skill models.Skill) bool {
+		return (filter.Name == "" || contains(skill.Name, filter.Name)) &&
+			(filter.SkillType == "" || string(skill.SkillType) == filter.SkillType)
+	})
+	totalCount := int64(len(skills))
+	sortBy(skills, orDefault(sort, skillSortKeys, repository.Sort{Field: "name"}), skillSortKeys)
+	return paginate(skills, page), totalCount, nil
+}
+
+func (r *skillRepository) Get(ctx context.Context, id string) (models.Skill, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	skill, ok := r.skills.get(parseID(id))
+	if !ok {
+		return models.Skill{}, gorm.ErrRecordNotFound
+	}
+	return skill, nil
+}
+
+func (r *skillRepository) ByName(ctx context.Context, name string) (models.Skill, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, skill := range r.skills.all() {
+		if skill.Name == name {
+			return skill, nil
+		}
+	}
+	return models.Skill{}, gorm.ErrRecordNotFound
+}
+
+func (r *skillRepository) Create(ctx context.Context, skill *models.Skill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&skill.ID, &skill.Model)
+	r.skills.put(skill.ID, *skill)
+	return nil
+}
+
+func (r *skillRepository) Update(ctx context.Context, skill *models.Skill) error {
+	return r.Create(ctx, skill)
+}
+
+func (r *skillRepository) Delete(ctx context.Context, skill *models.Skill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.skills.delete(skill.ID)
+	return nil
+}
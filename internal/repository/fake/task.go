@@ -0,0 +1,103 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// taskSortKeys are the keys List sorts by, and created_at its default order.
+var taskSortKeys = map[string]func(models.Task) any{
+	"TITLE":      func(task models.Task) any { return task.Title },
+	"STATUS":     func(task models.Task) any { return string(task.Status) },
+	"PRIORITY":   func(task models.Task) any { return string(task.Priority) },
+	"DUE_DATE":   func(task models.Task) any { return dueDate(task) },
+	"created_at": func(task models.Task) any { return task.CreatedAt },
+}
+
+// dueDate returns the due date of task, the zero time if it has none.
+func dueDate(task models.Task) time.Time {
+	if task.DueDate == nil {
+		return time.Time{}
+	}
+	return *task.DueDate
+}
+
+func taskKey(task models.Task) (time.Time, uuid.UUID) {
+	return task.CreatedAt, task.ID
+}
+
+type taskRepository struct {
+	*store
+}
+
+// filterTasks reports which tasks match filter.
+func (s *store) filterTasks(filter repository.TaskFilter) func(models.Task) bool {
+	return func(task models.Task) bool {
+		if filter.TeamID != "" {
+			user, _ := s.users.get(task.UserID)
+			if user.TeamID == nil || user.TeamID.String() != filter.TeamID {
+				return false
+			}
+		}
+		return (filter.UserID == "" || task.UserID.String() == filter.UserID) &&
+			(filter.Status == "" || string(task.Status) == filter.Status) &&
+			(filter.Priority == "" || string(task.Priority) == filter.Priority) &&
+			(filter.DueDate == "" || task.DueDate != nil && task.DueDate.Format(time.DateOnly) == filter.DueDate)
+	}
+}
+
+func (r *taskRepository) List(ctx context.Context, filter repository.TaskFilter, sort repository.Sort, page repository.Page) ([]models.Task, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := r.tasks.filter(r.filterTasks(filter))
+	totalCount := int64(len(tasks))
+	sortBy(tasks, orDefault(sort, taskSortKeys, repository.Sort{Field: "created_at", Desc: true}), taskSortKeys)
+	return paginate(tasks, page), totalCount, nil
+}
+
+func (r *taskRepository) Page(ctx context.Context, filter repository.TaskFilter, cursor repository.Cursor) ([]models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := keyset(r.tasks.filter(r.filterTasks(filter)), cursor, taskKey)
+	for i := range tasks {
+		tasks[i].User, _ = r.users.get(tasks[i].UserID)
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) Get(ctx context.Context, id string) (models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks.get(parseID(id))
+	if !ok {
+		return models.Task{}, gorm.ErrRecordNotFound
+	}
+	task.User, _ = r.users.get(task.UserID)
+	return task, nil
+}
+
+func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&task.ID, &task.Model)
+	stored := *task
+	stored.User = models.User{}
+	r.tasks.put(task.ID, stored)
+	return nil
+}
+
+func (r *taskRepository) Update(ctx context.Context, task *models.Task) error {
+	return r.Create(ctx, task)
+}
+
+func (r *taskRepository) Delete(ctx context.Context, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks.delete(task.ID)
+	return nil
+}
@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+type teamRepository struct {
+	*store
+}
+
+func (r *teamRepository) List(ctx context.Context) ([]models.Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	teams := r.teams.all()
+	slices.SortStableFunc(teams, func(a, b models.Team) int { return strings.Compare(a.Name, b.Name) })
+	return teams, nil
+}
+
+func (r *teamRepository) Get(ctx context.Context, id string) (models.Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team, ok := r.teams.get(parseID(id))
+	if !ok {
+		return models.Team{}, gorm.ErrRecordNotFound
+	}
+	return team, nil
+}
+
+func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&team.ID, &team.Model)
+	stored := *team
+	stored.Members = nil
+	r.teams.put(team.ID, stored)
+	return nil
+}
+
+func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
+	return r.Create(ctx, team)
+}
+
+func (r *teamRepository) Delete(ctx context.Context, team *models.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users.all() {
+		if user.TeamID != nil && *user.TeamID == team.ID {
+			user.TeamID = nil
+			r.users.put(user.ID, user)
+		}
+	}
+	r.teams.delete(team.ID)
+	return nil
+}
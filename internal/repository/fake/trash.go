@@ -0,0 +1,36 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/trash"
+	"github.com/google/uuid"
+)
+
+// trashRepository is an empty trash, as the fakes delete records for good.
+type trashRepository struct {
+	*store
+}
+
+func (r *trashRepository) List(ctx context.Context, entityType string, page repository.Page) ([]trash.Item, int64, error) {
+	if _, ok := trash.Entities[entityType]; !ok {
+		return nil, 0, trash.ErrUnknownEntity
+	}
+	return nil, 0, nil
+}
+
+func (r *trashRepository) Restore(ctx context.Context, entityType string, id uuid.UUID) (trash.Item, error) {
+	if _, ok := trash.Entities[entityType]; !ok {
+		return trash.Item{}, trash.ErrUnknownEntity
+	}
+	return trash.Item{}, trash.ErrNotFound
+}
+
+func (r *trashRepository) Purge(ctx context.Context, entityType string, before time.Time) (int64, error) {
+	if _, ok := trash.Entities[entityType]; entityType != "" && !ok {
+		return 0, trash.ErrUnknownEntity
+	}
+	return 0, nil
+}
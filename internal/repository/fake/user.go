@@ -0,0 +1,180 @@
+package fake
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxReportingDepth bounds walks down the manager chain, as in the GORM repository.
+const maxReportingDepth = 50
+
+// userSortKeys are the keys List sorts by.
+var userSortKeys = map[string]func(models.User) any{
+	"createdAt": func(user models.User) any { return user.CreatedAt },
+	"name":      func(user models.User) any { return user.Name },
+	"email":     func(user models.User) any { return user.Email },
+	"role":      func(user models.User) any { return user.Role },
+}
+
+type userRepository struct {
+	*store
+}
+
+func (r *userRepository) List(ctx context.Context, filter repository.UserFilter, sort repository.Sort, page repository.Page) ([]models.User, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.users.filter(func(user models.User) bool {
+		return (filter.Name == "" || contains(user.Name, filter.Name)) &&
+			(filter.Email == "" || contains(user.Email, filter.Email)) &&
+			(filter.Role == "" || user.Role == filter.Role) &&
+			(filter.TeamID == "" || user.TeamID != nil && user.TeamID.String() == filter.TeamID) &&
+			(filter.Search == "" || contains(user.Name, filter.Search) || contains(user.Email, filter.Search))
+	})
+	totalCount := int64(len(users))
+	sortBy(users, sort, userSortKeys)
+	users = paginate(users, page)
+	for i := range users {
+		users[i].Campaigns = r.userCampaigns(users[i].ID)
+	}
+	return users, totalCount, nil
+}
+
+func (r *userRepository) Get(ctx context.Context, id string) (models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users.get(parseID(id))
+	if !ok {
+		return models.User{}, gorm.ErrRecordNotFound
+	}
+	user.Campaigns = r.userCampaigns(user.ID)
+	return user, nil
+}
+
+// userCampaigns returns the campaigns userID is a member of.
+func (s *store) userCampaigns(userID uuid.UUID) []models.Campaign {
+	return s.campaigns.filter(func(campaign models.Campaign) bool {
+		return s.campaignUsers[campaign.ID][userID]
+	})
+}
+
+func (r *userRepository) Find(ctx context.Context, ids []string) ([]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.users.filter(func(user models.User) bool {
+		return slices.Contains(ids, user.ID.String())
+	}), nil
+}
+
+func (r *userRepository) ByEmail(ctx context.Context, email string) (models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users.all() {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return models.User{}, gorm.ErrRecordNotFound
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&user.ID, &user.Model)
+	stored := *user
+	stored.Campaigns = nil
+	r.users.put(user.ID, stored)
+	return nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	return r.Create(ctx, user)
+}
+
+func (r *userRepository) Delete(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users.delete(user.ID)
+	for _, members := range r.campaignUsers {
+		delete(members, user.ID)
+	}
+	return nil
+}
+
+func (r *userRepository) SetManager(ctx context.Context, id uuid.UUID, managerID *uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user, ok := r.users.get(id); ok {
+		user.ManagerID = managerID
+		r.users.put(id, user)
+	}
+	return nil
+}
+
+func (r *userRepository) SetTeam(ctx context.Context, id uuid.UUID, teamID *uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user, ok := r.users.get(id); ok {
+		user.TeamID = teamID
+		r.users.put(id, user)
+	}
+	return nil
+}
+
+func (r *userRepository) Reports(ctx context.Context, userID string, directOnly bool) ([]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	managers := map[uuid.UUID]bool{parseID(userID): true}
+	reports := make(map[uuid.UUID]bool)
+	for depth := 0; depth < maxReportingDepth && len(managers) > 0; depth++ {
+		next := make(map[uuid.UUID]bool)
+		for _, user := range r.users.all() {
+			if user.ManagerID != nil && managers[*user.ManagerID] && !reports[user.ID] {
+				reports[user.ID] = true
+				next[user.ID] = true
+			}
+		}
+		if directOnly {
+			break
+		}
+		managers = next
+	}
+	users := r.users.filter(func(user models.User) bool { return reports[user.ID] })
+	sortBy(users, repository.Sort{Field: "name"}, userSortKeys)
+	return users, nil
+}
+
+func (r *userRepository) TeamMembers(ctx context.Context, teamID string) ([]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	members := r.users.filter(func(user models.User) bool {
+		return user.TeamID != nil && user.TeamID.String() == teamID
+	})
+	sortBy(members, repository.Sort{Field: "name"}, userSortKeys)
+	return members, nil
+}
+
+func (r *userRepository) TeamUserIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.teamUserIDs(parseID(userID)), nil
+}
+
+// teamUserIDs returns the ids of the users that make up userID's team, like
+// auth.TeamUserIDs: the user, their direct reports and everyone in their team.
+func (s *store) teamUserIDs(userID uuid.UUID) []uuid.UUID {
+	me, _ := s.users.get(userID)
+	var ids []uuid.UUID
+	for _, user := range s.users.all() {
+		if user.ID == userID ||
+			user.ManagerID != nil && *user.ManagerID == userID ||
+			me.TeamID != nil && user.TeamID != nil && *user.TeamID == *me.TeamID {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,156 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// vendorSortKeys are the keys List sorts by.
+var vendorSortKeys = map[string]func(models.Vendor) any{
+	"createdAt":   func(vendor models.Vendor) any { return vendor.CreatedAt },
+	"updatedAt":   func(vendor models.Vendor) any { return vendor.UpdatedAt },
+	"companyName": func(vendor models.Vendor) any { return vendor.CompanyName },
+	"status":      func(vendor models.Vendor) any { return string(vendor.Status) },
+}
+
+func vendorKey(vendor models.Vendor) (time.Time, uuid.UUID) {
+	return vendor.CreatedAt, vendor.ID
+}
+
+type vendorRepository struct {
+	*store
+}
+
+// filterVendors reports which vendors match filter.
+func (s *store) filterVendors(filter repository.VendorFilter) func(models.Vendor) bool {
+	return func(vendor models.Vendor) bool {
+		notes := ""
+		if vendor.Notes != nil {
+			notes = *vendor.Notes
+		}
+		return (filter.CompanyName == "" || contains(vendor.CompanyName, filter.CompanyName)) &&
+			(filter.Status == "" || string(vendor.Status) == filter.Status) &&
+			(filter.PaymentTerms == "" || string(vendor.PaymentTerms) == filter.PaymentTerms) &&
+			(filter.Search == "" || contains(vendor.CompanyName, filter.Search) || contains(notes, filter.Search)) &&
+			(len(filter.SkillIDs) == 0 || slices.ContainsFunc(s.vendorSkills[vendor.ID], func(id uuid.UUID) bool {
+				return slices.Contains(filter.SkillIDs, id.String())
+			}))
+	}
+}
+
+// preloadVendor loads the skills of vendor, the contacts and ratings being kept with it.
+func (s *store) preloadVendor(vendor *models.Vendor) {
+	vendor.Skills = nil
+	for _, id := range s.vendorSkills[vendor.ID] {
+		if skill, ok := s.skills.get(id); ok {
+			vendor.Skills = append(vendor.Skills, skill)
+		}
+	}
+}
+
+func (r *vendorRepository) List(ctx context.Context, filter repository.VendorFilter, sort repository.Sort, page repository.Page) ([]models.Vendor, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vendors := r.vendors.filter(r.filterVendors(filter))
+	totalCount := int64(len(vendors))
+	sortBy(vendors, orDefault(sort, vendorSortKeys, repository.Sort{Field: "createdAt", Desc: true}), vendorSortKeys)
+	vendors = paginate(vendors, page)
+	for i := range vendors {
+		r.preloadVendor(&vendors[i])
+	}
+	return vendors, totalCount, nil
+}
+
+func (r *vendorRepository) Page(ctx context.Context, filter repository.VendorFilter, cursor repository.Cursor) ([]models.Vendor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vendors := keyset(r.vendors.filter(r.filterVendors(filter)), cursor, vendorKey)
+	for i := range vendors {
+		r.preloadVendor(&vendors[i])
+	}
+	return vendors, nil
+}
+
+func (r *vendorRepository) Get(ctx context.Context, id uuid.UUID) (models.Vendor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vendor, ok := r.vendors.get(id)
+	if !ok {
+		return models.Vendor{}, gorm.ErrRecordNotFound
+	}
+	r.preloadVendor(&vendor)
+	return vendor, nil
+}
+
+func (r *vendorRepository) Create(ctx context.Context, vendor *models.Vendor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&vendor.ID, &vendor.Model)
+	for i := range vendor.ContactList {
+		vendor.ContactList[i].VendorID = vendor.ID
+		r.stamp(&vendor.ContactList[i].ID, &vendor.ContactList[i].Model)
+	}
+	for i := range vendor.PerformanceRatings {
+		vendor.PerformanceRatings[i].VendorID = vendor.ID
+		r.stamp(&vendor.PerformanceRatings[i].ID, &vendor.PerformanceRatings[i].Model)
+	}
+	r.setVendorSkills(vendor.ID, vendor.Skills)
+	r.putVendor(*vendor)
+	return nil
+}
+
+// setVendorSkills makes skills the skills of vendorID, inserting those that
+// are new.
+func (s *store) setVendorSkills(vendorID uuid.UUID, skills []models.Skill) {
+	ids := make([]uuid.UUID, 0, len(skills))
+	for _, skill := range skills {
+		if _, ok := s.skills.get(skill.ID); !ok {
+			s.stamp(&skill.ID, &skill.Model)
+			s.skills.put(skill.ID, skill)
+		}
+		ids = append(ids, skill.ID)
+	}
+	s.vendorSkills[vendorID] = ids
+}
+
+// putVendor stores vendor with its contacts and ratings, but not its skills
+// and resources.
+func (s *store) putVendor(vendor models.Vendor) {
+	vendor.Skills, vendor.Resources = nil, nil
+	s.vendors.put(vendor.ID, vendor)
+}
+
+func (r *vendorRepository) Update(ctx context.Context, vendor *models.Vendor, skills []models.Skill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.vendors.get(vendor.ID)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if skills != nil {
+		r.setVendorSkills(vendor.ID, skills)
+	}
+	// The associations are not saved with the vendor
+	updated := *vendor
+	updated.ContactList, updated.PerformanceRatings = stored.ContactList, stored.PerformanceRatings
+	r.stamp(&updated.ID, &updated.Model)
+	r.putVendor(updated)
+	return nil
+}
+
+func (r *vendorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.vendors.get(id); !ok {
+		return gorm.ErrRecordNotFound
+	}
+	r.vendors.delete(id)
+	delete(r.vendorSkills, id)
+	return nil
+}
@@ -0,0 +1,116 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	*store
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hooks := r.webhooks.all()
+	newestFirst(hooks, func(webhook models.Webhook) (time.Time, uuid.UUID) { return webhook.CreatedAt, webhook.ID })
+	for i := range hooks {
+		hooks[i].CreatedByUser, _ = r.users.get(hooks[i].CreatedBy)
+	}
+	return hooks, nil
+}
+
+func (r *webhookRepository) Get(ctx context.Context, id string) (models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook, ok := r.webhooks.get(parseID(id))
+	if !ok {
+		return models.Webhook{}, gorm.ErrRecordNotFound
+	}
+	webhook.CreatedByUser, _ = r.users.get(webhook.CreatedBy)
+	return webhook, nil
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(&webhook.ID, &webhook.Model)
+	stored := *webhook
+	stored.CreatedByUser = models.User{}
+	r.webhooks.put(webhook.ID, stored)
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.webhooks.get(webhook.ID)
+	if !ok {
+		return nil
+	}
+	stored.URL = webhook.URL
+	stored.Events = webhook.Events
+	stored.Active = webhook.Active
+	stored.Description = webhook.Description
+	stored.UpdatedAt = r.now()
+	r.webhooks.put(stored.ID, stored)
+	return nil
+}
+
+func (r *webhookRepository) SetSecret(ctx context.Context, webhook *models.Webhook, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.webhooks.get(webhook.ID)
+	if !ok {
+		return nil
+	}
+	stored.Secret = secret
+	stored.UpdatedAt = r.now()
+	r.webhooks.put(stored.ID, stored)
+	webhook.Secret = secret
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks.delete(webhook.ID)
+	return nil
+}
+
+func (r *webhookRepository) Deliveries(ctx context.Context, filter repository.WebhookDeliveryFilter, page repository.Page) ([]models.WebhookDelivery, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := r.deliveries.filter(func(delivery models.WebhookDelivery) bool {
+		return (filter.WebhookID == "" || delivery.WebhookID == parseID(filter.WebhookID)) &&
+			(filter.Event == "" || delivery.Event == filter.Event) &&
+			(filter.Status == "" || delivery.Status == filter.Status)
+	})
+	totalCount := int64(len(deliveries))
+	newestFirst(deliveries, func(delivery models.WebhookDelivery) (time.Time, uuid.UUID) { return delivery.CreatedAt, delivery.ID })
+	return paginate(deliveries, page), totalCount, nil
+}
+
+func (r *webhookRepository) Redeliver(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous, ok := r.deliveries.get(parseID(id))
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	delivery := models.WebhookDelivery{
+		WebhookID:     previous.WebhookID,
+		Event:         previous.Event,
+		Payload:       previous.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	r.stamp(&delivery.ID, &delivery.Model)
+	r.deliveries.put(delivery.ID, delivery)
+	return &delivery, nil
+}
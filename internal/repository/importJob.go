@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository reads the import jobs of internal/importer and commits
+// their dry runs. Jobs are only visible to the user who uploaded the file.
+type ImportJobRepository interface {
+	// List returns the 50 newest jobs of userID with their creator.
+	List(ctx context.Context, userID string) ([]models.ImportJob, error)
+	// Get returns the job with id of userID with its creator.
+	Get(ctx context.Context, id, userID string) (models.ImportJob, error)
+	// Rows returns the rows of the job with id by row number, only those with
+	// status unless it is empty.
+	Rows(ctx context.Context, id string, status string) ([]models.ImportJobRow, error)
+	// Commit runs the previewed dry run with id for real, creating the records
+	// through services, see importer.Commit.
+	Commit(ctx context.Context, services importer.Services, id uuid.UUID) (*models.ImportJob, error)
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository returns an ImportJobRepository on db.
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) List(ctx context.Context, userID string) ([]models.ImportJob, error) {
+	var jobs []models.ImportJob
+	err := r.db.WithContext(ctx).Preload("CreatedByUser").
+		Where("created_by = ?", userID).
+		Order("created_at DESC").
+		Limit(50).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *importJobRepository) Get(ctx context.Context, id, userID string) (models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.WithContext(ctx).Preload("CreatedByUser").First(&job, "id = ? AND created_by = ?", id, userID).Error
+	return job, err
+}
+
+func (r *importJobRepository) Rows(ctx context.Context, id string, status string) ([]models.ImportJobRow, error) {
+	query := r.db.WithContext(ctx).Where("job_id = ?", id)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var rows []models.ImportJobRow
+	err := query.Order("row ASC").Find(&rows).Error
+	return rows, err
+}
+
+func (r *importJobRepository) Commit(ctx context.Context, services importer.Services, id uuid.UUID) (*models.ImportJob, error) {
+	return importer.Commit(ctx, r.db, services, id)
+}
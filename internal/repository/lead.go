@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/assignment"
+	"github.com/Zenithive/it-crm-backend/internal/dedupe"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/internal/trash"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeadFilter selects leads. Empty fields match every lead.
+type LeadFilter struct {
+	Name   string // part of the first name
+	Email  string // part of the email
+	TeamID string // assigned to a member of the team
+}
+
+// LeadChange is what Update records along with the fields of a lead.
+type LeadChange struct {
+	// ChangedBy is the user making the change, if known.
+	ChangedBy *uuid.UUID
+	// Reassigned, when set, is logged as the lead's new owner, assigned by hand.
+	Reassigned *models.User
+	// OldStage, when set, is recorded in the stage history as the stage the lead left.
+	OldStage *models.LeadStage
+	// Won creates a deal for the lead unless it has one.
+	Won bool
+}
+
+// LeadRepository stores leads. Reads only see the leads the caller in ctx may
+// act on (see auth.LeadScope). Writes rescore the leads they touch.
+type LeadRepository interface {
+	// List returns a page of the leads matching filter and how many match in all.
+	// It sorts by FIRST_NAME, LAST_NAME, EMAIL, CREATED_AT or SCORE.
+	List(ctx context.Context, filter LeadFilter, sort Sort, page Page) ([]models.Lead, int64, error)
+	// Page returns the leads matching filter on the connection page cursor selects.
+	Page(ctx context.Context, filter LeadFilter, cursor Cursor) ([]models.Lead, error)
+	// Get returns the lead with id if the caller may perform action on it, with
+	// its creator, assignee, organization, campaign and activities.
+	Get(ctx context.Context, action string, id string) (models.Lead, error)
+	// Find returns those of the leads with ids the caller may perform action on.
+	Find(ctx context.Context, action string, ids []string) ([]models.Lead, error)
+	// ByCampaign returns the leads of a campaign the caller may read, newest first.
+	ByCampaign(ctx context.Context, campaignID string) ([]models.Lead, error)
+	// Create assigns lead to assignee, or by the assignment rules if nil, and
+	// inserts it together with activity, if any. It returns the lead's owner.
+	Create(ctx context.Context, lead *models.Lead, assignee *models.User, activity *models.Activity) (models.User, error)
+	// Update saves lead and records change.
+	Update(ctx context.Context, lead *models.Lead, change LeadChange) (*models.Deal, error)
+	// Delete moves the lead with id and its activities to the trash.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Merge moves everything attached to duplicates onto survivor and deletes them.
+	Merge(ctx context.Context, survivor *models.Lead, duplicates []models.Lead) error
+	// StageHistory returns the stage changes of leadIDs, oldest first, with the
+	// users who made them.
+	StageHistory(ctx context.Context, leadIDs []uuid.UUID) ([]models.LeadStageHistory, error)
+	// Duplicates returns the leads the caller may read that are likely the same
+	// person as lead, see dedupe.FindLeadDuplicates.
+	Duplicates(ctx context.Context, lead dedupe.Lead) ([]dedupe.LeadMatch, error)
+}
+
+// leadSortColumns are the columns List sorts by.
+var leadSortColumns = map[string]string{
+	"FIRST_NAME": "leads.first_name",
+	"LAST_NAME":  "leads.last_name",
+	"EMAIL":      "leads.email",
+	"CREATED_AT": "leads.created_at",
+	"SCORE":      "leads.score",
+}
+
+type leadRepository struct {
+	db *gorm.DB
+}
+
+// NewLeadRepository returns a LeadRepository on db.
+func NewLeadRepository(db *gorm.DB) LeadRepository {
+	return &leadRepository{db: db}
+}
+
+// filterLeads applies filter to a query on leads.
+func filterLeads(filter LeadFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if filter.Name != "" {
+			query = query.Where("leads.first_name ILIKE ?", contains(filter.Name))
+		}
+		if filter.Email != "" {
+			query = query.Where("leads.email ILIKE ?", contains(filter.Email))
+		}
+		if filter.TeamID != "" {
+			query = query.Where("leads.lead_assigned_to IN (?)", teamMembers(query, filter.TeamID))
+		}
+		return query
+	}
+}
+
+// teamMembers selects the ids of the users in teamID, for "column IN (?)" filters
+// of a query on db.
+func teamMembers(db *gorm.DB, teamID string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.User{}).Select("id").Where("team_id = ?", teamID)
+}
+
+func (r *leadRepository) List(ctx context.Context, filter LeadFilter, sort Sort, page Page) ([]models.Lead, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Lead{}).Scopes(auth.LeadScope(ctx, auth.LeadActionRead), filterLeads(filter))
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// The associations are preloaded only now that the leads have been counted.
+	// Creator, assignee and organization are batched by their field resolvers.
+	var leads []models.Lead
+	err := query.
+		Scopes(order(sort, leadSortColumns, ""), paginate(page)).
+		Preload("Campaign").
+		Preload("Activities").
+		Find(&leads).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return leads, totalCount, nil
+}
+
+func (r *leadRepository) Page(ctx context.Context, filter LeadFilter, cursor Cursor) ([]models.Lead, error) {
+	var leads []models.Lead
+	err := r.db.WithContext(ctx).
+		Scopes(auth.LeadScope(ctx, auth.LeadActionRead), filterLeads(filter), keyset("leads", cursor)).
+		Preload("Campaign").
+		Preload("Activities").
+		Find(&leads).Error
+	return leads, err
+}
+
+func (r *leadRepository) Get(ctx context.Context, action string, id string) (models.Lead, error) {
+	var lead models.Lead
+	err := r.db.WithContext(ctx).
+		Scopes(auth.LeadScope(ctx, action)).
+		Preload("Creator").
+		Preload("Assignee").
+		Preload("Organization").
+		Preload("Campaign").
+		Preload("Activities").
+		First(&lead, "leads.id = ?", id).Error
+	return lead, err
+}
+
+func (r *leadRepository) Find(ctx context.Context, action string, ids []string) ([]models.Lead, error) {
+	var leads []models.Lead
+	err := r.db.WithContext(ctx).Scopes(auth.LeadScope(ctx, action)).Where("leads.id IN ?", ids).Find(&leads).Error
+	return leads, err
+}
+
+func (r *leadRepository) ByCampaign(ctx context.Context, campaignID string) ([]models.Lead, error) {
+	var leads []models.Lead
+	err := r.db.WithContext(ctx).
+		Scopes(auth.LeadScope(ctx, auth.LeadActionRead)).
+		Preload("Campaign").
+		Preload("Activities").
+		Where("leads.campaign_id = ?", campaignID).
+		Order("leads.created_at DESC").
+		Find(&leads).Error
+	return leads, err
+}
+
+func (r *leadRepository) Create(ctx context.Context, lead *models.Lead, assignee *models.User, activity *models.Activity) (models.User, error) {
+	// The lead, its assignment and the activity are saved together
+	var decision *assignment.Decision
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if decision, err = assignment.AssignLead(tx, lead, assignee); err != nil {
+			return err
+		}
+		if err := tx.Create(lead).Error; err != nil {
+			return err
+		}
+		if err := assignment.LogDecision(tx, lead.ID, decision, &lead.LeadCreatedBy); err != nil {
+			return err
+		}
+		if activity != nil {
+			activity.LeadID = lead.ID
+			return tx.Create(activity).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := scoring.RescoreLead(r.db, lead); err != nil {
+		log.Printf("Error scoring lead %s: %v", lead.ID, err)
+	}
+	return decision.User, nil
+}
+
+func (r *leadRepository) Update(ctx context.Context, lead *models.Lead, change LeadChange) (*models.Deal, error) {
+	var deal *models.Deal
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(lead).Error; err != nil {
+			return err
+		}
+		if change.Reassigned != nil {
+			decision := assignment.Manual(*change.Reassigned)
+			decision.Reason = "reassigned by hand"
+			if err := assignment.LogDecision(tx, lead.ID, decision, change.ChangedBy); err != nil {
+				return err
+			}
+		}
+		if change.OldStage != nil {
+			if err := tx.Create(&models.LeadStageHistory{
+				LeadID:    lead.ID,
+				OldStage:  *change.OldStage,
+				NewStage:  lead.LeadStage,
+				ChangedAt: time.Now(),
+				ChangedBy: change.ChangedBy,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if change.Won {
+			var existing models.Deal
+			err := tx.Where("lead_id = ?", lead.ID).First(&existing).Error
+			if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			deal = &models.Deal{
+				LeadID:        lead.ID,
+				DealName:      lead.FirstName + " " + lead.LastName,
+				DealAmount:    models.Money{Currency: models.BaseCurrency()}, // Set later by hand
+				DealStartDate: time.Now(),
+				DealEndDate:   time.Now().AddDate(0, 6, 0),
+				DealStatus:    "Active",
+			}
+			return tx.Create(deal).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := scoring.RescoreLead(r.db, lead); err != nil {
+		log.Printf("Error scoring lead %s: %v", lead.ID, err)
+	}
+	return deal, nil
+}
+
+func (r *leadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return trash.Delete(r.db.WithContext(ctx), "LEAD", id)
+}
+
+func (r *leadRepository) Merge(ctx context.Context, survivor *models.Lead, duplicates []models.Lead) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return dedupe.MergeLeads(tx, survivor, duplicates)
+	})
+	if err != nil {
+		return err
+	}
+	if err := scoring.Recalculate(r.db, survivor.ID); err != nil {
+		log.Printf("Error scoring lead %s: %v", survivor.ID, err)
+	}
+	return nil
+}
+
+func (r *leadRepository) StageHistory(ctx context.Context, leadIDs []uuid.UUID) ([]models.LeadStageHistory, error) {
+	var history []models.LeadStageHistory
+	err := r.db.WithContext(ctx).
+		Preload("ChangedByUser").
+		Where("lead_id IN ?", leadIDs).
+		Order("changed_at ASC").
+		Find(&history).Error
+	return history, err
+}
+
+func (r *leadRepository) Duplicates(ctx context.Context, lead dedupe.Lead) ([]dedupe.LeadMatch, error) {
+	return dedupe.FindLeadDuplicates(r.db.WithContext(ctx).
+		Scopes(auth.LeadScope(ctx, auth.LeadActionRead)).
+		Preload("Creator").
+		Preload("Assignee").
+		Preload("Organization").
+		Preload("Campaign"), lead)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/loaders"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// LoaderSource returns the source of the DataLoaders that batch the nested
+// fields of r's records.
+func (r *Repositories) LoaderSource() loaders.Source {
+	return loaders.Source{
+		Users: func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.User, error) {
+			users, err := r.Users.Find(ctx, idStrings(ids))
+			return byID(users, func(user models.User) uuid.UUID { return user.ID }), err
+		},
+		Organizations: func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.Organization, error) {
+			organizations, err := r.Organizations.Find(ctx, idStrings(ids))
+			return byID(organizations, func(organization models.Organization) uuid.UUID { return organization.ID }), err
+		},
+		CampaignUsers: r.Campaigns.Members,
+		VendorResources: func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ResourceProfile, error) {
+			profiles, err := r.ResourceProfiles.ByVendors(ctx, ids)
+			return groupBy(profiles, func(profile models.ResourceProfile) uuid.UUID { return profile.VendorID }), err
+		},
+		ResourceSkills: func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ResourceSkill, error) {
+			skills, err := r.ResourceProfiles.Skills(ctx, ids)
+			return groupBy(skills, func(skill models.ResourceSkill) uuid.UUID { return skill.ResourceProfileID }), err
+		},
+		StageHistory: func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.LeadStageHistory, error) {
+			history, err := r.Leads.StageHistory(ctx, ids)
+			return groupBy(history, func(entry models.LeadStageHistory) uuid.UUID { return entry.LeadID }), err
+		},
+	}
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
+
+// byID indexes records by their id.
+func byID[V any](records []V, id func(V) uuid.UUID) map[uuid.UUID]V {
+	result := make(map[uuid.UUID]V, len(records))
+	for _, record := range records {
+		result[id(record)] = record
+	}
+	return result
+}
+
+// groupBy groups records by the id of their parent, keeping their order.
+func groupBy[V any](records []V, parent func(V) uuid.UUID) map[uuid.UUID][]V {
+	result := make(map[uuid.UUID][]V)
+	for _, record := range records {
+		result[parent(record)] = append(result[parent(record)], record)
+	}
+	return result
+}
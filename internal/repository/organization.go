@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/Zenithive/it-crm-backend/internal/dedupe"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationFilter selects organizations. Empty fields match every organization.
+type OrganizationFilter struct {
+	Search       string // part of the organization name
+	Country      string // exact country
+	MinEmployees *int   // at least this many employees
+	MaxEmployees *int   // at most this many employees
+}
+
+// OrganizationRepository stores organizations. Writes rescore the leads of the
+// organizations they touch, since head count and revenue feed into lead scores.
+type OrganizationRepository interface {
+	// List returns a page of the organizations matching filter and how many match
+	// in all. It sorts by ORGANIZATION_NAME, COUNTRY, NO_OF_EMPLOYEES or
+	// ANNUAL_REVENUE, the latter compared in the base currency.
+	List(ctx context.Context, filter OrganizationFilter, sort Sort, page Page) ([]models.Organization, int64, error)
+	Get(ctx context.Context, id string) (models.Organization, error)
+	// Find returns those of the organizations with ids that exist.
+	Find(ctx context.Context, ids []string) ([]models.Organization, error)
+	Create(ctx context.Context, organization *models.Organization) error
+	Update(ctx context.Context, organization *models.Organization) error
+	Delete(ctx context.Context, organization *models.Organization) error
+	// Merge moves the leads of duplicates onto survivor and deletes them.
+	Merge(ctx context.Context, survivor *models.Organization, duplicates []models.Organization) error
+	// Duplicates returns the organizations that are likely the same as
+	// organization, see dedupe.FindOrganizationDuplicates.
+	Duplicates(ctx context.Context, organization dedupe.Organization) ([]dedupe.OrganizationMatch, error)
+}
+
+// organizationSortColumns are the columns List sorts by, but for ANNUAL_REVENUE.
+var organizationSortColumns = map[string]string{
+	"ORGANIZATION_NAME": "organizations.organization_name",
+	"COUNTRY":           "organizations.country",
+	"NO_OF_EMPLOYEES":   "organizations.no_of_employees",
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository returns an OrganizationRepository on db.
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) List(ctx context.Context, filter OrganizationFilter, sort Sort, page Page) ([]models.Organization, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Organization{})
+	if filter.Search != "" {
+		query = query.Where("organizations.organization_name ILIKE ?", contains(filter.Search))
+	}
+	if filter.Country != "" {
+		query = query.Where("organizations.country = ?", filter.Country)
+	}
+	if filter.MinEmployees != nil {
+		query = query.Where("organizations.no_of_employees::INTEGER >= ?", *filter.MinEmployees)
+	}
+	if filter.MaxEmployees != nil {
+		query = query.Where("organizations.no_of_employees::INTEGER <= ?", *filter.MaxEmployees)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if sort.Field == "ANNUAL_REVENUE" {
+		// Compare revenues in the base currency; currencies without a rate sort as-is
+		direction := " ASC"
+		if sort.Desc {
+			direction = " DESC"
+		}
+		query = query.
+			Joins("LEFT JOIN exchange_rates ON exchange_rates.currency = organizations.annual_revenue_currency AND exchange_rates.deleted_at IS NULL").
+			Order("organizations.annual_revenue_amount * COALESCE(exchange_rates.rate_to_base, 1)" + direction)
+	} else {
+		query = query.Scopes(order(sort, organizationSortColumns, ""))
+	}
+
+	var organizations []models.Organization
+	if err := query.Scopes(paginate(page)).Find(&organizations).Error; err != nil {
+		return nil, 0, err
+	}
+	return organizations, totalCount, nil
+}
+
+func (r *organizationRepository) Get(ctx context.Context, id string) (models.Organization, error) {
+	var organization models.Organization
+	err := r.db.WithContext(ctx).First(&organization, "id = ?", id).Error
+	return organization, err
+}
+
+func (r *organizationRepository) Find(ctx context.Context, ids []string) ([]models.Organization, error) {
+	var organizations []models.Organization
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&organizations).Error
+	return organizations, err
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *models.Organization) error {
+	return r.db.WithContext(ctx).Create(organization).Error
+}
+
+func (r *organizationRepository) Update(ctx context.Context, organization *models.Organization) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(organization).Error; err != nil {
+		return err
+	}
+	r.rescore(organization.ID)
+	return nil
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, organization *models.Organization) error {
+	if err := r.db.WithContext(ctx).Delete(organization).Error; err != nil {
+		return err
+	}
+	r.rescore(organization.ID)
+	return nil
+}
+
+func (r *organizationRepository) Merge(ctx context.Context, survivor *models.Organization, duplicates []models.Organization) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return dedupe.MergeOrganizations(tx, survivor, duplicates)
+	})
+	if err != nil {
+		return err
+	}
+	// The survivor gained leads and maybe head count or revenue
+	r.rescore(survivor.ID)
+	return nil
+}
+
+func (r *organizationRepository) Duplicates(ctx context.Context, organization dedupe.Organization) ([]dedupe.OrganizationMatch, error) {
+	return dedupe.FindOrganizationDuplicates(r.db.WithContext(ctx), organization)
+}
+
+// rescore recalculates the scores of the leads of an organization. Failures are
+// only logged: the scores catch up on the next change or rescore.
+func (r *organizationRepository) rescore(id uuid.UUID) {
+	if err := scoring.RecalculateOrganization(r.db, id); err != nil {
+		log.Printf("Error scoring leads of organization %s: %v", id, err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package repository reads and writes the records behind the services of
+// internal/service. Every entity has an interface, so services can run against
+// a fake, and a GORM implementation for Postgres.
+//
+// Repositories deal in models only. Filters, sorts and pages are the plain
+// types of this package; the services map the GraphQL inputs onto them.
+//
+// Lookups of a single record return gorm.ErrRecordNotFound when there is none.
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repositories are the repositories of every entity the services manage.
+type Repositories struct {
+	Users            UserRepository
+	Teams            TeamRepository
+	Organizations    OrganizationRepository
+	Campaigns        CampaignRepository
+	Leads            LeadRepository
+	Activities       ActivityRepository
+	Deals            DealRepository
+	Tasks            TaskRepository
+	ResourceProfiles ResourceProfileRepository
+	CaseStudies      CaseStudyRepository
+	Skills           SkillRepository
+	Vendors          VendorRepository
+	ExchangeRates    ExchangeRateRepository
+	AssignmentRules  AssignmentRuleRepository
+	ScoringRules     ScoringRuleRepository
+	ImportJobs       ImportJobRepository
+	ExportJobs       ExportJobRepository
+	Webhooks         WebhookRepository
+	AuditEvents      AuditEventRepository
+	Trash            TrashRepository
+}
+
+// New returns the GORM repositories on db.
+func New(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Users:            NewUserRepository(db),
+		Teams:            NewTeamRepository(db),
+		Organizations:    NewOrganizationRepository(db),
+		Campaigns:        NewCampaignRepository(db),
+		Leads:            NewLeadRepository(db),
+		Activities:       NewActivityRepository(db),
+		Deals:            NewDealRepository(db),
+		Tasks:            NewTaskRepository(db),
+		ResourceProfiles: NewResourceProfileRepository(db),
+		CaseStudies:      NewCaseStudyRepository(db),
+		Skills:           NewSkillRepository(db),
+		Vendors:          NewVendorRepository(db),
+		ExchangeRates:    NewExchangeRateRepository(db),
+		AssignmentRules:  NewAssignmentRuleRepository(db),
+		ScoringRules:     NewScoringRuleRepository(db),
+		ImportJobs:       NewImportJobRepository(db),
+		ExportJobs:       NewExportJobRepository(db),
+		Webhooks:         NewWebhookRepository(db),
+		AuditEvents:      NewAuditEventRepository(db),
+		Trash:            NewTrashRepository(db),
+	}
+}
+
+// Page selects Limit rows of a list starting at Offset. A zero Limit selects
+// every row.
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// Sort orders a list by Field, one of the names listed by the repository, in
+// descending order if Desc is set. An empty or unknown Field keeps the
+// repository's default order.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Cursor selects a page of a connection. Connections walk a table newest first
+// by (created_at, id); a page holds the rows after the row at (CreatedAt, ID),
+// or the first rows if ID is uuid.Nil. Repositories return up to Size+1 rows,
+// the extra one telling that there is a next page.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+	Size      int
+}
+
+// paginate applies page to a query.
+func paginate(page Page) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if page.Limit > 0 {
+			db = db.Offset(page.Offset).Limit(page.Limit)
+		}
+		return db
+	}
+}
+
+// order orders a query by the column of columns named by sort.Field, or by
+// fallback when there is none.
+func order(sort Sort, columns map[string]string, fallback string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		column, ok := columns[sort.Field]
+		if !ok {
+			if fallback == "" {
+				return db
+			}
+			return db.Order(fallback)
+		}
+		if sort.Desc {
+			return db.Order(column + " DESC")
+		}
+		return db.Order(column + " ASC")
+	}
+}
+
+// keyset selects the rows of table on the page cursor selects.
+func keyset(table string, cursor Cursor) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cursor.ID != uuid.Nil {
+			db = db.Where("("+table+".created_at, "+table+".id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+		}
+		return db.Order(table + ".created_at DESC").Order(table + ".id DESC").Limit(cursor.Size + 1)
+	}
+}
+
+// contains returns the ILIKE pattern matching values that contain s.
+func contains(s string) string {
+	return "%" + s + "%"
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/trash"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ResourceProfileFilter selects resource profiles. Empty fields match every profile.
+type ResourceProfileFilter struct {
+	Type               string   // exact type
+	FirstName          string   // part of the first name
+	LastName           string   // part of the last name
+	TotalExperienceMin *float64 // at least this many years
+	TotalExperienceMax *float64 // at most this many years
+	Status             string   // exact status
+	VendorID           string   // supplied by the vendor
+	SkillIDs           []string // has any of the skills
+	Search             string   // part of the name or of a skill's name
+}
+
+// ResourceProfileRepository stores resource profiles. Profiles it returns have
+// their vendor and past projects loaded, and Get also their skills.
+type ResourceProfileRepository interface {
+	// List returns a page of the profiles matching filter and how many match in
+	// all. It sorts by createdAt, updatedAt, firstName, lastName, totalExperience
+	// or status, newest first by default.
+	List(ctx context.Context, filter ResourceProfileFilter, sort Sort, page Page) ([]models.ResourceProfile, int64, error)
+	// Page returns the profiles matching filter on the connection page cursor selects.
+	Page(ctx context.Context, filter ResourceProfileFilter, cursor Cursor) ([]models.ResourceProfile, error)
+	Get(ctx context.Context, id string) (models.ResourceProfile, error)
+	// Create inserts profile along with its ResourceSkills.
+	Create(ctx context.Context, profile *models.ResourceProfile) error
+	// Update saves profile, and replaces its skills with skills unless they are nil.
+	Update(ctx context.Context, profile *models.ResourceProfile, skills []models.ResourceSkill) error
+	// Delete moves the profile with id and its skills and projects to the trash.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ByVendors returns the profiles of vendorIDs, oldest first, with their past
+	// projects but not their vendor.
+	ByVendors(ctx context.Context, vendorIDs []uuid.UUID) ([]models.ResourceProfile, error)
+	// Skills returns the skills of profileIDs, with the skills they refer to.
+	Skills(ctx context.Context, profileIDs []uuid.UUID) ([]models.ResourceSkill, error)
+}
+
+// resourceProfileSortColumns are the columns List sorts by.
+var resourceProfileSortColumns = map[string]string{
+	"createdAt":       "resource_profiles.created_at",
+	"updatedAt":       "resource_profiles.updated_at",
+	"firstName":       "resource_profiles.first_name",
+	"lastName":        "resource_profiles.last_name",
+	"totalExperience": "resource_profiles.total_experience",
+	"status":          "resource_profiles.status",
+}
+
+type resourceProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewResourceProfileRepository returns a ResourceProfileRepository on db.
+func NewResourceProfileRepository(db *gorm.DB) ResourceProfileRepository {
+	return &resourceProfileRepository{db: db}
+}
+
+// filterResourceProfiles applies filter to a query on resource_profiles.
+func filterResourceProfiles(filter ResourceProfileFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if filter.Type != "" {
+			query = query.Where("resource_profiles.type = ?", filter.Type)
+		}
+		if filter.FirstName != "" {
+			query = query.Where("resource_profiles.first_name ILIKE ?", contains(filter.FirstName))
+		}
+		if filter.LastName != "" {
+			query = query.Where("resource_profiles.last_name ILIKE ?", contains(filter.LastName))
+		}
+		if filter.TotalExperienceMin != nil {
+			query = query.Where("resource_profiles.total_experience >= ?", *filter.TotalExperienceMin)
+		}
+		if filter.TotalExperienceMax != nil {
+			query = query.Where("resource_profiles.total_experience <= ?", *filter.TotalExperienceMax)
+		}
+		if filter.Status != "" {
+			query = query.Where("resource_profiles.status = ?", filter.Status)
+		}
+		if filter.VendorID != "" {
+			query = query.Where("resource_profiles.vendor_id = ?", filter.VendorID)
+		}
+		if len(filter.SkillIDs) > 0 {
+			// A subquery rather than a join, so profiles with several of the skills are listed once
+			query = query.Where("resource_profiles.id IN (SELECT resource_profile_id FROM resource_skills WHERE skill_id IN ?)", filter.SkillIDs)
+		}
+		if filter.Search != "" {
+			search := contains(filter.Search)
+			query = query.Where(`(resource_profiles.first_name ILIKE ? OR resource_profiles.last_name ILIKE ? OR
+				EXISTS (SELECT 1 FROM resource_skills rs JOIN skills s ON rs.skill_id = s.id
+				WHERE rs.resource_profile_id = resource_profiles.id AND s.name ILIKE ?))`,
+				search, search, search)
+		}
+		return query
+	}
+}
+
+func (r *resourceProfileRepository) List(ctx context.Context, filter ResourceProfileFilter, sort Sort, page Page) ([]models.ResourceProfile, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ResourceProfile{}).Scopes(filterResourceProfiles(filter))
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Skills are batched by the ResourceProfile.resourceSkills resolver
+	var profiles []models.ResourceProfile
+	err := query.
+		Scopes(order(sort, resourceProfileSortColumns, "resource_profiles.created_at DESC"), paginate(page)).
+		Preload("Vendor").
+		Preload("PastProjects").
+		Find(&profiles).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return profiles, totalCount, nil
+}
+
+func (r *resourceProfileRepository) Page(ctx context.Context, filter ResourceProfileFilter, cursor Cursor) ([]models.ResourceProfile, error) {
+	var profiles []models.ResourceProfile
+	err := r.db.WithContext(ctx).
+		Scopes(filterResourceProfiles(filter), keyset("resource_profiles", cursor)).
+		Preload("Vendor").
+		Preload("PastProjects").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+func (r *resourceProfileRepository) Get(ctx context.Context, id string) (models.ResourceProfile, error) {
+	var profile models.ResourceProfile
+	err := r.db.WithContext(ctx).
+		Preload("ResourceSkills.Skill").
+		Preload("Vendor").
+		Preload("PastProjects").
+		First(&profile, "id = ?", id).Error
+	return profile, err
+}
+
+func (r *resourceProfileRepository) Create(ctx context.Context, profile *models.ResourceProfile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		skills := profile.ResourceSkills
+		if err := tx.Omit(clause.Associations).Create(profile).Error; err != nil {
+			return err
+		}
+		for i := range skills {
+			skills[i].ResourceProfileID = profile.ID
+		}
+		if len(skills) > 0 {
+			return tx.Omit(clause.Associations).Create(&skills).Error
+		}
+		return nil
+	})
+}
+
+func (r *resourceProfileRepository) Update(ctx context.Context, profile *models.ResourceProfile, skills []models.ResourceSkill) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if skills != nil {
+			if err := tx.Where("resource_profile_id = ?", profile.ID).Delete(&models.ResourceSkill{}).Error; err != nil {
+				return err
+			}
+			if len(skills) > 0 {
+				if err := tx.Omit(clause.Associations).Create(&skills).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Omit(clause.Associations).Save(profile).Error
+	})
+}
+
+func (r *resourceProfileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return trash.Delete(r.db.WithContext(ctx), "RESOURCE_PROFILE", id)
+}
+
+func (r *resourceProfileRepository) ByVendors(ctx context.Context, vendorIDs []uuid.UUID) ([]models.ResourceProfile, error) {
+	var profiles []models.ResourceProfile
+	err := r.db.WithContext(ctx).
+		Preload("PastProjects").
+		Where("vendor_id IN ?", vendorIDs).
+		Order("created_at").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+func (r *resourceProfileRepository) Skills(ctx context.Context, profileIDs []uuid.UUID) ([]models.ResourceSkill, error) {
+	var skills []models.ResourceSkill
+	err := r.db.WithContext(ctx).
+		Joins("Skill").
+		Where("resource_skills.resource_profile_id IN ?", profileIDs).
+		Find(&skills).Error
+	return skills, err
+}
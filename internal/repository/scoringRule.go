@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// ScoringRuleRepository stores the lead scoring model, see internal/scoring.
+type ScoringRuleRepository interface {
+	// List returns the rules by attribute.
+	List(ctx context.Context) ([]models.ScoringRule, error)
+	// Replace swaps every rule for rules, rescores every lead and returns the
+	// stored rules.
+	Replace(ctx context.Context, rules []models.ScoringRule) ([]models.ScoringRule, error)
+}
+
+type scoringRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewScoringRuleRepository returns a ScoringRuleRepository on db.
+func NewScoringRuleRepository(db *gorm.DB) ScoringRuleRepository {
+	return &scoringRuleRepository{db: db}
+}
+
+func (r *scoringRuleRepository) List(ctx context.Context) ([]models.ScoringRule, error) {
+	return scoring.LoadRules(r.db.WithContext(ctx))
+}
+
+func (r *scoringRuleRepository) Replace(ctx context.Context, rules []models.ScoringRule) ([]models.ScoringRule, error) {
+	return scoring.ReplaceRules(r.db.WithContext(ctx), rules)
+}
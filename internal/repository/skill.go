@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// SkillFilter selects skills. Empty fields match every skill.
+type SkillFilter struct {
+	Name      string // part of the name
+	SkillType string // exact type
+}
+
+// SkillRepository stores skills.
+type SkillRepository interface {
+	// List returns a page of the skills matching filter and how many match in
+	// all. It sorts by name, skillType or createdAt, by name by default.
+	List(ctx context.Context, filter SkillFilter, sort Sort, page Page) ([]models.Skill, int64, error)
+	Get(ctx context.Context, id string) (models.Skill, error)
+	// ByName returns the skill named name.
+	ByName(ctx context.Context, name string) (models.Skill, error)
+	Create(ctx context.Context, skill *models.Skill) error
+	Update(ctx context.Context, skill *models.Skill) error
+	Delete(ctx context.Context, skill *models.Skill) error
+}
+
+// skillSortColumns are the columns List sorts by.
+var skillSortColumns = map[string]string{
+	"name":      "skills.name",
+	"skillType": "skills.skill_type",
+	"createdAt": "skills.created_at",
+}
+
+type skillRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillRepository returns a SkillRepository on db.
+func NewSkillRepository(db *gorm.DB) SkillRepository {
+	return &skillRepository{db: db}
+}
+
+func (r *skillRepository) List(ctx context.Context, filter SkillFilter, sort Sort, page Page) ([]models.Skill, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Skill{})
+	if filter.Name != "" {
+		query = query.Where("skills.name ILIKE ?", contains(filter.Name))
+	}
+	if filter.SkillType != "" {
+		query = query.Where("skills.skill_type = ?", filter.SkillType)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var skills []models.Skill
+	err := query.Scopes(order(sort, skillSortColumns, "skills.name ASC"), paginate(page)).Find(&skills).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return skills, totalCount, nil
+}
+
+func (r *skillRepository) Get(ctx context.Context, id string) (models.Skill, error) {
+	var skill models.Skill
+	err := r.db.WithContext(ctx).First(&skill, "id = ?", id).Error
+	return skill, err
+}
+
+func (r *skillRepository) ByName(ctx context.Context, name string) (models.Skill, error) {
+	var skill models.Skill
+	err := r.db.WithContext(ctx).First(&skill, "name = ?", name).Error
+	return skill, err
+}
+
+func (r *skillRepository) Create(ctx context.Context, skill *models.Skill) error {
+	return r.db.WithContext(ctx).Create(skill).Error
+}
+
+func (r *skillRepository) Update(ctx context.Context, skill *models.Skill) error {
+	return r.db.WithContext(ctx).Save(skill).Error
+}
+
+func (r *skillRepository) Delete(ctx context.Context, skill *models.Skill) error {
+	return r.db.WithContext(ctx).Delete(skill).Error
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// TaskFilter selects tasks. Empty fields match every task.
+type TaskFilter struct {
+	UserID   string // assigned to the user
+	TeamID   string // assigned to a member of the team
+	Status   string // exact status
+	Priority string // exact priority
+	DueDate  string // due on the day, "YYYY-MM-DD"
+}
+
+// TaskRepository stores tasks.
+type TaskRepository interface {
+	// List returns a page of the tasks matching filter and how many match in
+	// all. It sorts by TITLE, STATUS, PRIORITY or DUE_DATE, newest first by default.
+	List(ctx context.Context, filter TaskFilter, sort Sort, page Page) ([]models.Task, int64, error)
+	// Page returns the tasks matching filter, with their users, on the connection
+	// page cursor selects.
+	Page(ctx context.Context, filter TaskFilter, cursor Cursor) ([]models.Task, error)
+	// Get returns a task with its user.
+	Get(ctx context.Context, id string) (models.Task, error)
+	Create(ctx context.Context, task *models.Task) error
+	Update(ctx context.Context, task *models.Task) error
+	Delete(ctx context.Context, task *models.Task) error
+}
+
+// taskSortColumns are the columns List sorts by.
+var taskSortColumns = map[string]string{
+	"TITLE":    "tasks.title",
+	"STATUS":   "tasks.status",
+	"PRIORITY": "tasks.priority",
+	"DUE_DATE": "tasks.due_date",
+}
+
+type taskRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskRepository returns a TaskRepository on db.
+func NewTaskRepository(db *gorm.DB) TaskRepository {
+	return &taskRepository{db: db}
+}
+
+// filterTasks applies filter to a query on tasks.
+func filterTasks(filter TaskFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if filter.UserID != "" {
+			query = query.Where("tasks.user_id = ?", filter.UserID)
+		}
+		if filter.TeamID != "" {
+			query = query.Where("tasks.user_id IN (?)", teamMembers(query, filter.TeamID))
+		}
+		if filter.Status != "" {
+			query = query.Where("tasks.status = ?", filter.Status)
+		}
+		if filter.Priority != "" {
+			query = query.Where("tasks.priority = ?", filter.Priority)
+		}
+		if filter.DueDate != "" {
+			query = query.Where("DATE(tasks.due_date) = ?", filter.DueDate)
+		}
+		return query
+	}
+}
+
+func (r *taskRepository) List(ctx context.Context, filter TaskFilter, sort Sort, page Page) ([]models.Task, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Task{}).Scopes(filterTasks(filter))
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var tasks []models.Task
+	err := query.Scopes(order(sort, taskSortColumns, "tasks.created_at DESC"), paginate(page)).Find(&tasks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return tasks, totalCount, nil
+}
+
+func (r *taskRepository) Page(ctx context.Context, filter TaskFilter, cursor Cursor) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.WithContext(ctx).
+		Scopes(filterTasks(filter), keyset("tasks", cursor)).
+		Preload("User").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) Get(ctx context.Context, id string) (models.Task, error) {
+	var task models.Task
+	err := r.db.WithContext(ctx).Preload("User").First(&task, "id = ?", id).Error
+	return task, err
+}
+
+func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
+	return r.db.WithContext(ctx).Omit("User").Create(task).Error
+}
+
+func (r *taskRepository) Update(ctx context.Context, task *models.Task) error {
+	return r.db.WithContext(ctx).Omit("User").Save(task).Error
+}
+
+func (r *taskRepository) Delete(ctx context.Context, task *models.Task) error {
+	return r.db.WithContext(ctx).Delete(task).Error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// TeamRepository stores teams.
+type TeamRepository interface {
+	// List returns every team by name.
+	List(ctx context.Context) ([]models.Team, error)
+	Get(ctx context.Context, id string) (models.Team, error)
+	Create(ctx context.Context, team *models.Team) error
+	Update(ctx context.Context, team *models.Team) error
+	// Delete deletes team. Its members stay, without a team.
+	Delete(ctx context.Context, team *models.Team) error
+}
+
+type teamRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamRepository returns a TeamRepository on db.
+func NewTeamRepository(db *gorm.DB) TeamRepository {
+	return &teamRepository{db: db}
+}
+
+func (r *teamRepository) List(ctx context.Context) ([]models.Team, error) {
+	var teams []models.Team
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&teams).Error
+	return teams, err
+}
+
+func (r *teamRepository) Get(ctx context.Context, id string) (models.Team, error) {
+	var team models.Team
+	err := r.db.WithContext(ctx).First(&team, "id = ?", id).Error
+	return team, err
+}
+
+func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
+	return r.db.WithContext(ctx).Create(team).Error
+}
+
+func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
+	return r.db.WithContext(ctx).Omit("Members").Save(team).Error
+}
+
+func (r *teamRepository) Delete(ctx context.Context, team *models.Team) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("team_id = ?", team.ID).Update("team_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(team).Error
+	})
+}
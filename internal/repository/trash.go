@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/internal/trash"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrashRepository lists, restores and purges deleted records, see
+// internal/trash. Entity types are those of trash.Entities. Deleted leads are
+// limited to those the user in ctx could have deleted.
+type TrashRepository interface {
+	// List returns a page of the deleted records of entityType, last deleted
+	// first, and how many there are in all.
+	List(ctx context.Context, entityType string, page Page) ([]trash.Item, int64, error)
+	// Restore undeletes the record of entityType with id and the records
+	// deleted with it, and rescores the leads it counts towards.
+	Restore(ctx context.Context, entityType string, id uuid.UUID) (trash.Item, error)
+	// Purge removes for good the records of entityType, or of every type if it
+	// is empty, deleted before before, and returns how many.
+	Purge(ctx context.Context, entityType string, before time.Time) (int64, error)
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository returns a TrashRepository on db.
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+func (r *trashRepository) List(ctx context.Context, entityType string, page Page) ([]trash.Item, int64, error) {
+	return trash.List(r.db.WithContext(ctx), entityType, page.Offset, page.Limit, trashScopes(ctx, entityType)...)
+}
+
+func (r *trashRepository) Restore(ctx context.Context, entityType string, id uuid.UUID) (trash.Item, error) {
+	item, err := trash.Restore(r.db.WithContext(ctx), entityType, id, trashScopes(ctx, entityType)...)
+	if err != nil {
+		return item, err
+	}
+
+	// Deleted leads are left out of rescoring, and scores count activities and organizations
+	switch entityType {
+	case "ACTIVITY":
+		var activity models.Activity
+		if err := r.db.First(&activity, "id = ?", id).Error; err == nil {
+			if err := scoring.Recalculate(r.db, activity.LeadID); err != nil {
+				log.Printf("Error scoring lead %s: %v", activity.LeadID, err)
+			}
+		}
+	case "ORGANIZATION":
+		if err := scoring.RecalculateOrganization(r.db, id); err != nil {
+			log.Printf("Error scoring leads of organization %s: %v", id, err)
+		}
+	case "LEAD":
+		if err := scoring.Recalculate(r.db, id); err != nil {
+			log.Printf("Error scoring lead %s: %v", id, err)
+		}
+	}
+	return item, nil
+}
+
+func (r *trashRepository) Purge(ctx context.Context, entityType string, before time.Time) (int64, error) {
+	return trash.Purge(r.db.WithContext(ctx), entityType, before)
+}
+
+// trashScopes limits the deleted leads the user in ctx sees and restores to
+// those they could have deleted. Other entity types are not limited.
+func trashScopes(ctx context.Context, entityType string) []func(*gorm.DB) *gorm.DB {
+	if entityType == "LEAD" {
+		return []func(*gorm.DB) *gorm.DB{auth.LeadScope(ctx, auth.LeadActionDelete)}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxReportingDepth bounds walks down the manager chain.
+const maxReportingDepth = 50
+
+// UserFilter selects users. Empty fields match every user.
+type UserFilter struct {
+	Name   string // part of the name
+	Email  string // part of the email
+	Role   string // exact role
+	TeamID string // member of the team
+	Search string // part of the name or email
+}
+
+// UserRepository stores users.
+type UserRepository interface {
+	// List returns a page of the users matching filter, with their campaigns, and
+	// how many match in all. It sorts by createdAt, name, email or role.
+	List(ctx context.Context, filter UserFilter, sort Sort, page Page) ([]models.User, int64, error)
+	// Get returns the user with id, with their campaigns.
+	Get(ctx context.Context, id string) (models.User, error)
+	// Find returns those of the users with ids that exist, without their campaigns.
+	Find(ctx context.Context, ids []string) ([]models.User, error)
+	// ByEmail returns the user with email, without their campaigns.
+	ByEmail(ctx context.Context, email string) (models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, user *models.User) error
+	// SetManager makes managerID the manager of the user with id, or leaves them
+	// without one if managerID is nil.
+	SetManager(ctx context.Context, id uuid.UUID, managerID *uuid.UUID) error
+	// SetTeam moves the user with id to teamID, or out of their team if teamID is nil.
+	SetTeam(ctx context.Context, id uuid.UUID, teamID *uuid.UUID) error
+	// Reports returns the users reporting to userID by name, only the direct
+	// reports if directOnly is set and the whole tree below them otherwise.
+	Reports(ctx context.Context, userID string, directOnly bool) ([]models.User, error)
+	// TeamMembers returns the members of a team by name.
+	TeamMembers(ctx context.Context, teamID string) ([]models.User, error)
+	// TeamUserIDs returns the ids of the users in userID's team, see auth.TeamLookup.
+	TeamUserIDs(ctx context.Context, userID string) ([]uuid.UUID, error)
+}
+
+// userSortColumns are the columns List sorts by.
+var userSortColumns = map[string]string{
+	"createdAt": "users.created_at",
+	"name":      "users.name",
+	"email":     "users.email",
+	"role":      "users.role",
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository returns a UserRepository on db.
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// filterUsers applies filter to a query on users.
+func filterUsers(filter UserFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if filter.Name != "" {
+			query = query.Where("users.name ILIKE ?", contains(filter.Name))
+		}
+		if filter.Email != "" {
+			query = query.Where("users.email ILIKE ?", contains(filter.Email))
+		}
+		if filter.Role != "" {
+			query = query.Where("users.role = ?", filter.Role)
+		}
+		if filter.TeamID != "" {
+			query = query.Where("users.team_id = ?", filter.TeamID)
+		}
+		if filter.Search != "" {
+			query = query.Where("(users.name ILIKE ? OR users.email ILIKE ?)", contains(filter.Search), contains(filter.Search))
+		}
+		return query
+	}
+}
+
+func (r *userRepository) List(ctx context.Context, filter UserFilter, sort Sort, page Page) ([]models.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.User{}).Scopes(filterUsers(filter))
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if err := query.Scopes(order(sort, userSortColumns, ""), paginate(page)).Preload("Campaigns").Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, totalCount, nil
+}
+
+func (r *userRepository) Get(ctx context.Context, id string) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Preload("Campaigns").First(&user, "id = ?", id).Error
+	return user, err
+}
+
+func (r *userRepository) Find(ctx context.Context, ids []string) ([]models.User, error) {
+	var users []models.User
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) ByEmail(ctx context.Context, email string) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error
+	return user, err
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Omit("Campaigns").Save(user).Error
+}
+
+func (r *userRepository) Delete(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Delete(user).Error
+}
+
+func (r *userRepository) SetManager(ctx context.Context, id uuid.UUID, managerID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("manager_id", managerID).Error
+}
+
+func (r *userRepository) SetTeam(ctx context.Context, id uuid.UUID, teamID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("team_id", teamID).Error
+}
+
+func (r *userRepository) Reports(ctx context.Context, userID string, directOnly bool) ([]models.User, error) {
+	var users []models.User
+	if directOnly {
+		err := r.db.WithContext(ctx).Where("manager_id = ?", userID).Order("name ASC").Find(&users).Error
+		return users, err
+	}
+	err := r.db.WithContext(ctx).Raw(`WITH RECURSIVE reports AS (
+			SELECT id, 1 AS depth FROM users WHERE manager_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT users.id, reports.depth + 1 FROM users
+			JOIN reports ON users.manager_id = reports.id
+			WHERE users.deleted_at IS NULL AND reports.depth < ?
+		)
+		SELECT users.* FROM users WHERE users.id IN (SELECT id FROM reports) ORDER BY users.name ASC`,
+		userID, maxReportingDepth).Scan(&users).Error
+	return users, err
+}
+
+func (r *userRepository) TeamMembers(ctx context.Context, teamID string) ([]models.User, error) {
+	var members []models.User
+	err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Order("name ASC").Find(&members).Error
+	return members, err
+}
+
+func (r *userRepository) TeamUserIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	return auth.TeamUserIDs(ctx, r.db, userID)
+}
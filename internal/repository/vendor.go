@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/trash"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VendorFilter selects vendors. Empty fields match every vendor.
+type VendorFilter struct {
+	CompanyName  string   // part of the company name
+	Status       string   // exact status
+	PaymentTerms string   // exact payment terms
+	Search       string   // part of the company name or notes
+	SkillIDs     []string // has any of the skills
+}
+
+// VendorRepository stores vendors. Vendors it returns have their contacts,
+// skills and performance ratings loaded.
+type VendorRepository interface {
+	// List returns a page of the vendors matching filter and how many match in all.
+	// It sorts by createdAt, updatedAt, companyName or status, newest first by default.
+	List(ctx context.Context, filter VendorFilter, sort Sort, page Page) ([]models.Vendor, int64, error)
+	// Page returns the vendors matching filter on the connection page cursor selects.
+	Page(ctx context.Context, filter VendorFilter, cursor Cursor) ([]models.Vendor, error)
+	Get(ctx context.Context, id uuid.UUID) (models.Vendor, error)
+	// Create inserts vendor along with its skills.
+	Create(ctx context.Context, vendor *models.Vendor) error
+	// Update saves vendor, and replaces its skills with skills unless they are nil.
+	Update(ctx context.Context, vendor *models.Vendor, skills []models.Skill) error
+	// Delete moves the vendor with id, its contacts and ratings to the trash.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// vendorSortColumns are the columns List sorts by.
+var vendorSortColumns = map[string]string{
+	"createdAt":   "vendors.created_at",
+	"updatedAt":   "vendors.updated_at",
+	"companyName": "vendors.company_name",
+	"status":      "vendors.status",
+}
+
+type vendorRepository struct {
+	db *gorm.DB
+}
+
+// NewVendorRepository returns a VendorRepository on db.
+func NewVendorRepository(db *gorm.DB) VendorRepository {
+	return &vendorRepository{db: db}
+}
+
+// preloadVendor preloads the contacts, skills and ratings of vendors.
+func preloadVendor(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("ContactList").
+		Preload("Skills").
+		Preload("PerformanceRatings")
+}
+
+// filterVendors applies filter to a query on vendors.
+func filterVendors(filter VendorFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if filter.CompanyName != "" {
+			query = query.Where("vendors.company_name ILIKE ?", contains(filter.CompanyName))
+		}
+		if filter.Status != "" {
+			query = query.Where("vendors.status = ?", filter.Status)
+		}
+		if filter.PaymentTerms != "" {
+			query = query.Where("vendors.payment_terms = ?", filter.PaymentTerms)
+		}
+		if filter.Search != "" {
+			query = query.Where("(vendors.company_name ILIKE ? OR vendors.notes ILIKE ?)", contains(filter.Search), contains(filter.Search))
+		}
+		if len(filter.SkillIDs) > 0 {
+			// A subquery rather than a join, so vendors with several of the skills are listed once
+			query = query.Where("vendors.id IN (SELECT vendor_id FROM vendor_skills WHERE skill_id IN ?)", filter.SkillIDs)
+		}
+		return query
+	}
+}
+
+func (r *vendorRepository) List(ctx context.Context, filter VendorFilter, sort Sort, page Page) ([]models.Vendor, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Vendor{}).Scopes(filterVendors(filter))
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var vendors []models.Vendor
+	err := query.
+		Scopes(order(sort, vendorSortColumns, "vendors.created_at DESC"), paginate(page), preloadVendor).
+		Find(&vendors).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return vendors, totalCount, nil
+}
+
+func (r *vendorRepository) Page(ctx context.Context, filter VendorFilter, cursor Cursor) ([]models.Vendor, error) {
+	var vendors []models.Vendor
+	err := r.db.WithContext(ctx).Scopes(filterVendors(filter), keyset("vendors", cursor), preloadVendor).Find(&vendors).Error
+	return vendors, err
+}
+
+func (r *vendorRepository) Get(ctx context.Context, id uuid.UUID) (models.Vendor, error) {
+	var vendor models.Vendor
+	err := r.db.WithContext(ctx).Scopes(preloadVendor).First(&vendor, "id = ?", id).Error
+	return vendor, err
+}
+
+func (r *vendorRepository) Create(ctx context.Context, vendor *models.Vendor) error {
+	return r.db.WithContext(ctx).Create(vendor).Error
+}
+
+func (r *vendorRepository) Update(ctx context.Context, vendor *models.Vendor, skills []models.Skill) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if skills != nil {
+			if err := tx.Model(vendor).Association("Skills").Replace(skills); err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(vendor).Error
+	})
+}
+
+func (r *vendorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return trash.Delete(r.db.WithContext(ctx), "VENDOR", id)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/webhooks"
+	"github.com/Zenithive/it-crm-backend/models"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryFilter selects webhook deliveries. Empty fields match every delivery.
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	Event     string
+	Status    string
+}
+
+// WebhookRepository stores webhooks and reads their deliveries, which the queue
+// of internal/webhooks sends.
+type WebhookRepository interface {
+	// List returns every webhook, newest first, with its creator.
+	List(ctx context.Context) ([]models.Webhook, error)
+	// Get returns the webhook with id and its creator.
+	Get(ctx context.Context, id string) (models.Webhook, error)
+	Create(ctx context.Context, webhook *models.Webhook) error
+	// Update saves the URL, events, description and whether webhook is active.
+	Update(ctx context.Context, webhook *models.Webhook) error
+	// SetSecret replaces the signing secret of webhook.
+	SetSecret(ctx context.Context, webhook *models.Webhook, secret string) error
+	// Delete deletes webhook. Its pending deliveries fail on their next attempt.
+	Delete(ctx context.Context, webhook *models.Webhook) error
+	// Deliveries returns a page of the deliveries matching filter, newest first,
+	// and how many match in all.
+	Deliveries(ctx context.Context, filter WebhookDeliveryFilter, page Page) ([]models.WebhookDelivery, int64, error)
+	// Redeliver queues the payload of the delivery with id again, as a new delivery.
+	Redeliver(ctx context.Context, id string) (*models.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository returns a WebhookRepository on db.
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]models.Webhook, error) {
+	var hooks []models.Webhook
+	err := r.db.WithContext(ctx).Preload("CreatedByUser").Order("created_at DESC").Find(&hooks).Error
+	return hooks, err
+}
+
+func (r *webhookRepository) Get(ctx context.Context, id string) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.WithContext(ctx).Preload("CreatedByUser").First(&webhook, "id = ?", id).Error
+	return webhook, err
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Select("url", "events", "active", "description").Updates(webhook).Error
+}
+
+func (r *webhookRepository) SetSecret(ctx context.Context, webhook *models.Webhook, secret string) error {
+	return r.db.WithContext(ctx).Model(webhook).Update("secret", secret).Error
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Delete(webhook).Error
+}
+
+func (r *webhookRepository) Deliveries(ctx context.Context, filter WebhookDeliveryFilter, page Page) ([]models.WebhookDelivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.WebhookDelivery{})
+	if filter.WebhookID != "" {
+		query = query.Where("webhook_id = ?", filter.WebhookID)
+	}
+	if filter.Event != "" {
+		query = query.Where("event = ?", filter.Event)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Scopes(paginate(page)).Order("created_at DESC").Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, totalCount, nil
+}
+
+func (r *webhookRepository) Redeliver(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	return webhooks.Redeliver(r.db.WithContext(ctx), id)
+}
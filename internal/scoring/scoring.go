@@ -0,0 +1,373 @@
+// Package scoring computes lead scores from the weighted rules stored in
+// scoring_rules and keeps leads.score up to date.
+package scoring
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/audit"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// DefaultRules is the scoring model scoring_rules is seeded with.
+var DefaultRules = []models.ScoringRule{
+	{Attribute: models.ScoringAttributeLeadPriority, Operator: models.ScoringOperatorEquals, Value: "HIGH", Weight: 20, Description: "High priority"},
+	{Attribute: models.ScoringAttributeLeadPriority, Operator: models.ScoringOperatorEquals, Value: "MEDIUM", Weight: 10, Description: "Medium priority"},
+	{Attribute: models.ScoringAttributeLeadSource, Operator: models.ScoringOperatorEquals, Value: "Referral", Weight: 15, Description: "Referred lead"},
+	{Attribute: models.ScoringAttributeEmployees, Operator: models.ScoringOperatorGTE, Value: "50", Weight: 10, Description: "50+ employees"},
+	{Attribute: models.ScoringAttributeEmployees, Operator: models.ScoringOperatorGTE, Value: "500", Weight: 10, Description: "500+ employees"},
+	{Attribute: models.ScoringAttributeAnnualRevenue, Operator: models.ScoringOperatorGTE, Value: "1000000", Weight: 15, Description: "Revenue of 1M+ in the base currency"},
+	{Attribute: models.ScoringAttributeActivityCount, Operator: models.ScoringOperatorGTE, Value: "3", Weight: 10, Description: "3+ activities"},
+	{Attribute: models.ScoringAttributeActivityRecency, Operator: models.ScoringOperatorLTE, Value: "14", Weight: 15, Description: "Activity in the last 14 days"},
+	{Attribute: models.ScoringAttributeCampaign, Operator: models.ScoringOperatorEquals, Value: "", Weight: 5, Description: "Part of a campaign"},
+}
+
+// numericAttributes can only be compared with GTE/LTE (or EQUALS on the number).
+var numericAttributes = map[string]bool{
+	models.ScoringAttributeEmployees:       true,
+	models.ScoringAttributeAnnualRevenue:   true,
+	models.ScoringAttributeActivityCount:   true,
+	models.ScoringAttributeActivityRecency: true,
+}
+
+// ValidateRule checks that a rule can be evaluated.
+func ValidateRule(rule models.ScoringRule) error {
+	switch rule.Attribute {
+	case models.ScoringAttributeLeadSource, models.ScoringAttributeLeadPriority, models.ScoringAttributeCampaign:
+		if rule.Operator != models.ScoringOperatorEquals {
+			return apperr.Invalid("attribute %s only supports %s", rule.Attribute, models.ScoringOperatorEquals)
+		}
+		if rule.Attribute == models.ScoringAttributeCampaign && rule.Value != "" {
+			if _, err := uuid.Parse(rule.Value); err != nil {
+				return apperr.Invalid("invalid campaign ID %q", rule.Value)
+			}
+		}
+	case models.ScoringAttributeEmployees, models.ScoringAttributeAnnualRevenue,
+		models.ScoringAttributeActivityCount, models.ScoringAttributeActivityRecency:
+		switch rule.Operator {
+		case models.ScoringOperatorEquals, models.ScoringOperatorGTE, models.ScoringOperatorLTE:
+		default:
+			return apperr.Invalid("unknown operator %s", rule.Operator)
+		}
+		if _, err := decimal.NewFromString(rule.Value); err != nil {
+			return apperr.Invalid("attribute %s needs a numeric value, got %q", rule.Attribute, rule.Value)
+		}
+	default:
+		return apperr.Invalid("unknown attribute %s", rule.Attribute)
+	}
+	return nil
+}
+
+// SeedDefaultRules stores DefaultRules when no scoring rule was ever created,
+// and scores the existing leads with them.
+// Soft-deleted rules count, so an admin emptying the model doesn't get the defaults back.
+func SeedDefaultRules(db *gorm.DB) error {
+	var count int64
+	if err := db.Unscoped().Model(&models.ScoringRule{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	rules := make([]models.ScoringRule, len(DefaultRules))
+	copy(rules, DefaultRules)
+	if err := db.Create(&rules).Error; err != nil {
+		return err
+	}
+	return RecalculateAll(db)
+}
+
+// LoadRules returns the current scoring model.
+func LoadRules(db *gorm.DB) ([]models.ScoringRule, error) {
+	var rules []models.ScoringRule
+	if err := db.Order("attribute ASC, created_at ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to load scoring rules: %w", err)
+	}
+	return rules, nil
+}
+
+// ReplaceRules swaps the scoring model for rules and rescores every lead, in one transaction.
+func ReplaceRules(db *gorm.DB, rules []models.ScoringRule) ([]models.ScoringRule, error) {
+	for _, rule := range rules {
+		if err := ValidateRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.ScoringRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) > 0 {
+			if err := tx.Create(&rules).Error; err != nil {
+				return err
+			}
+		}
+		return RecalculateAll(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return LoadRules(db)
+}
+
+// facts are the values of a lead the rules are evaluated against.
+type facts struct {
+	LeadSource    string
+	LeadPriority  string
+	Employees     *decimal.Decimal
+	AnnualRevenue *decimal.Decimal // base currency, nil when the rate is unknown
+	ActivityCount int64
+	LastActivity  *time.Time
+	CampaignID    uuid.UUID
+}
+
+// score sums the weights of the rules f matches.
+func score(rules []models.ScoringRule, f facts, now time.Time) int {
+	total := 0
+	for _, rule := range rules {
+		if matches(rule, f, now) {
+			total += rule.Weight
+		}
+	}
+	return total
+}
+
+func matches(rule models.ScoringRule, f facts, now time.Time) bool {
+	switch rule.Attribute {
+	case models.ScoringAttributeLeadSource:
+		return strings.EqualFold(strings.TrimSpace(f.LeadSource), strings.TrimSpace(rule.Value))
+	case models.ScoringAttributeLeadPriority:
+		return strings.EqualFold(f.LeadPriority, rule.Value)
+	case models.ScoringAttributeCampaign:
+		if f.CampaignID == uuid.Nil {
+			return false
+		}
+		return rule.Value == "" || strings.EqualFold(f.CampaignID.String(), rule.Value)
+	}
+
+	var value decimal.Decimal
+	switch rule.Attribute {
+	case models.ScoringAttributeEmployees:
+		if f.Employees == nil {
+			return false
+		}
+		value = *f.Employees
+	case models.ScoringAttributeAnnualRevenue:
+		if f.AnnualRevenue == nil {
+			return false
+		}
+		value = *f.AnnualRevenue
+	case models.ScoringAttributeActivityCount:
+		value = decimal.NewFromInt(f.ActivityCount)
+	case models.ScoringAttributeActivityRecency:
+		if f.LastActivity == nil {
+			return false
+		}
+		days := math.Floor(now.Sub(*f.LastActivity).Hours() / 24)
+		value = decimal.NewFromFloat(math.Max(days, 0))
+	default:
+		return false
+	}
+
+	threshold, err := decimal.NewFromString(rule.Value)
+	if err != nil {
+		return false
+	}
+	switch rule.Operator {
+	case models.ScoringOperatorEquals:
+		return value.Equal(threshold)
+	case models.ScoringOperatorGTE:
+		return value.GreaterThanOrEqual(threshold)
+	case models.ScoringOperatorLTE:
+		return value.LessThanOrEqual(threshold)
+	}
+	return false
+}
+
+var firstNumber = regexp.MustCompile(`\d[\d,]*`)
+
+// parseEmployees reads the head count from the free-text NoOfEmployees,
+// taking the first number so "50-200" and "1,000+" both work.
+func parseEmployees(raw string) *decimal.Decimal {
+	match := firstNumber.FindString(raw)
+	if match == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(strings.ReplaceAll(match, ",", ""), 10, 64)
+	if err != nil {
+		return nil
+	}
+	value := decimal.NewFromInt(n)
+	return &value
+}
+
+// Recalculate rescores the given leads. Pass the transaction that changed them, if any.
+func Recalculate(db *gorm.DB, leadIDs ...uuid.UUID) error {
+	if len(leadIDs) == 0 {
+		return nil
+	}
+	var leads []models.Lead
+	if err := db.Preload("Organization").Where("id IN ?", leadIDs).Find(&leads).Error; err != nil {
+		return fmt.Errorf("failed to load leads to score: %w", err)
+	}
+	rules, err := LoadRules(db)
+	if err != nil {
+		return err
+	}
+	rates, err := utils.LoadExchangeRates(db)
+	if err != nil {
+		return fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+	return scoreLeads(db, leads, rules, rates)
+}
+
+// RescoreLead rescores one lead and refreshes lead.Score.
+func RescoreLead(db *gorm.DB, lead *models.Lead) error {
+	if err := Recalculate(db, lead.ID); err != nil {
+		return err
+	}
+	return db.Model(&models.Lead{}).Select("score").Where("id = ?", lead.ID).Scan(&lead.Score).Error
+}
+
+// RecalculateOrganization rescores every lead of an organization.
+func RecalculateOrganization(db *gorm.DB, organizationID uuid.UUID) error {
+	var leadIDs []uuid.UUID
+	if err := db.Model(&models.Lead{}).Where("organization_id = ?", organizationID).Pluck("id", &leadIDs).Error; err != nil {
+		return fmt.Errorf("failed to load organization leads: %w", err)
+	}
+	return Recalculate(db, leadIDs...)
+}
+
+// RecalculateAll rescores every lead, e.g. after the scoring model changed.
+func RecalculateAll(db *gorm.DB) error {
+	rules, err := LoadRules(db)
+	if err != nil {
+		return err
+	}
+	rates, err := utils.LoadExchangeRates(db)
+	if err != nil {
+		return fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+	var leads []models.Lead
+	result := db.Preload("Organization").FindInBatches(&leads, 500, func(batch *gorm.DB, _ int) error {
+		return scoreLeads(db, leads, rules, rates)
+	})
+	return result.Error
+}
+
+func scoreLeads(db *gorm.DB, leads []models.Lead, rules []models.ScoringRule, rates map[string]decimal.Decimal) error {
+	if len(leads) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(leads))
+	for i, lead := range leads {
+		ids[i] = lead.ID
+	}
+
+	// --- Activity counts and latest activity per lead ---
+	type activityStats struct {
+		LeadID uuid.UUID
+		Count  int64
+		Latest *time.Time
+	}
+	var stats []activityStats
+	err := db.Model(&models.Activity{}).
+		Select("lead_id, COUNT(*) AS count, MAX(date_time) AS latest").
+		Where("lead_id IN ?", ids).
+		Group("lead_id").
+		Scan(&stats).Error
+	if err != nil {
+		return fmt.Errorf("failed to load lead activities: %w", err)
+	}
+	statsByLead := make(map[uuid.UUID]activityStats, len(stats))
+	for _, s := range stats {
+		statsByLead[s.LeadID] = s
+	}
+
+	now := time.Now()
+	for _, lead := range leads {
+		f := facts{
+			LeadSource:   lead.LeadSource,
+			LeadPriority: lead.LeadPriority,
+			CampaignID:   lead.CampaignID,
+		}
+		if lead.OrganizationID != uuid.Nil {
+			f.Employees = parseEmployees(lead.Organization.NoOfEmployees)
+			revenue := lead.Organization.AnnualRevenue
+			if rate, ok := rates[strings.ToUpper(revenue.Currency)]; ok {
+				base := revenue.Amount.Mul(rate)
+				f.AnnualRevenue = &base
+			}
+		}
+		if s, ok := statsByLead[lead.ID]; ok {
+			f.ActivityCount = s.Count
+			f.LastActivity = s.Latest
+		}
+
+		newScore := score(rules, f, now)
+		if newScore == lead.Score {
+			continue
+		}
+		// UpdateColumn leaves updated_at alone and the audit log is skipped, a rescore isn't an edit of the lead
+		if err := audit.Skip(db).Model(&models.Lead{}).Where("id = ?", lead.ID).UpdateColumn("score", newScore).Error; err != nil {
+			return fmt.Errorf("failed to update lead score: %w", err)
+		}
+	}
+	return nil
+}
+
+// RescoreByActivityRecency rescores, every interval, the leads whose activity
+// recency rules may have changed outcome as time passed. Scores are otherwise
+// only updated when a lead or its activities change, so a lead would keep
+// counting as recently active forever. It blocks forever, run it in its own
+// goroutine.
+func RescoreByActivityRecency(db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := rescoreByActivityRecency(db, interval, time.Now()); err != nil {
+			log.Printf("Error rescoring leads by activity recency: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// rescoreByActivityRecency rescores the leads with an activity recent enough to
+// still cross a recency threshold: within the largest threshold, plus the day
+// recency is rounded down to and the interval since the last run.
+func rescoreByActivityRecency(db *gorm.DB, interval time.Duration, now time.Time) error {
+	rules, err := LoadRules(db)
+	if err != nil {
+		return err
+	}
+	maxDays := -1.0
+	for _, rule := range rules {
+		if rule.Attribute != models.ScoringAttributeActivityRecency {
+			continue
+		}
+		if threshold, err := decimal.NewFromString(rule.Value); err == nil {
+			maxDays = math.Max(maxDays, threshold.InexactFloat64())
+		}
+	}
+	if maxDays < 0 {
+		return nil
+	}
+
+	since := now.Add(-time.Duration((maxDays+1)*24*float64(time.Hour)) - interval)
+	var leadIDs []uuid.UUID
+	if err := db.Model(&models.Activity{}).Distinct("lead_id").Where("date_time >= ?", since).Pluck("lead_id", &leadIDs).Error; err != nil {
+		return fmt.Errorf("failed to load recently active leads: %w", err)
+	}
+	return Recalculate(db, leadIDs...)
+}
@@ -0,0 +1,338 @@
+// Package seed fills a database with demo data: a sales team with a manager and
+// two sales executives, and a few records of every kind the CRM manages, linked
+// to each other the way users would link them.
+//
+// Logs, jobs, webhooks and tokens are left out, as they record what the
+// application did rather than data anyone enters.
+package seed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Password is the password of every demo user.
+const Password = "demo1234"
+
+// TeamName names the demo team, whose presence marks a seeded database.
+const TeamName = "Demo Sales"
+
+// ErrSeeded is returned by Demo when the database already has the demo data.
+var ErrSeeded = errors.New("demo data is already present")
+
+// Demo adds the demo data in one transaction and returns the demo users.
+func Demo(db *gorm.DB) ([]models.User, error) {
+	var users []models.User
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var teams int64
+		if err := tx.Model(&models.Team{}).Where("name = ?", TeamName).Count(&teams).Error; err != nil {
+			return err
+		}
+		if teams > 0 {
+			return ErrSeeded
+		}
+
+		var err error
+		if users, err = seedTeam(tx); err != nil {
+			return fmt.Errorf("failed to seed users: %w", err)
+		}
+		// Exchange rates first, as lead scores depend on them
+		if err := seedReference(tx, users); err != nil {
+			return fmt.Errorf("failed to seed reference data: %w", err)
+		}
+		if err := seedSales(tx, users); err != nil {
+			return fmt.Errorf("failed to seed leads: %w", err)
+		}
+		if err := seedResources(tx); err != nil {
+			return fmt.Errorf("failed to seed vendors: %w", err)
+		}
+		return nil
+	})
+	return users, err
+}
+
+// seedTeam creates the demo team and its users: the manager first, then the sales executives.
+func seedTeam(tx *gorm.DB) ([]models.User, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	team := models.Team{ID: uuid.New(), Name: TeamName, Description: "Demo sales team"}
+	managerID := uuid.New()
+	users := []models.User{
+		{ID: managerID, Name: "Maya Manager", Email: "manager@demo.example", Phone: "+14155550100", Role: "MANAGER"},
+		{ID: uuid.New(), Name: "Sam Sales", Email: "sam@demo.example", Phone: "+14155550101", Role: "SALES_EXECUTIVE", ManagerID: &managerID},
+		{ID: uuid.New(), Name: "Priya Sales", Email: "priya@demo.example", Phone: "+14155550102", Role: "SALES_EXECUTIVE", ManagerID: &managerID},
+	}
+	for i := range users {
+		users[i].Password = string(hashedPassword)
+		users[i].TeamID = &team.ID
+	}
+	team.ManagerID = &managerID
+
+	if err := tx.Create(&team).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Omit(clause.Associations).Create(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// seedSales creates a campaign, organizations and leads in several stages, with
+// their activities, stage history, deals, tasks and documents.
+func seedSales(tx *gorm.DB, users []models.User) error {
+	now := time.Now()
+	manager, sam, priya := users[0], users[1], users[2]
+
+	campaign := models.Campaign{
+		ID:               uuid.New(),
+		CampaignName:     "Demo Cloud Migration",
+		CampaignCountry:  "United States",
+		CampaignRegion:   "North America",
+		IndustryTargeted: "Retail",
+		Users:            users,
+	}
+	if err := tx.Omit("Users.*").Create(&campaign).Error; err != nil {
+		return err
+	}
+
+	organizations := []models.Organization{
+		{
+			ID:                  uuid.New(),
+			OrganizationName:    "Northwind Traders",
+			OrganizationEmail:   "info@northwind.example",
+			OrganizationWebsite: "https://northwind.example",
+			City:                "Seattle",
+			Country:             "United States",
+			NoOfEmployees:       "250",
+			AnnualRevenue:       models.Money{Amount: decimal.NewFromInt(12_000_000), Currency: "USD"},
+		},
+		{
+			ID:                  uuid.New(),
+			OrganizationName:    "Contoso GmbH",
+			OrganizationEmail:   "kontakt@contoso.example",
+			OrganizationWebsite: "https://contoso.example",
+			City:                "Berlin",
+			Country:             "Germany",
+			NoOfEmployees:       "40",
+			AnnualRevenue:       models.Money{Amount: decimal.NewFromInt(3_500_000), Currency: "EUR"},
+		},
+	}
+	if err := tx.Create(&organizations).Error; err != nil {
+		return err
+	}
+
+	lead := func(first, last, email string, organization models.Organization, owner models.User, stage models.LeadStage, priority string, age time.Duration) models.Lead {
+		return models.Lead{
+			ID:                 uuid.New(),
+			FirstName:          first,
+			LastName:           last,
+			Email:              email,
+			LinkedIn:           "https://www.linkedin.com/in/" + first + "-" + last,
+			Country:            organization.Country,
+			Phone:              "+14155550199",
+			LeadSource:         "Website",
+			InitialContactDate: now.Add(-age),
+			LeadCreatedBy:      manager.ID,
+			LeadAssignedTo:     owner.ID,
+			LeadStage:          stage,
+			LeadPriority:       priority,
+			LeadNotes:          "Demo lead",
+			OrganizationID:     organization.ID,
+			CampaignID:         campaign.ID,
+		}
+	}
+	day := 24 * time.Hour
+	leads := []models.Lead{
+		lead("Nancy", "Davolio", "nancy@northwind.example", organizations[0], sam, models.LeadStageNew, "HIGH", 2*day),
+		lead("Andrew", "Fuller", "andrew@northwind.example", organizations[0], sam, models.LeadStageFollowUp, "MEDIUM", 14*day),
+		lead("Jonas", "Weber", "jonas@contoso.example", organizations[1], priya, models.LeadStageClosedWon, "HIGH", 45*day),
+	}
+	if err := tx.Omit(clause.Associations).Create(&leads).Error; err != nil {
+		return err
+	}
+
+	activities := []models.Activity{
+		{ID: uuid.New(), LeadID: leads[0].ID, ActivityType: "EMAIL", DateTime: now.Add(-day), CommunicationChannel: "Email", ContentNotes: "Sent the introduction deck", ParticipantDetails: sam.Name, FollowUpActions: "Call next week"},
+		{ID: uuid.New(), LeadID: leads[1].ID, ActivityType: "CALL", DateTime: now.Add(-10 * day), CommunicationChannel: "Phone", ContentNotes: "Discussed the migration timeline", ParticipantDetails: sam.Name, FollowUpActions: "Send a proposal"},
+		{ID: uuid.New(), LeadID: leads[2].ID, ActivityType: "MEETING", DateTime: now.Add(-30 * day), CommunicationChannel: "Video call", ContentNotes: "Agreed on scope and budget", ParticipantDetails: priya.Name, FollowUpActions: "Draft the contract"},
+	}
+	if err := tx.Create(&activities).Error; err != nil {
+		return err
+	}
+
+	history := []models.LeadStageHistory{
+		{ID: uuid.New(), LeadID: leads[1].ID, OldStage: models.LeadStageNew, NewStage: models.LeadStageFollowUp, ChangedAt: now.Add(-10 * day), ChangedBy: &sam.ID},
+		{ID: uuid.New(), LeadID: leads[2].ID, OldStage: models.LeadStageNew, NewStage: models.LeadStageInProgress, ChangedAt: now.Add(-30 * day), ChangedBy: &priya.ID},
+		{ID: uuid.New(), LeadID: leads[2].ID, OldStage: models.LeadStageInProgress, NewStage: models.LeadStageClosedWon, ChangedAt: now.Add(-20 * day), ChangedBy: &priya.ID},
+	}
+	if err := tx.Omit(clause.Associations).Create(&history).Error; err != nil {
+		return err
+	}
+
+	deal := models.Deal{
+		ID:                  uuid.New(),
+		DealName:            "Contoso platform rebuild",
+		LeadID:              leads[2].ID,
+		DealStartDate:       now.Add(-20 * day),
+		DealEndDate:         now.Add(160 * day),
+		ProjectRequirements: "Rebuild the ordering platform on managed cloud services",
+		DealAmount:          models.Money{Amount: decimal.NewFromInt(180_000), Currency: "EUR"},
+		DealStatus:          "ACTIVE",
+	}
+	if err := tx.Create(&deal).Error; err != nil {
+		return err
+	}
+
+	dueDate := now.Add(3 * day)
+	tasks := []models.Task{
+		{ID: uuid.New(), UserID: sam.ID, Title: "Send Northwind a proposal", Description: "Follow up on the migration call", Status: models.TODO, Priority: models.HIGH, DueDate: &dueDate},
+		{ID: uuid.New(), UserID: priya.ID, Title: "Kick off the Contoso project", Description: "Schedule the kickoff with the delivery team", Status: models.IN_PROGRESS, Priority: models.MEDIUM},
+	}
+	if err := tx.Omit(clause.Associations).Create(&tasks).Error; err != nil {
+		return err
+	}
+
+	document := models.Document{
+		ID:            uuid.New(),
+		Title:         "Contoso statement of work",
+		UserID:        priya.ID,
+		FilePath:      "demo/contoso-sow.pdf",
+		FileSize:      "120 KB",
+		FileType:      "application/pdf",
+		ReferenceID:   deal.ID,
+		ReferenceType: "DEAL",
+		Tags:          pq.StringArray{"contract", "demo"},
+	}
+	if err := tx.Create(&document).Error; err != nil {
+		return err
+	}
+
+	leadIDs := make([]uuid.UUID, len(leads))
+	for i, lead := range leads {
+		leadIDs[i] = lead.ID
+	}
+	return scoring.Recalculate(tx, leadIDs...)
+}
+
+// seedResources creates skills, a vendor with contacts and ratings, and its resources.
+func seedResources(tx *gorm.DB) error {
+	skills := []models.Skill{
+		{Name: "Go", SkillType: models.BACKEND},
+		{Name: "PostgreSQL", SkillType: models.BACKEND},
+		{Name: "React", SkillType: models.FRONTEND},
+		{Name: "Figma", SkillType: models.DESIGN},
+	}
+	for i := range skills {
+		// Skill names are unique, so reuse skills that already exist
+		if err := tx.Where(models.Skill{Name: skills[i].Name}).Attrs(models.Skill{ID: uuid.New(), SkillType: skills[i].SkillType}).FirstOrCreate(&skills[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	review := "Delivered on time with strong engineers"
+	vendor := models.Vendor{
+		ID:           uuid.New(),
+		CompanyName:  "Demo Talent Partners",
+		Status:       models.VendorStatusPreferred,
+		PaymentTerms: models.PaymentTermsNet30,
+		Address:      "1 Market Street, San Francisco, CA",
+		ContactList: []models.Contact{
+			{ID: uuid.New(), Name: "Alex Vendor", Email: "alex@talent.example", PhoneNumber: "+14155550150"},
+		},
+		Skills: skills,
+		PerformanceRatings: []models.PerformanceRating{
+			{ID: uuid.New(), Rating: 5, Review: &review},
+		},
+	}
+	if err := tx.Omit("Skills.*").Create(&vendor).Error; err != nil {
+		return err
+	}
+
+	contact, err := json.Marshal(map[string]string{"email": "lee@talent.example", "phoneNumber": "+14155550151"})
+	if err != nil {
+		return err
+	}
+	resource := models.ResourceProfile{
+		ID:                 uuid.New(),
+		Type:               models.Contractor,
+		FirstName:          "Lee",
+		LastName:           "Developer",
+		TotalExperience:    7,
+		ContactInformation: contact,
+		Status:             models.ResourceStatusActive,
+		VendorID:           vendor.ID,
+	}
+	if err := tx.Omit(clause.Associations).Create(&resource).Error; err != nil {
+		return err
+	}
+	resourceSkills := []models.ResourceSkill{
+		{ResourceProfileID: resource.ID, SkillID: skills[0].ID, ExperienceYears: 5},
+		{ResourceProfileID: resource.ID, SkillID: skills[1].ID, ExperienceYears: 4},
+	}
+	if err := tx.Omit(clause.Associations).Create(&resourceSkills).Error; err != nil {
+		return err
+	}
+	project := models.PastProject{
+		ID:                uuid.New(),
+		ResourceProfileID: resource.ID,
+		ProjectName:       "Inventory service",
+		Description:       "Built the inventory service of a retail platform in Go",
+	}
+	return tx.Create(&project).Error
+}
+
+// seedReference creates a case study, exchange rates and an assignment rule for the demo team.
+func seedReference(tx *gorm.DB, users []models.User) error {
+	caseStudy := models.CaseStudy{
+		ID:              uuid.New(),
+		ProjectName:     "Retail platform modernisation",
+		ClientName:      "Demo Retail Co.",
+		TechStack:       "Go, PostgreSQL, React",
+		ProjectDuration: "6 months",
+		KeyOutcomes:     "Checkout latency down 40%",
+		IndustryTarget:  "Retail",
+		Tags:            "cloud,retail",
+	}
+	if err := tx.Create(&caseStudy).Error; err != nil {
+		return err
+	}
+
+	// Rates already set by an admin are kept
+	rates := []models.ExchangeRate{
+		{ID: uuid.New(), Currency: "EUR", RateToBase: decimal.RequireFromString("1.08")},
+		{ID: uuid.New(), Currency: "GBP", RateToBase: decimal.RequireFromString("1.27")},
+	}
+	for _, rate := range rates {
+		if rate.Currency == models.BaseCurrency() {
+			continue
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rate).Error; err != nil {
+			return err
+		}
+	}
+
+	rule := models.AssignmentRule{
+		ID:        uuid.New(),
+		Name:      "Demo: North America round robin",
+		Priority:  100,
+		Enabled:   true,
+		Strategy:  models.AssignmentStrategyRoundRobin,
+		Countries: []string{"United States", "Canada"},
+		TeamID:    users[0].TeamID,
+	}
+	return tx.Create(&rule).Error
+}
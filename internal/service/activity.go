@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ActivityService manages the activities of leads.
+type ActivityService struct {
+	activities repository.ActivityRepository
+	leads      repository.LeadRepository
+}
+
+// NewActivityService returns an ActivityService storing activities in
+// activities and looking their leads up in leads.
+func NewActivityService(activities repository.ActivityRepository, leads repository.LeadRepository) *ActivityService {
+	return &ActivityService{activities: activities, leads: leads}
+}
+
+// convertActivity maps an activity to the GraphQL type. Unlike in lead
+// listings, mutations return the activity's full date and time.
+func convertActivity(activity models.Activity) *generated.Activity {
+	result := utils.ConvertActivity(activity)
+	result.DateTime = activity.DateTime.Format(time.RFC3339)
+	return result
+}
+
+// Connection returns the activities of the leads the caller may read, or of
+// one lead, after a cursor.
+func (s *ActivityService) Connection(ctx context.Context, leadID *string, first *int32, after *string) (*generated.ActivityConnection, error) {
+	position, err := cursor(first, after)
+	if err != nil {
+		return nil, err
+	}
+	activities, err := s.activities.Page(ctx, value(leadID), position)
+	if err != nil {
+		return nil, internalError(err, "fetch activities")
+	}
+
+	activities, cursors, pageInfo := utils.KeysetPage(activities, position.Size, after, func(activity models.Activity) string {
+		return utils.EncodeCursor(activity.CreatedAt, activity.ID)
+	})
+	edges := make([]*generated.ActivityEdge, 0, len(activities))
+	for i, activity := range activities {
+		edges = append(edges, &generated.ActivityEdge{Node: utils.ConvertActivity(activity), Cursor: cursors[i]})
+	}
+	return &generated.ActivityConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
+
+// Create adds an activity to a lead the caller may change.
+func (s *ActivityService) Create(ctx context.Context, input generated.CreateActivityInput) (*generated.Activity, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	parsedLeadID, err := uuid.Parse(input.LeadID)
+	if err != nil {
+		return nil, apperr.InvalidField("leadID", "invalid LeadID: %v", err)
+	}
+	parsedDateTime, err := time.Parse(time.RFC3339, input.DateTime)
+	if err != nil {
+		return nil, apperr.InvalidField("dateTime", "invalid DateTime format: %v", err)
+	}
+	if _, err := s.leads.Get(ctx, auth.LeadActionWrite, input.LeadID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("lead with ID %s does not exist", input.LeadID)
+		}
+		return nil, internalError(err, "check lead existence")
+	}
+
+	activity := models.Activity{
+		ID:                   uuid.New(),
+		LeadID:               parsedLeadID,
+		ActivityType:         input.ActivityType,
+		DateTime:             parsedDateTime,
+		CommunicationChannel: input.CommunicationChannel,
+		ContentNotes:         input.ContentNotes,
+		ParticipantDetails:   input.ParticipantDetails,
+		FollowUpActions:      input.FollowUpActions,
+	}
+	if err := s.activities.Create(ctx, &activity); err != nil {
+		return nil, internalError(err, "create activity")
+	}
+	return convertActivity(activity), nil
+}
+
+// Update changes the fields set in input of an activity on a lead the caller may change.
+func (s *ActivityService) Update(ctx context.Context, activityID string, input generated.UpdateActivityInput) (*generated.Activity, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	activity, err := s.get(ctx, auth.LeadActionWrite, activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DateTime != nil {
+		parsedDateTime, err := time.Parse(time.RFC3339, *input.DateTime)
+		if err != nil {
+			return nil, apperr.InvalidField("dateTime", "invalid DateTime format: %v", err)
+		}
+		activity.DateTime = parsedDateTime
+	}
+	if input.ActivityType != nil {
+		activity.ActivityType = *input.ActivityType
+	}
+	if input.CommunicationChannel != nil {
+		activity.CommunicationChannel = *input.CommunicationChannel
+	}
+	if input.ContentNotes != nil {
+		activity.ContentNotes = *input.ContentNotes
+	}
+	if input.ParticipantDetails != nil {
+		activity.ParticipantDetails = *input.ParticipantDetails
+	}
+	if input.FollowUpActions != nil {
+		activity.FollowUpActions = *input.FollowUpActions
+	}
+
+	if err := s.activities.Update(ctx, &activity); err != nil {
+		return nil, internalError(err, "update activity")
+	}
+	return convertActivity(activity), nil
+}
+
+// Delete deletes an activity on a lead the caller may delete.
+func (s *ActivityService) Delete(ctx context.Context, activityID string) (*generated.Activity, error) {
+	activity, err := s.get(ctx, auth.LeadActionDelete, activityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.activities.Delete(ctx, &activity); err != nil {
+		return nil, internalError(err, "delete activity")
+	}
+	return convertActivity(activity), nil
+}
+
+// get returns an activity whose lead the caller may perform action on.
+func (s *ActivityService) get(ctx context.Context, action string, activityID string) (models.Activity, error) {
+	activity, err := s.activities.Get(ctx, action, activityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Activity{}, apperr.NotFound("activity not found")
+		}
+		return models.Activity{}, internalError(err, "fetch activity")
+	}
+	return activity, nil
+}
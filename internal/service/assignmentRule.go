@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssignmentRuleService manages the rules that assign new leads and shows the
+// log of their decisions.
+type AssignmentRuleService struct {
+	rules     repository.AssignmentRuleRepository
+	leads     repository.LeadRepository
+	campaigns repository.CampaignRepository
+	teams     repository.TeamRepository
+	users     repository.UserRepository
+}
+
+// NewAssignmentRuleService returns an AssignmentRuleService storing rules in
+// rules, looking up the leads whose log is read in leads and the campaigns,
+// teams and users rules refer to in campaigns, teams and users.
+func NewAssignmentRuleService(rules repository.AssignmentRuleRepository, leads repository.LeadRepository, campaigns repository.CampaignRepository, teams repository.TeamRepository, users repository.UserRepository) *AssignmentRuleService {
+	return &AssignmentRuleService{rules: rules, leads: leads, campaigns: campaigns, teams: teams, users: users}
+}
+
+// List returns every rule in the order they are tried.
+func (s *AssignmentRuleService) List(ctx context.Context) ([]*generated.AssignmentRule, error) {
+	rules, err := s.rules.List(ctx)
+	if err != nil {
+		return nil, internalError(err, "fetch assignment rules")
+	}
+	result := make([]*generated.AssignmentRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, utils.ConvertAssignmentRule(rule))
+	}
+	return result, nil
+}
+
+// Create adds an enabled rule unless input says otherwise.
+func (s *AssignmentRuleService) Create(ctx context.Context, input generated.AssignmentRuleInput) (*generated.AssignmentRule, error) {
+	rule := models.AssignmentRule{ID: uuid.New(), Enabled: true}
+	if err := s.apply(ctx, &rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Create(ctx, &rule); err != nil {
+		return nil, internalError(err, "create assignment rule")
+	}
+	return utils.ConvertAssignmentRule(rule), nil
+}
+
+// Update replaces a rule with input.
+func (s *AssignmentRuleService) Update(ctx context.Context, ruleID string, input generated.AssignmentRuleInput) (*generated.AssignmentRule, error) {
+	rule, err := s.get(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, &rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Update(ctx, &rule); err != nil {
+		return nil, internalError(err, "update assignment rule")
+	}
+	return utils.ConvertAssignmentRule(rule), nil
+}
+
+// Delete deletes a rule.
+func (s *AssignmentRuleService) Delete(ctx context.Context, ruleID string) (*generated.AssignmentRule, error) {
+	rule, err := s.get(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rules.Delete(ctx, &rule); err != nil {
+		return nil, internalError(err, "delete assignment rule")
+	}
+	return utils.ConvertAssignmentRule(rule), nil
+}
+
+// Log returns how a lead was assigned, oldest first. The log is visible to
+// whoever can see the lead.
+func (s *AssignmentRuleService) Log(ctx context.Context, leadID string) ([]*generated.LeadAssignmentLog, error) {
+	lead, err := s.leads.Get(ctx, auth.LeadActionRead, leadID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("lead not found")
+		}
+		return nil, internalError(err, "fetch lead")
+	}
+
+	entries, err := s.rules.Log(ctx, lead.ID)
+	if err != nil {
+		return nil, internalError(err, "fetch lead assignment log")
+	}
+	result := make([]*generated.LeadAssignmentLog, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, utils.ConvertLeadAssignmentLog(entry))
+	}
+	return result, nil
+}
+
+// apply validates input and copies it onto rule, loading the listed campaign,
+// team and users.
+func (s *AssignmentRuleService) apply(ctx context.Context, rule *models.AssignmentRule, input generated.AssignmentRuleInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return apperr.InvalidField("name", "name is required")
+	}
+	if !input.Strategy.IsValid() {
+		return apperr.InvalidField("strategy", "invalid strategy %s", input.Strategy)
+	}
+	rule.Name = strings.TrimSpace(input.Name)
+	rule.Priority = int(input.Priority)
+	rule.Strategy = input.Strategy.String()
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+
+	rule.Countries = []string{}
+	for _, country := range input.Countries {
+		if country = strings.TrimSpace(country); country != "" {
+			rule.Countries = append(rule.Countries, country)
+		}
+	}
+
+	rule.CampaignID = nil
+	if input.CampaignID != nil && *input.CampaignID != "" {
+		campaign, err := s.campaigns.Get(ctx, *input.CampaignID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperr.NotFound("campaign not found")
+			}
+			return internalError(err, "fetch campaign")
+		}
+		rule.CampaignID = &campaign.ID
+	}
+
+	rule.TeamID = nil
+	if input.TeamID != nil && *input.TeamID != "" {
+		team, err := s.teams.Get(ctx, *input.TeamID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperr.NotFound("team not found")
+			}
+			return internalError(err, "fetch team")
+		}
+		rule.TeamID = &team.ID
+	}
+
+	rule.Users = []models.User{}
+	if len(input.UserIDs) > 0 {
+		// Malformed ids are reported instead of failing the query
+		userIDs := make([]string, 0, len(input.UserIDs))
+		for _, userID := range input.UserIDs {
+			id, err := uuid.Parse(userID)
+			if err != nil {
+				return apperr.InvalidField("userIDs", "invalid ID %q", userID)
+			}
+			userIDs = append(userIDs, id.String())
+		}
+		users, err := s.users.Find(ctx, userIDs)
+		if err != nil {
+			return internalError(err, "fetch users")
+		}
+		if len(users) != len(input.UserIDs) {
+			return apperr.NotFound("one or more users not found")
+		}
+		rule.Users = users
+	}
+	if rule.TeamID == nil && len(rule.Users) == 0 {
+		return apperr.Invalid("a rule needs a team or at least one user")
+	}
+	return nil
+}
+
+func (s *AssignmentRuleService) get(ctx context.Context, ruleID string) (models.AssignmentRule, error) {
+	if _, err := uuid.Parse(ruleID); err != nil {
+		return models.AssignmentRule{}, apperr.InvalidField("ruleID", "invalid rule ID")
+	}
+	rule, err := s.rules.Get(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.AssignmentRule{}, apperr.NotFound("assignment rule not found")
+		}
+		return models.AssignmentRule{}, internalError(err, "fetch assignment rule")
+	}
+	return rule, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/utils"
+)
+
+// AuditService shows the audit log.
+type AuditService struct {
+	events repository.AuditEventRepository
+}
+
+// NewAuditService returns an AuditService reading the log from events.
+func NewAuditService(events repository.AuditEventRepository) *AuditService {
+	return &AuditService{events: events}
+}
+
+// List returns a page of the events matching filter, newest first. Its dates
+// are RFC 3339.
+func (s *AuditService) List(ctx context.Context, filter *generated.AuditLogFilter, pagination *generated.PaginationInput) (*generated.AuditEventPage, error) {
+	var eventFilter repository.AuditEventFilter
+	if filter != nil {
+		eventFilter = repository.AuditEventFilter{
+			EntityType: value(filter.EntityType),
+			EntityID:   value(filter.EntityID),
+			ActorID:    value(filter.ActorID),
+		}
+		if filter.Action != nil {
+			eventFilter.Action = filter.Action.String()
+		}
+		if filter.From != nil {
+			from, err := time.Parse(time.RFC3339, *filter.From)
+			if err != nil {
+				return nil, apperr.InvalidField("filter.from", "invalid from date: %v", err)
+			}
+			eventFilter.From = &from
+		}
+		if filter.To != nil {
+			to, err := time.Parse(time.RFC3339, *filter.To)
+			if err != nil {
+				return nil, apperr.InvalidField("filter.to", "invalid to date: %v", err)
+			}
+			eventFilter.To = &to
+		}
+	}
+
+	auditEvents, totalCount, err := s.events.List(ctx, eventFilter, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch audit events")
+	}
+	items := make([]*generated.AuditEvent, 0, len(auditEvents))
+	for _, event := range auditEvents {
+		items = append(items, utils.ConvertAuditEvent(event))
+	}
+	return &generated.AuditEventPage{Items: items, TotalCount: int32(totalCount)}, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CampaignService manages campaigns and their members.
+type CampaignService struct {
+	campaigns repository.CampaignRepository
+	users     repository.UserRepository
+}
+
+// NewCampaignService returns a CampaignService storing campaigns in campaigns
+// and looking their members up in users.
+func NewCampaignService(campaigns repository.CampaignRepository, users repository.UserRepository) *CampaignService {
+	return &CampaignService{campaigns: campaigns, users: users}
+}
+
+// List returns a page of campaigns.
+func (s *CampaignService) List(ctx context.Context, filter *generated.CampaignFilter, pagination *generated.PaginationInput, sort *generated.CampaignSortInput) (*generated.CampaignPage, error) {
+	var campaignFilter repository.CampaignFilter
+	if filter != nil {
+		campaignFilter = repository.CampaignFilter{
+			Name:    value(filter.CampaignName),
+			Country: value(filter.CampaignCountry),
+		}
+	}
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	campaigns, totalCount, err := s.campaigns.List(ctx, campaignFilter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch campaigns")
+	}
+
+	items := make([]*generated.Campaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		items = append(items, utils.ConvertCampaign(campaign))
+	}
+	return &generated.CampaignPage{
+		Items:      items,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// Get returns a campaign.
+func (s *CampaignService) Get(ctx context.Context, campaignID string) (*generated.Campaign, error) {
+	campaign, err := s.get(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertCampaign(campaign), nil
+}
+
+// Create adds a campaign.
+func (s *CampaignService) Create(ctx context.Context, input generated.CreateCampaignInput) (*generated.Campaign, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	campaign := models.Campaign{
+		ID:               uuid.New(),
+		CampaignName:     input.CampaignName,
+		CampaignCountry:  input.CampaignCountry,
+		CampaignRegion:   input.CampaignRegion,
+		IndustryTargeted: input.IndustryTargeted,
+	}
+	if err := s.campaigns.Create(ctx, &campaign); err != nil {
+		return nil, internalError(err, "create campaign")
+	}
+	return utils.ConvertCampaign(campaign), nil
+}
+
+// Update changes the fields set in input.
+func (s *CampaignService) Update(ctx context.Context, campaignID string, input generated.UpdateCampaignInput) (*generated.Campaign, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	campaign, err := s.get(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.CampaignName != nil {
+		campaign.CampaignName = *input.CampaignName
+	}
+	if input.CampaignCountry != nil {
+		campaign.CampaignCountry = *input.CampaignCountry
+	}
+	if input.CampaignRegion != nil {
+		campaign.CampaignRegion = *input.CampaignRegion
+	}
+	if input.IndustryTargeted != nil {
+		campaign.IndustryTargeted = *input.IndustryTargeted
+	}
+
+	if err := s.campaigns.Update(ctx, &campaign); err != nil {
+		return nil, internalError(err, "update campaign")
+	}
+	return utils.ConvertCampaign(campaign), nil
+}
+
+// Delete deletes a campaign.
+func (s *CampaignService) Delete(ctx context.Context, campaignID string) (*generated.Campaign, error) {
+	campaign, err := s.get(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.campaigns.Delete(ctx, &campaign); err != nil {
+		return nil, internalError(err, "delete campaign")
+	}
+	return utils.ConvertCampaign(campaign), nil
+}
+
+// AddMember adds a user to a campaign.
+func (s *CampaignService) AddMember(ctx context.Context, userID string, campaignID string) (*generated.Campaign, error) {
+	user, campaign, err := s.membership(ctx, userID, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.campaigns.AddMember(ctx, &campaign, &user); err != nil {
+		return nil, internalError(err, "add user to campaign")
+	}
+	return utils.ConvertCampaign(campaign), nil
+}
+
+// RemoveMember removes a user from a campaign they are a member of.
+func (s *CampaignService) RemoveMember(ctx context.Context, userID string, campaignID string) (*generated.Campaign, error) {
+	user, campaign, err := s.membership(ctx, userID, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	isMember, err := s.campaigns.IsMember(ctx, campaign.ID, user.ID)
+	if err != nil {
+		return nil, internalError(err, "check user in campaign")
+	}
+	if !isMember {
+		return nil, apperr.Forbidden("user is not part of this campaign")
+	}
+	if err := s.campaigns.RemoveMember(ctx, &campaign, &user); err != nil {
+		return nil, internalError(err, "remove user from campaign")
+	}
+	return utils.ConvertCampaign(campaign), nil
+}
+
+// membership returns the user and campaign a membership change is about.
+func (s *CampaignService) membership(ctx context.Context, userID string, campaignID string) (models.User, models.Campaign, error) {
+	user, err := s.users.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, models.Campaign{}, apperr.NotFound("user not found")
+		}
+		return models.User{}, models.Campaign{}, internalError(err, "find user")
+	}
+	campaign, err := s.get(ctx, campaignID)
+	if err != nil {
+		return models.User{}, models.Campaign{}, err
+	}
+	return user, campaign, nil
+}
+
+func (s *CampaignService) get(ctx context.Context, campaignID string) (models.Campaign, error) {
+	campaign, err := s.campaigns.Get(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Campaign{}, apperr.NotFound("campaign with ID %s not found", campaignID)
+		}
+		return models.Campaign{}, internalError(err, "fetch campaign")
+	}
+	return campaign, nil
+}
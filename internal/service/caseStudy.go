@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CaseStudyService manages case studies.
+type CaseStudyService struct {
+	caseStudies repository.CaseStudyRepository
+}
+
+// NewCaseStudyService returns a CaseStudyService storing case studies in caseStudies.
+func NewCaseStudyService(caseStudies repository.CaseStudyRepository) *CaseStudyService {
+	return &CaseStudyService{caseStudies: caseStudies}
+}
+
+// List returns a page of case studies.
+func (s *CaseStudyService) List(ctx context.Context, filter *generated.CaseStudyFilter, pagination *generated.PaginationInput, sort *generated.CaseStudySortInput) (*generated.CaseStudyPage, error) {
+	var caseStudyFilter repository.CaseStudyFilter
+	if filter != nil {
+		caseStudyFilter = repository.CaseStudyFilter{
+			ProjectName:    value(filter.ProjectName),
+			ClientName:     value(filter.ClientName),
+			TechStack:      value(filter.TechStack),
+			IndustryTarget: value(filter.IndustryTarget),
+			Tags:           value(filter.Tags),
+			Search:         value(filter.Search),
+		}
+	}
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	caseStudies, totalCount, err := s.caseStudies.List(ctx, caseStudyFilter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch case studies")
+	}
+
+	items := make([]*generated.CaseStudy, 0, len(caseStudies))
+	for _, caseStudy := range caseStudies {
+		items = append(items, utils.ConvertCaseStudy(caseStudy))
+	}
+	return &generated.CaseStudyPage{Items: items, TotalCount: int32(totalCount)}, nil
+}
+
+// Get returns a case study.
+func (s *CaseStudyService) Get(ctx context.Context, caseStudyID string) (*generated.CaseStudy, error) {
+	caseStudy, err := s.get(ctx, caseStudyID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertCaseStudy(caseStudy), nil
+}
+
+// Create adds a case study. It needs a project and a client name.
+func (s *CaseStudyService) Create(ctx context.Context, input generated.CreateCaseStudyInput) (*generated.CaseStudy, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	if input.ProjectName == "" || input.ClientName == "" {
+		return nil, apperr.Invalid("project name and client name are required")
+	}
+
+	caseStudy := models.CaseStudy{
+		ID:              uuid.New(),
+		ProjectName:     input.ProjectName,
+		ClientName:      input.ClientName,
+		TechStack:       input.TechStack,
+		ProjectDuration: input.ProjectDuration,
+		KeyOutcomes:     input.KeyOutcomes,
+		IndustryTarget:  input.IndustryTarget,
+		Tags:            input.Tags,
+		Document:        input.Document,
+	}
+	if err := s.caseStudies.Create(ctx, &caseStudy); err != nil {
+		return nil, internalError(err, "create case study")
+	}
+	return utils.ConvertCaseStudy(caseStudy), nil
+}
+
+// Update changes the fields of a case study that are not empty in input.
+func (s *CaseStudyService) Update(ctx context.Context, caseStudyID string, input generated.UpdateCaseStudyInput) (*generated.CaseStudy, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	caseStudy, err := s.get(ctx, caseStudyID)
+	if err != nil {
+		return nil, err
+	}
+
+	setIfNotEmpty(&caseStudy.ProjectName, &input.ProjectName)
+	setIfNotEmpty(&caseStudy.ClientName, &input.ClientName)
+	setIfNotEmpty(&caseStudy.TechStack, &input.TechStack)
+	setIfNotEmpty(&caseStudy.ProjectDuration, &input.ProjectDuration)
+	setIfNotEmpty(&caseStudy.KeyOutcomes, &input.KeyOutcomes)
+	setIfNotEmpty(&caseStudy.IndustryTarget, &input.IndustryTarget)
+	setIfNotEmpty(&caseStudy.Tags, &input.Tags)
+	setIfNotEmpty(&caseStudy.Document, &input.Document)
+
+	if err := s.caseStudies.Update(ctx, &caseStudy); err != nil {
+		return nil, internalError(err, "update case study")
+	}
+	return utils.ConvertCaseStudy(caseStudy), nil
+}
+
+// Delete deletes a case study.
+func (s *CaseStudyService) Delete(ctx context.Context, caseStudyID string) (*generated.CaseStudy, error) {
+	caseStudy, err := s.get(ctx, caseStudyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.caseStudies.Delete(ctx, &caseStudy); err != nil {
+		return nil, internalError(err, "delete case study")
+	}
+	return utils.ConvertCaseStudy(caseStudy), nil
+}
+
+func (s *CaseStudyService) get(ctx context.Context, caseStudyID string) (models.CaseStudy, error) {
+	if _, err := uuid.Parse(caseStudyID); err != nil {
+		return models.CaseStudy{}, apperr.InvalidField("caseStudyID", "invalid case study ID")
+	}
+	caseStudy, err := s.caseStudies.Get(ctx, caseStudyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.CaseStudy{}, apperr.NotFound("case study with ID %s not found", caseStudyID)
+		}
+		return models.CaseStudy{}, internalError(err, "fetch case study")
+	}
+	return caseStudy, nil
+}
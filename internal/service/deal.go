@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/events"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DealService manages deals. A deal is read and changed by those who may read
+// and change its lead.
+type DealService struct {
+	deals repository.DealRepository
+	leads repository.LeadRepository
+}
+
+// NewDealService returns a DealService storing deals in deals and looking
+// their leads up in leads.
+func NewDealService(deals repository.DealRepository, leads repository.LeadRepository) *DealService {
+	return &DealService{deals: deals, leads: leads}
+}
+
+// List returns a page of deals. Amount bounds only match deals in the bound's own currency.
+func (s *DealService) List(ctx context.Context, filter *generated.DealFilter, pagination *generated.PaginationInput, sort *generated.DealSortInput) ([]*generated.Deal, error) {
+	var dealFilter repository.DealFilter
+	if filter != nil {
+		if filter.MinAmount != nil && filter.MaxAmount != nil && filter.MinAmount.Currency != filter.MaxAmount.Currency {
+			return nil, apperr.Invalid("minAmount and maxAmount must use the same currency")
+		}
+		dealFilter = repository.DealFilter{
+			Name:      value(filter.DealName),
+			LeadID:    value(filter.LeadID),
+			Status:    value(filter.DealStatus),
+			Amount:    filter.DealAmount,
+			MinAmount: filter.MinAmount,
+			MaxAmount: filter.MaxAmount,
+			Search:    value(filter.Search),
+		}
+	}
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	deals, err := s.deals.List(ctx, dealFilter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch deals")
+	}
+
+	result := make([]*generated.Deal, 0, len(deals))
+	for _, deal := range deals {
+		result = append(result, utils.ConvertDeal(deal))
+	}
+	return result, nil
+}
+
+// Get returns a deal.
+func (s *DealService) Get(ctx context.Context, dealID string) (*generated.Deal, error) {
+	deal, err := s.get(ctx, auth.LeadActionRead, dealID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertDeal(deal), nil
+}
+
+// Create adds a deal to a lead the caller may change.
+func (s *DealService) Create(ctx context.Context, input generated.CreateDealInput) (*generated.Deal, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	parsedDealStartDate, err := time.Parse(time.RFC3339, input.DealStartDate)
+	if err != nil {
+		return nil, apperr.InvalidField("dealStartDate", "invalid DealStartDate format: %v", err)
+	}
+	parsedDealEndDate, err := time.Parse(time.RFC3339, input.DealEndDate)
+	if err != nil {
+		return nil, apperr.InvalidField("dealEndDate", "invalid DealEndDate format: %v", err)
+	}
+	parsedLeadID, err := uuid.Parse(input.LeadID)
+	if err != nil {
+		return nil, apperr.InvalidField("leadID", "invalid LeadID: %v", err)
+	}
+	if err := s.checkLead(ctx, input.LeadID); err != nil {
+		return nil, err
+	}
+
+	deal := models.Deal{
+		LeadID:        parsedLeadID,
+		DealName:      input.DealName,
+		DealAmount:    input.DealAmount,
+		DealStartDate: parsedDealStartDate,
+		DealEndDate:   parsedDealEndDate,
+		DealStatus:    input.DealStatus.String(),
+	}
+	if err := s.deals.Create(ctx, &deal); err != nil {
+		return nil, internalError(err, "create deal")
+	}
+
+	result := utils.ConvertDeal(deal)
+	events.Publish(events.Event{Type: events.DealCreated, EntityID: deal.ID, ActorID: events.ActorOf(ctx), Data: result})
+	return result, nil
+}
+
+// Update replaces the fields of a deal with input.
+func (s *DealService) Update(ctx context.Context, dealID string, input generated.UpdateDealInput) (*generated.Deal, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	deal, err := s.get(ctx, auth.LeadActionWrite, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedLeadID, err := uuid.Parse(input.LeadID)
+	if err != nil {
+		return nil, apperr.InvalidField("leadID", "invalid LeadID: %v", err)
+	}
+	if parsedLeadID != deal.LeadID {
+		if err := s.checkLead(ctx, input.LeadID); err != nil {
+			return nil, err
+		}
+	}
+	parsedDealStartDate, err := time.Parse(time.RFC3339, input.DealStartDate)
+	if err != nil {
+		return nil, apperr.InvalidField("dealStartDate", "invalid DealStartDate format: %v", err)
+	}
+	parsedDealEndDate, err := time.Parse(time.RFC3339, input.DealEndDate)
+	if err != nil {
+		return nil, apperr.InvalidField("dealEndDate", "invalid DealEndDate format: %v", err)
+	}
+
+	oldStatus := deal.DealStatus
+	deal.DealName = input.DealName
+	deal.DealAmount = input.DealAmount
+	deal.LeadID = parsedLeadID
+	deal.ProjectRequirements = input.ProjectRequirements
+	deal.DealStartDate = parsedDealStartDate
+	deal.DealEndDate = parsedDealEndDate
+	deal.DealStatus = input.DealStatus.String()
+
+	if err := s.deals.Update(ctx, &deal); err != nil {
+		return nil, internalError(err, "update deal")
+	}
+
+	result := utils.ConvertDeal(deal)
+	actorID := events.ActorOf(ctx)
+	events.Publish(events.Event{Type: events.DealUpdated, EntityID: deal.ID, ActorID: actorID, Data: result})
+	if deal.DealStatus != oldStatus {
+		events.Publish(events.Event{
+			Type:     events.DealStatusChanged,
+			EntityID: deal.ID,
+			ActorID:  actorID,
+			From:     oldStatus,
+			To:       deal.DealStatus,
+			Data:     result,
+		})
+	}
+	return result, nil
+}
+
+// Delete deletes a deal.
+func (s *DealService) Delete(ctx context.Context, dealID string) (*generated.Deal, error) {
+	deal, err := s.get(ctx, auth.LeadActionDelete, dealID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deals.Delete(ctx, &deal); err != nil {
+		return nil, internalError(err, "delete deal")
+	}
+
+	result := utils.ConvertDeal(deal)
+	events.Publish(events.Event{Type: events.DealDeleted, EntityID: deal.ID, ActorID: events.ActorOf(ctx), Data: result})
+	return result, nil
+}
+
+// get returns a deal whose lead the caller may perform action on.
+func (s *DealService) get(ctx context.Context, action string, dealID string) (models.Deal, error) {
+	if _, err := uuid.Parse(dealID); err != nil {
+		return models.Deal{}, apperr.InvalidField("dealID", "invalid DealID: %v", err)
+	}
+	deal, err := s.deals.Get(ctx, action, dealID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Deal{}, apperr.NotFound("deal not found")
+		}
+		return models.Deal{}, internalError(err, "fetch deal")
+	}
+	return deal, nil
+}
+
+// checkLead fails unless the lead with leadID exists and the caller may change it.
+func (s *DealService) checkLead(ctx context.Context, leadID string) error {
+	if _, err := s.leads.Get(ctx, auth.LeadActionWrite, leadID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperr.NotFound("lead with ID %s does not exist", leadID)
+		}
+		return internalError(err, "check lead existence")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// ExchangeRateService manages the rates amounts in other currencies are
+// converted to the base currency with.
+type ExchangeRateService struct {
+	rates repository.ExchangeRateRepository
+}
+
+// NewExchangeRateService returns an ExchangeRateService storing rates in rates.
+func NewExchangeRateService(rates repository.ExchangeRateRepository) *ExchangeRateService {
+	return &ExchangeRateService{rates: rates}
+}
+
+// List returns every rate by currency.
+func (s *ExchangeRateService) List(ctx context.Context) ([]*generated.ExchangeRate, error) {
+	rates, err := s.rates.List(ctx)
+	if err != nil {
+		return nil, internalError(err, "fetch exchange rates")
+	}
+	result := make([]*generated.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		result = append(result, utils.ConvertExchangeRate(rate))
+	}
+	return result, nil
+}
+
+// Set adds the rate of currency or changes it. The base currency has no rate.
+func (s *ExchangeRateService) Set(ctx context.Context, currency string, rateToBase string) (*generated.ExchangeRate, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if _, err := models.NewMoney(decimal.Zero, currency); err != nil {
+		return nil, err
+	}
+	if currency == models.BaseCurrency() {
+		return nil, apperr.Invalid("%s is the base currency and always has a rate of 1", currency)
+	}
+	rate, err := decimal.NewFromString(strings.TrimSpace(rateToBase))
+	if err != nil || !rate.IsPositive() {
+		return nil, apperr.Invalid("invalid rateToBase %q, expected a positive decimal", rateToBase)
+	}
+
+	exchangeRate, err := s.rates.Get(ctx, currency)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, internalError(err, "fetch exchange rate")
+	}
+	exchangeRate.Currency = currency
+	exchangeRate.RateToBase = rate
+	if err := s.rates.Save(ctx, &exchangeRate); err != nil {
+		return nil, internalError(err, "save exchange rate")
+	}
+	return utils.ConvertExchangeRate(exchangeRate), nil
+}
+
+// Delete removes the rate of currency.
+func (s *ExchangeRateService) Delete(ctx context.Context, currency string) (*generated.ExchangeRate, error) {
+	exchangeRate, err := s.rates.Get(ctx, strings.ToUpper(strings.TrimSpace(currency)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("exchange rate not found")
+		}
+		return nil, internalError(err, "fetch exchange rate")
+	}
+	if err := s.rates.Delete(ctx, &exchangeRate); err != nil {
+		return nil, internalError(err, "delete exchange rate")
+	}
+	return utils.ConvertExchangeRate(exchangeRate), nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExportJobService shows the export jobs of the current user.
+type ExportJobService struct {
+	jobs repository.ExportJobRepository
+}
+
+// NewExportJobService returns an ExportJobService reading jobs from jobs.
+func NewExportJobService(jobs repository.ExportJobRepository) *ExportJobService {
+	return &ExportJobService{jobs: jobs}
+}
+
+// List returns the newest jobs of the current user.
+func (s *ExportJobService) List(ctx context.Context) ([]*generated.ExportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.jobs.List(ctx, userID)
+	if err != nil {
+		return nil, internalError(err, "fetch export jobs")
+	}
+	result := make([]*generated.ExportJob, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, utils.ConvertExportJob(job))
+	}
+	return result, nil
+}
+
+// Get returns a job of the current user.
+func (s *ExportJobService) Get(ctx context.Context, jobID string) (*generated.ExportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(jobID); err != nil {
+		return nil, apperr.InvalidField("jobID", "invalid jobID: %v", err)
+	}
+	job, err := s.jobs.Get(ctx, jobID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("export job not found")
+		}
+		return nil, internalError(err, "fetch export job")
+	}
+	return utils.ConvertExportJob(job), nil
+}
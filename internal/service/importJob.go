@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportJobService shows the import jobs of the current user and commits their
+// dry runs.
+type ImportJobService struct {
+	jobs repository.ImportJobRepository
+}
+
+// NewImportJobService returns an ImportJobService reading jobs from jobs.
+func NewImportJobService(jobs repository.ImportJobRepository) *ImportJobService {
+	return &ImportJobService{jobs: jobs}
+}
+
+// List returns the newest jobs of the current user.
+func (s *ImportJobService) List(ctx context.Context) ([]*generated.ImportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.jobs.List(ctx, userID)
+	if err != nil {
+		return nil, internalError(err, "fetch import jobs")
+	}
+	result := make([]*generated.ImportJob, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, utils.ConvertImportJob(job))
+	}
+	return result, nil
+}
+
+// Get returns a job of the current user.
+func (s *ImportJobService) Get(ctx context.Context, jobID string) (*generated.ImportJob, error) {
+	job, err := s.get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertImportJob(job), nil
+}
+
+// Rows returns the rows of a job, only those with status if it is set.
+func (s *ImportJobService) Rows(ctx context.Context, jobID string, status *generated.ImportRowStatus) ([]*generated.ImportRow, error) {
+	var rowStatus string
+	if status != nil {
+		rowStatus = status.String()
+	}
+	rows, err := s.jobs.Rows(ctx, jobID, rowStatus)
+	if err != nil {
+		return nil, internalError(err, "fetch import rows")
+	}
+	return utils.ConvertImportRows(rows), nil
+}
+
+// Commit runs a previewed dry run of the current user for real, creating the
+// records through services. It needs the permission to create the records, as
+// the upload did.
+func (s *ImportJobService) Commit(ctx context.Context, jobID string, services importer.Services) (*generated.ImportJob, error) {
+	parsedJobID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, apperr.InvalidField("jobID", "invalid jobID: %v", err)
+	}
+	job, err := s.get(ctx, parsedJobID.String())
+	if err != nil {
+		return nil, err
+	}
+	if permission, err := importer.Permission(job.Entity); err != nil {
+		return nil, apperr.NotFound("import job not found")
+	} else if err := auth.RequirePermission(ctx, permission); err != nil {
+		return nil, err
+	}
+
+	committed, err := s.jobs.Commit(ctx, services, parsedJobID)
+	if err != nil {
+		return nil, internalError(err, "commit import job")
+	}
+	return utils.ConvertImportJob(*committed), nil
+}
+
+func (s *ImportJobService) get(ctx context.Context, jobID string) (models.ImportJob, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	if _, err := uuid.Parse(jobID); err != nil {
+		return models.ImportJob{}, apperr.InvalidField("jobID", "invalid jobID: %v", err)
+	}
+	job, err := s.jobs.Get(ctx, jobID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ImportJob{}, apperr.NotFound("import job not found")
+		}
+		return models.ImportJob{}, internalError(err, "fetch import job")
+	}
+	return job, nil
+}
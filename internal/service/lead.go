@@ -0,0 +1,444 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/events"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LeadService manages leads. Callers only see and change the leads their
+// permissions allow, see auth.LeadScope.
+type LeadService struct {
+	leads         repository.LeadRepository
+	users         repository.UserRepository
+	organizations repository.OrganizationRepository
+	campaigns     repository.CampaignRepository
+}
+
+// NewLeadService returns a LeadService storing leads in leads and looking up
+// their owners, organizations and campaigns in the other repositories.
+func NewLeadService(leads repository.LeadRepository, users repository.UserRepository, organizations repository.OrganizationRepository, campaigns repository.CampaignRepository) *LeadService {
+	return &LeadService{leads: leads, users: users, organizations: organizations, campaigns: campaigns}
+}
+
+// leadFilter maps a GraphQL lead filter to the repository's.
+func leadFilter(filter *generated.LeadFilter) repository.LeadFilter {
+	if filter == nil {
+		return repository.LeadFilter{}
+	}
+	return repository.LeadFilter{
+		Name:   value(filter.Name),
+		Email:  value(filter.Email),
+		TeamID: value(filter.TeamID),
+	}
+}
+
+// List returns a page of the leads the caller may read.
+func (s *LeadService) List(ctx context.Context, filter *generated.LeadFilter, pagination *generated.PaginationInput, sort *generated.LeadSortInput) (*generated.LeadPage, error) {
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	leads, totalCount, err := s.leads.List(ctx, leadFilter(filter), order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch leads")
+	}
+
+	items := make([]*generated.Lead, 0, len(leads))
+	for _, lead := range leads {
+		items = append(items, utils.ConvertLead(lead))
+	}
+	return &generated.LeadPage{
+		Items:      items,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// Connection returns the leads the caller may read after a cursor.
+func (s *LeadService) Connection(ctx context.Context, filter *generated.LeadFilter, first *int32, after *string) (*generated.LeadConnection, error) {
+	position, err := cursor(first, after)
+	if err != nil {
+		return nil, err
+	}
+	leads, err := s.leads.Page(ctx, leadFilter(filter), position)
+	if err != nil {
+		return nil, internalError(err, "fetch leads")
+	}
+
+	leads, cursors, pageInfo := utils.KeysetPage(leads, position.Size, after, func(lead models.Lead) string {
+		return utils.EncodeCursor(lead.CreatedAt, lead.ID)
+	})
+	edges := make([]*generated.LeadEdge, 0, len(leads))
+	for i, lead := range leads {
+		edges = append(edges, &generated.LeadEdge{Node: utils.ConvertLead(lead), Cursor: cursors[i]})
+	}
+	return &generated.LeadConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
+
+// Get returns a lead the caller may read.
+func (s *LeadService) Get(ctx context.Context, leadID string) (*generated.Lead, error) {
+	lead, err := s.get(ctx, auth.LeadActionRead, leadID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertLead(lead), nil
+}
+
+// ByCampaign returns the leads of a campaign the caller may read.
+func (s *LeadService) ByCampaign(ctx context.Context, campaignID string) ([]*generated.Lead, error) {
+	leads, err := s.leads.ByCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, internalError(err, "fetch campaign leads")
+	}
+	result := make([]*generated.Lead, 0, len(leads))
+	for _, lead := range leads {
+		result = append(result, utils.ConvertLead(lead))
+	}
+	return result, nil
+}
+
+// Create adds a lead owned by the given user, or by whoever the assignment rules pick.
+func (s *LeadService) Create(ctx context.Context, input generated.CreateLeadInput) (*generated.Lead, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	return s.create(ctx, input, nil)
+}
+
+// CreateWithActivity adds a lead like Create together with its first activity.
+func (s *LeadService) CreateWithActivity(ctx context.Context, input generated.CreateLeadWithActivityInput) (*generated.Lead, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	dateTime, err := time.Parse(time.RFC3339, input.DateTime)
+	if err != nil {
+		return nil, apperr.InvalidField("dateTime", "invalid DateTime format: %v", err)
+	}
+	activity := models.Activity{
+		ID:                   uuid.New(),
+		ActivityType:         input.ActivityType,
+		DateTime:             dateTime,
+		CommunicationChannel: input.CommunicationChannel,
+		ContentNotes:         input.ContentNotes,
+		ParticipantDetails:   input.ParticipantDetails,
+		FollowUpActions:      input.FollowUpActions,
+	}
+	return s.create(ctx, generated.CreateLeadInput{
+		FirstName:          input.FirstName,
+		LastName:           input.LastName,
+		Email:              input.Email,
+		LinkedIn:           input.LinkedIn,
+		Country:            input.Country,
+		Phone:              input.Phone,
+		LeadSource:         input.LeadSource,
+		InitialContactDate: input.InitialContactDate,
+		LeadAssignedTo:     input.LeadAssignedTo,
+		LeadStage:          input.LeadStage,
+		LeadNotes:          input.LeadNotes,
+		LeadPriority:       input.LeadPriority,
+		LeadType:           input.LeadType,
+		OrganizationID:     input.OrganizationID,
+		CampaignID:         input.CampaignID,
+	}, &activity)
+}
+
+func (s *LeadService) create(ctx context.Context, input generated.CreateLeadInput, activity *models.Activity) (*generated.Lead, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the given owner, otherwise the assignment rules pick one when the lead is saved
+	var manualAssignee *models.User
+	if input.LeadAssignedTo != nil && *input.LeadAssignedTo != "" {
+		assignee, err := s.assignee(ctx, *input.LeadAssignedTo)
+		if err != nil {
+			return nil, err
+		}
+		manualAssignee = &assignee
+	}
+
+	organization, err := s.organizations.Get(ctx, input.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("organization not found")
+		}
+		return nil, internalError(err, "fetch organization")
+	}
+	campaign, err := s.campaigns.Get(ctx, input.CampaignID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("campaign not found")
+		}
+		return nil, internalError(err, "fetch campaign")
+	}
+	createdBy, err := s.users.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("created by user not found")
+		}
+		return nil, internalError(err, "fetch user")
+	}
+	initialContactDate, err := time.Parse("2006-01-02", input.InitialContactDate)
+	if err != nil {
+		return nil, apperr.InvalidField("initialContactDate", "invalid initialContactDate format, expected YYYY-MM-DD: %v", err)
+	}
+
+	lead := models.Lead{
+		ID:                 uuid.New(),
+		FirstName:          input.FirstName,
+		LastName:           input.LastName,
+		Email:              input.Email,
+		LinkedIn:           input.LinkedIn,
+		Country:            input.Country,
+		Phone:              input.Phone,
+		LeadSource:         input.LeadSource,
+		InitialContactDate: initialContactDate,
+		LeadCreatedBy:      createdBy.ID,
+		LeadStage:          models.LeadStage(input.LeadStage),
+		LeadNotes:          input.LeadNotes,
+		LeadPriority:       input.LeadPriority.String(),
+		OrganizationID:     organization.ID,
+		CampaignID:         campaign.ID,
+	}
+	assignee, err := s.leads.Create(ctx, &lead, manualAssignee, activity)
+	if err != nil {
+		return nil, internalError(err, "create lead")
+	}
+
+	lead.Creator = createdBy
+	lead.Assignee = assignee
+	lead.Organization = organization
+	lead.Campaign = campaign
+	if activity != nil {
+		lead.Activities = []models.Activity{*activity}
+	}
+	result := utils.ConvertLead(lead)
+	actorID := events.ActorOf(ctx)
+	events.Publish(events.Event{Type: events.LeadCreated, EntityID: lead.ID, ActorID: actorID, CampaignID: lead.CampaignID, Data: result})
+	events.Publish(events.Event{Type: events.LeadAssigned, EntityID: lead.ID, UserID: lead.LeadAssignedTo, ActorID: actorID, CampaignID: lead.CampaignID, Data: result})
+	return result, nil
+}
+
+// Update changes a lead the caller may write. Reassigning it is limited by the
+// caller's lead:assign scope; moving it to another stage is recorded in its
+// stage history, and winning it opens a deal.
+func (s *LeadService) Update(ctx context.Context, leadID string, input generated.UpdateLeadInput) (*generated.Lead, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	lead, err := s.get(ctx, auth.LeadActionWrite, leadID)
+	if err != nil {
+		return nil, err
+	}
+	oldStage := lead.LeadStage
+
+	var change repository.LeadChange
+	if userID, err := auth.CurrentUserID(ctx); err == nil {
+		if parsedUserID, err := uuid.Parse(userID); err == nil {
+			change.ChangedBy = &parsedUserID
+		}
+	}
+	if input.LeadAssignedTo != "" && input.LeadAssignedTo != lead.LeadAssignedTo.String() {
+		assignee, err := s.assignee(ctx, input.LeadAssignedTo)
+		if err != nil {
+			return nil, err
+		}
+		lead.LeadAssignedTo = assignee.ID
+		lead.Assignee = assignee
+		change.Reassigned = &assignee
+	}
+
+	// Fields left empty keep their value
+	setIfNotEmpty(&lead.FirstName, input.FirstName)
+	setIfNotEmpty(&lead.LastName, input.LastName)
+	setIfNotEmpty(&lead.Email, &input.Email)
+	setIfNotEmpty(&lead.LinkedIn, input.LinkedIn)
+	setIfNotEmpty(&lead.Country, input.Country)
+	setIfNotEmpty(&lead.Phone, input.Phone)
+	setIfNotEmpty(&lead.LeadSource, &input.LeadSource)
+	if input.LeadStage != "" && models.LeadStage(input.LeadStage) != oldStage {
+		lead.LeadStage = models.LeadStage(input.LeadStage)
+		change.OldStage = &oldStage
+		change.Won = lead.LeadStage == models.LeadStageClosedWon
+	}
+
+	deal, err := s.leads.Update(ctx, &lead, change)
+	if err != nil {
+		return nil, internalError(err, "update lead")
+	}
+
+	result := utils.ConvertLead(lead)
+	actorID := events.ActorOf(ctx)
+	events.Publish(events.Event{Type: events.LeadUpdated, EntityID: lead.ID, ActorID: actorID, CampaignID: lead.CampaignID, Data: result})
+	if change.Reassigned != nil {
+		events.Publish(events.Event{Type: events.LeadAssigned, EntityID: lead.ID, UserID: lead.LeadAssignedTo, ActorID: actorID, CampaignID: lead.CampaignID, Data: result})
+	}
+	if change.OldStage != nil {
+		events.Publish(events.Event{
+			Type:       events.LeadStageChanged,
+			EntityID:   lead.ID,
+			ActorID:    actorID,
+			CampaignID: lead.CampaignID,
+			From:       string(oldStage),
+			To:         string(lead.LeadStage),
+			Data:       result,
+		})
+	}
+	if deal != nil {
+		events.Publish(events.Event{Type: events.DealCreated, EntityID: deal.ID, ActorID: actorID, Data: utils.ConvertDeal(*deal)})
+	}
+	return result, nil
+}
+
+// Delete moves a lead the caller may delete to the trash, with its activities.
+func (s *LeadService) Delete(ctx context.Context, leadID string) (*generated.Lead, error) {
+	lead, err := s.get(ctx, auth.LeadActionDelete, leadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.leads.Delete(ctx, lead.ID); err != nil {
+		return nil, internalError(err, "delete lead")
+	}
+
+	result := &generated.Lead{
+		LeadID:    lead.ID.String(),
+		FirstName: lead.FirstName,
+		LastName:  lead.LastName,
+		Email:     lead.Email,
+		LinkedIn:  lead.LinkedIn,
+		Country:   lead.Country,
+		CreatedAt: lead.CreatedAt.Format(time.RFC3339),
+	}
+	events.Publish(events.Event{Type: events.LeadDeleted, EntityID: lead.ID, ActorID: events.ActorOf(ctx), CampaignID: lead.CampaignID, Data: result})
+	return result, nil
+}
+
+// Merge moves everything attached to the duplicates onto the survivor and
+// deletes them. The caller must be able to write the survivor and delete every duplicate.
+func (s *LeadService) Merge(ctx context.Context, survivorID string, duplicateIDs []string) (*generated.Lead, error) {
+	survivorID, duplicateIDs, err := mergeIDs(survivorID, duplicateIDs)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(duplicateIDs, survivorID) {
+		return nil, apperr.Invalid("a lead cannot be merged into itself")
+	}
+	survivor, err := s.get(ctx, auth.LeadActionWrite, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicates, err := s.leads.Find(ctx, auth.LeadActionDelete, duplicateIDs)
+	if err != nil {
+		return nil, internalError(err, "fetch leads")
+	}
+	if len(duplicates) != len(duplicateIDs) {
+		return nil, apperr.NotFound("duplicate lead not found")
+	}
+
+	if err := s.leads.Merge(ctx, &survivor, duplicates); err != nil {
+		return nil, internalError(err, "merge leads")
+	}
+	if survivor, err = s.leads.Get(ctx, auth.LeadActionRead, survivor.ID.String()); err != nil {
+		return nil, internalError(err, "fetch merged lead")
+	}
+
+	result := utils.ConvertLead(survivor)
+	actorID := events.ActorOf(ctx)
+	for _, duplicate := range duplicates {
+		events.Publish(events.Event{Type: events.LeadDeleted, EntityID: duplicate.ID, ActorID: actorID, CampaignID: duplicate.CampaignID, Data: utils.ConvertLead(duplicate)})
+	}
+	events.Publish(events.Event{Type: events.LeadUpdated, EntityID: survivor.ID, ActorID: actorID, CampaignID: survivor.CampaignID, Data: result})
+	return result, nil
+}
+
+// StageHistory returns the stage changes of a lead the caller may read, oldest
+// first, within dateRange if given.
+func (s *LeadService) StageHistory(ctx context.Context, leadID string, dateRange *generated.DateRangeInput) ([]*generated.LeadStageHistory, error) {
+	from, to, err := utils.ParseDateRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	lead, err := s.get(ctx, auth.LeadActionRead, leadID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.leads.StageHistory(ctx, []uuid.UUID{lead.ID})
+	if err != nil {
+		return nil, internalError(err, "fetch lead stage history")
+	}
+
+	// Durations are computed over the full history, then the date range is applied
+	// so an entry keeps its real time-in-stage even if the previous change is out of range.
+	entries := utils.ConvertLeadStageHistory(lead.CreatedAt, history)
+	var result []*generated.LeadStageHistory
+	for i, entry := range entries {
+		changedAt := history[i].ChangedAt
+		if from != nil && changedAt.Before(*from) {
+			continue
+		}
+		if to != nil && !changedAt.Before(*to) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// Duplicates returns the leads the caller may read that are likely the same person as lead.
+func (s *LeadService) Duplicates(ctx context.Context, lead *generated.Lead) ([]*generated.LeadDuplicate, error) {
+	matches, err := s.leads.Duplicates(ctx, utils.DedupeLead(lead))
+	if err != nil {
+		return nil, internalError(err, "check for duplicate leads")
+	}
+	return utils.ConvertLeadMatches(matches), nil
+}
+
+func (s *LeadService) get(ctx context.Context, action string, leadID string) (models.Lead, error) {
+	lead, err := s.leads.Get(ctx, action, leadID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Lead{}, apperr.NotFound("lead not found")
+		}
+		return models.Lead{}, internalError(err, "fetch lead")
+	}
+	return lead, nil
+}
+
+// assignee returns the user with userID if the caller may assign leads to them.
+func (s *LeadService) assignee(ctx context.Context, userID string) (models.User, error) {
+	user, err := s.users.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, apperr.NotFound("assigned user not found")
+		}
+		return models.User{}, internalError(err, "fetch assigned user")
+	}
+	canAssign, err := auth.CanAssignLeadTo(ctx, user.ID, s.users.TeamUserIDs)
+	if err != nil {
+		return models.User{}, internalError(err, "check lead assignment")
+	}
+	if !canAssign {
+		return models.User{}, apperr.Forbidden("unauthorized to assign leads to this user")
+	}
+	return user, nil
+}
+
+// setIfNotEmpty sets *target to *value unless value is nil or empty.
+func setIfNotEmpty(target *string, value *string) {
+	if value != nil && *value != "" {
+		*target = *value
+	}
+}
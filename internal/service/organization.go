@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"gorm.io/gorm"
+)
+
+// OrganizationService manages organizations.
+type OrganizationService struct {
+	organizations repository.OrganizationRepository
+}
+
+// NewOrganizationService returns an OrganizationService storing organizations in organizations.
+func NewOrganizationService(organizations repository.OrganizationRepository) *OrganizationService {
+	return &OrganizationService{organizations: organizations}
+}
+
+// List returns a page of organizations.
+func (s *OrganizationService) List(ctx context.Context, filter *generated.OrganizationFilter, sort *generated.OrganizationSortInput, pagination *generated.PaginationInput) (*generated.OrganizationPage, error) {
+	var organizationFilter repository.OrganizationFilter
+	if filter != nil {
+		organizationFilter = repository.OrganizationFilter{
+			Search:  value(filter.Search),
+			Country: value(filter.Country),
+		}
+		if filter.MinEmployees != nil {
+			minEmployees := int(*filter.MinEmployees)
+			organizationFilter.MinEmployees = &minEmployees
+		}
+		if filter.MaxEmployees != nil {
+			maxEmployees := int(*filter.MaxEmployees)
+			organizationFilter.MaxEmployees = &maxEmployees
+		}
+	}
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	organizations, totalCount, err := s.organizations.List(ctx, organizationFilter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch organizations")
+	}
+
+	items := make([]*generated.Organization, 0, len(organizations))
+	for _, organization := range organizations {
+		items = append(items, utils.ConvertOrganization(organization))
+	}
+	return &generated.OrganizationPage{
+		Items:      items,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// Get returns an organization.
+func (s *OrganizationService) Get(ctx context.Context, organizationID string) (*generated.Organization, error) {
+	organization, err := s.get(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertOrganization(organization), nil
+}
+
+// Create adds an organization.
+func (s *OrganizationService) Create(ctx context.Context, input generated.CreateOrganizationInput) (*generated.Organization, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	organization := models.Organization{
+		OrganizationName:    input.OrganizationName,
+		OrganizationEmail:   input.OrganizationEmail,
+		OrganizationWebsite: value(input.OrganizationWebsite),
+		City:                input.City,
+		Country:             input.Country,
+		NoOfEmployees:       input.NoOfEmployees,
+		AnnualRevenue:       input.AnnualRevenue,
+	}
+	if err := s.organizations.Create(ctx, &organization); err != nil {
+		return nil, internalError(err, "create organization")
+	}
+	return utils.ConvertOrganization(organization), nil
+}
+
+// Update changes the fields set in input on the organization with input.OrganizationID.
+func (s *OrganizationService) Update(ctx context.Context, input generated.UpdateOrganizationInput) (*generated.Organization, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	organization, err := s.get(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.OrganizationName != nil {
+		organization.OrganizationName = *input.OrganizationName
+	}
+	if input.OrganizationEmail != nil {
+		organization.OrganizationEmail = *input.OrganizationEmail
+	}
+	if input.OrganizationWebsite != nil {
+		organization.OrganizationWebsite = *input.OrganizationWebsite
+	}
+	if input.City != nil {
+		organization.City = *input.City
+	}
+	if input.Country != nil {
+		organization.Country = *input.Country
+	}
+	if input.NoOfEmployees != nil {
+		organization.NoOfEmployees = *input.NoOfEmployees
+	}
+	if input.AnnualRevenue != nil {
+		organization.AnnualRevenue = *input.AnnualRevenue
+	}
+
+	if err := s.organizations.Update(ctx, &organization); err != nil {
+		return nil, internalError(err, "update organization")
+	}
+	return utils.ConvertOrganization(organization), nil
+}
+
+// Delete deletes an organization.
+func (s *OrganizationService) Delete(ctx context.Context, organizationID string) (*generated.Organization, error) {
+	organization, err := s.get(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.organizations.Delete(ctx, &organization); err != nil {
+		return nil, internalError(err, "delete organization")
+	}
+	return utils.ConvertOrganization(organization), nil
+}
+
+// Merge moves the leads of the duplicates onto the survivor and deletes them.
+func (s *OrganizationService) Merge(ctx context.Context, survivorID string, duplicateIDs []string) (*generated.Organization, error) {
+	survivorID, duplicateIDs, err := mergeIDs(survivorID, duplicateIDs)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(duplicateIDs, survivorID) {
+		return nil, apperr.Invalid("an organization cannot be merged into itself")
+	}
+	survivor, err := s.get(ctx, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicates, err := s.organizations.Find(ctx, duplicateIDs)
+	if err != nil {
+		return nil, internalError(err, "fetch organizations")
+	}
+	if len(duplicates) != len(duplicateIDs) {
+		return nil, apperr.NotFound("duplicate organization not found")
+	}
+
+	if err := s.organizations.Merge(ctx, &survivor, duplicates); err != nil {
+		return nil, internalError(err, "merge organizations")
+	}
+	return utils.ConvertOrganization(survivor), nil
+}
+
+// Duplicates returns the organizations that are likely the same as organization.
+func (s *OrganizationService) Duplicates(ctx context.Context, organization *generated.Organization) ([]*generated.OrganizationDuplicate, error) {
+	matches, err := s.organizations.Duplicates(ctx, utils.DedupeOrganization(organization))
+	if err != nil {
+		return nil, internalError(err, "check for duplicate organizations")
+	}
+	return utils.ConvertOrganizationMatches(matches), nil
+}
+
+func (s *OrganizationService) get(ctx context.Context, organizationID string) (models.Organization, error) {
+	organization, err := s.organizations.Get(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Organization{}, apperr.NotFound("organization not found")
+		}
+		return models.Organization{}, internalError(err, "fetch organization")
+	}
+	return organization, nil
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ResourceProfileService manages resource profiles.
+type ResourceProfileService struct {
+	profiles repository.ResourceProfileRepository
+	skills   repository.SkillRepository
+}
+
+// NewResourceProfileService returns a ResourceProfileService storing profiles
+// in profiles and looking their skills up in skills.
+func NewResourceProfileService(profiles repository.ResourceProfileRepository, skills repository.SkillRepository) *ResourceProfileService {
+	return &ResourceProfileService{profiles: profiles, skills: skills}
+}
+
+// resourceProfileFilter maps a GraphQL resource profile filter to the repository's.
+func resourceProfileFilter(filter *generated.ResourceProfileFilter) repository.ResourceProfileFilter {
+	if filter == nil {
+		return repository.ResourceProfileFilter{}
+	}
+	result := repository.ResourceProfileFilter{
+		FirstName:          value(filter.FirstName),
+		LastName:           value(filter.LastName),
+		TotalExperienceMin: filter.TotalExperienceMin,
+		TotalExperienceMax: filter.TotalExperienceMax,
+		VendorID:           value(filter.VendorID),
+		SkillIDs:           filter.SkillIDs,
+		Search:             value(filter.Search),
+	}
+	if filter.Type != nil {
+		result.Type = filter.Type.String()
+	}
+	if filter.Status != nil {
+		result.Status = filter.Status.String()
+	}
+	return result
+}
+
+// List returns a page of resource profiles.
+func (s *ResourceProfileService) List(ctx context.Context, filter *generated.ResourceProfileFilter, pagination *generated.PaginationInput, sort *generated.ResourceProfileSortInput) (*generated.ResourceProfilePage, error) {
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	profiles, totalCount, err := s.profiles.List(ctx, resourceProfileFilter(filter), order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch resource profiles")
+	}
+
+	items := make([]*generated.ResourceProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		items = append(items, utils.ConvertResourceProfile(profile))
+	}
+	return &generated.ResourceProfilePage{
+		Items:      items,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// Connection returns the resource profiles after a cursor.
+func (s *ResourceProfileService) Connection(ctx context.Context, filter *generated.ResourceProfileFilter, first *int32, after *string) (*generated.ResourceProfileConnection, error) {
+	position, err := cursor(first, after)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := s.profiles.Page(ctx, resourceProfileFilter(filter), position)
+	if err != nil {
+		return nil, internalError(err, "fetch resource profiles")
+	}
+
+	profiles, cursors, pageInfo := utils.KeysetPage(profiles, position.Size, after, func(profile models.ResourceProfile) string {
+		return utils.EncodeCursor(profile.CreatedAt, profile.ID)
+	})
+	edges := make([]*generated.ResourceProfileEdge, 0, len(profiles))
+	for i, profile := range profiles {
+		edges = append(edges, &generated.ResourceProfileEdge{Node: utils.ConvertResourceProfile(profile), Cursor: cursors[i]})
+	}
+	return &generated.ResourceProfileConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
+
+// Get returns a resource profile with its skills, vendor and past projects.
+func (s *ResourceProfileService) Get(ctx context.Context, resourceProfileID string) (*generated.ResourceProfile, error) {
+	profile, err := s.get(ctx, resourceProfileID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertResourceProfile(profile), nil
+}
+
+// Create adds a resource profile with the skills of input.
+func (s *ResourceProfileService) Create(ctx context.Context, input generated.CreateResourceProfileInput) (*generated.ResourceProfile, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	profile := models.ResourceProfile{
+		ID:              uuid.New(),
+		Type:            models.ResourceType(input.Type),
+		FirstName:       input.FirstName,
+		LastName:        input.LastName,
+		TotalExperience: input.TotalExperience,
+		Status:          models.ResourceStatus(input.Status),
+		GoogleDriveLink: input.GoogleDriveLink,
+	}
+	if input.ContactInformation != "" {
+		profile.ContactInformation = []byte(input.ContactInformation)
+	}
+	if input.VendorID != nil && *input.VendorID != "" {
+		vendorID, err := uuid.Parse(*input.VendorID)
+		if err != nil {
+			return nil, apperr.InvalidField("vendorID", "invalid vendor ID: %v", err)
+		}
+		profile.VendorID = vendorID
+	}
+	for _, skillInput := range input.SkillInputs {
+		skillID, err := uuid.Parse(skillInput.SkillID)
+		if err != nil {
+			return nil, apperr.InvalidField("skillID", "invalid skill ID: %v", err)
+		}
+		profile.ResourceSkills = append(profile.ResourceSkills, models.ResourceSkill{
+			ResourceProfileID: profile.ID,
+			SkillID:           skillID,
+			ExperienceYears:   skillInput.ExperienceYears,
+		})
+	}
+
+	if err := s.profiles.Create(ctx, &profile); err != nil {
+		return nil, internalError(err, "create resource profile")
+	}
+	created, err := s.profiles.Get(ctx, profile.ID.String())
+	if err != nil {
+		return nil, internalError(err, "fetch resource profile")
+	}
+	return utils.ConvertResourceProfile(created), nil
+}
+
+// Update changes the fields set in input. Skills, when set, replace the
+// profile's skills; skills it already had keep their years of experience.
+func (s *ResourceProfileService) Update(ctx context.Context, resourceProfileID string, input generated.UpdateResourceProfileInput) (*generated.ResourceProfile, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	profile, err := s.get(ctx, resourceProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Type != nil {
+		profile.Type = models.ResourceType(*input.Type)
+	}
+	if input.FirstName != nil {
+		profile.FirstName = *input.FirstName
+	}
+	if input.LastName != nil {
+		profile.LastName = *input.LastName
+	}
+	if input.TotalExperience != nil {
+		profile.TotalExperience = *input.TotalExperience
+	}
+	if input.ContactInformation != "" {
+		profile.ContactInformation = []byte(input.ContactInformation)
+	}
+	if input.GoogleDriveLink != nil {
+		profile.GoogleDriveLink = input.GoogleDriveLink
+	}
+	if input.Status != nil {
+		profile.Status = models.ResourceStatus(*input.Status)
+	}
+	if input.VendorID != nil && *input.VendorID != "" {
+		vendorID, err := uuid.Parse(*input.VendorID)
+		if err != nil {
+			return nil, apperr.InvalidField("vendorID", "invalid vendor ID: %v", err)
+		}
+		profile.VendorID = vendorID
+	}
+
+	var skills []models.ResourceSkill
+	if input.SkillIDs != nil {
+		experience := make(map[uuid.UUID]float64, len(profile.ResourceSkills))
+		for _, skill := range profile.ResourceSkills {
+			experience[skill.SkillID] = skill.ExperienceYears
+		}
+		// Not nil, so that an empty list clears the skills
+		skills = []models.ResourceSkill{}
+		for _, skillID := range input.SkillIDs {
+			skill, err := s.skill(ctx, skillID)
+			if err != nil {
+				return nil, err
+			}
+			if slices.ContainsFunc(skills, func(added models.ResourceSkill) bool { return added.SkillID == skill.ID }) {
+				continue
+			}
+			skills = append(skills, models.ResourceSkill{
+				ResourceProfileID: profile.ID,
+				SkillID:           skill.ID,
+				ExperienceYears:   experience[skill.ID],
+				Skill:             skill,
+			})
+		}
+		profile.ResourceSkills = skills
+	}
+
+	if err := s.profiles.Update(ctx, &profile, skills); err != nil {
+		return nil, internalError(err, "update resource profile")
+	}
+	return utils.ConvertResourceProfile(profile), nil
+}
+
+// Delete moves a resource profile to the trash with its skills and projects.
+func (s *ResourceProfileService) Delete(ctx context.Context, resourceProfileID string) (*generated.ResourceProfile, error) {
+	profile, err := s.get(ctx, resourceProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.profiles.Delete(ctx, profile.ID); err != nil {
+		return nil, internalError(err, "delete resource profile")
+	}
+	return utils.ConvertResourceProfile(profile), nil
+}
+
+func (s *ResourceProfileService) get(ctx context.Context, resourceProfileID string) (models.ResourceProfile, error) {
+	if _, err := uuid.Parse(resourceProfileID); err != nil {
+		return models.ResourceProfile{}, apperr.InvalidField("resourceProfileID", "invalid resource profile ID")
+	}
+	profile, err := s.profiles.Get(ctx, resourceProfileID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ResourceProfile{}, apperr.NotFound("resource profile with ID %s not found", resourceProfileID)
+		}
+		return models.ResourceProfile{}, internalError(err, "fetch resource profile")
+	}
+	return profile, nil
+}
+
+// skill returns the skill with skillID.
+func (s *ResourceProfileService) skill(ctx context.Context, skillID string) (models.Skill, error) {
+	if _, err := uuid.Parse(skillID); err != nil {
+		return models.Skill{}, apperr.InvalidField("skillIDs", "invalid skill ID %s", skillID)
+	}
+	skill, err := s.skills.Get(ctx, skillID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Skill{}, apperr.NotFound("skill with ID %s not found", skillID)
+		}
+		return models.Skill{}, internalError(err, "fetch skills")
+	}
+	return skill, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+)
+
+// ScoringRuleService manages the rules leads are scored with.
+type ScoringRuleService struct {
+	rules repository.ScoringRuleRepository
+}
+
+// NewScoringRuleService returns a ScoringRuleService storing rules in rules.
+func NewScoringRuleService(rules repository.ScoringRuleRepository) *ScoringRuleService {
+	return &ScoringRuleService{rules: rules}
+}
+
+// List returns the rules by attribute.
+func (s *ScoringRuleService) List(ctx context.Context) ([]*generated.ScoringRule, error) {
+	rules, err := s.rules.List(ctx)
+	if err != nil {
+		return nil, internalError(err, "fetch scoring rules")
+	}
+	return utils.ConvertScoringRules(rules), nil
+}
+
+// Set replaces every rule with rules, which are all checked first.
+func (s *ScoringRuleService) Set(ctx context.Context, rules []*generated.ScoringRuleInput) ([]*generated.ScoringRule, error) {
+	scoringRules := make([]models.ScoringRule, 0, len(rules))
+	for _, input := range rules {
+		rule := utils.ScoringRuleFromInput(*input)
+		if err := scoring.ValidateRule(rule); err != nil {
+			return nil, err
+		}
+		scoringRules = append(scoringRules, rule)
+	}
+	saved, err := s.rules.Replace(ctx, scoringRules)
+	if err != nil {
+		return nil, internalError(err, "save scoring rules")
+	}
+	return utils.ConvertScoringRules(saved), nil
+}
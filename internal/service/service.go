@@ -0,0 +1,91 @@
+// Package service holds the business logic behind the resolvers: it checks
+// input, reads and writes through the repositories of internal/repository,
+// maps records to GraphQL types and publishes events.
+//
+// Services get their repositories from their constructors, so they can be
+// built on fakes; schema.NewResolver builds them on the database.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+)
+
+// internalError returns err if it is meant for clients, such as an invalid
+// cursor. Otherwise it logs err and returns an internal error saying what failed.
+func internalError(err error, action string) error {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	log.Printf("Error: failed to %s: %v", action, err)
+	return fmt.Errorf("internal error: failed to %s", action)
+}
+
+// page returns the repository page of pagination; without one every row is listed.
+func page(pagination *generated.PaginationInput) repository.Page {
+	if pagination == nil {
+		return repository.Page{}
+	}
+	return repository.Page{
+		Offset: int((pagination.Page - 1) * pagination.PageSize),
+		Limit:  int(pagination.PageSize),
+	}
+}
+
+// sortBy returns the repository sort by field, a GraphQL sort field.
+func sortBy(field string, order generated.SortOrder) repository.Sort {
+	return repository.Sort{Field: field, Desc: order == generated.SortOrderDesc}
+}
+
+// cursor returns the repository cursor of the connection page of first rows after after.
+func cursor(first *int32, after *string) (repository.Cursor, error) {
+	size, err := utils.ConnectionSize(first)
+	if err != nil {
+		return repository.Cursor{}, err
+	}
+	position := repository.Cursor{Size: size}
+	if after != nil && *after != "" {
+		if position.CreatedAt, position.ID, err = utils.DecodeCursor(*after); err != nil {
+			return repository.Cursor{}, err
+		}
+	}
+	return position, nil
+}
+
+// mergeIDs parses the IDs of a merge of duplicateIDs into survivorID and
+// returns them in canonical form, each duplicate once.
+func mergeIDs(survivorID string, duplicateIDs []string) (string, []string, error) {
+	survivor, err := uuid.Parse(survivorID)
+	if err != nil {
+		return "", nil, apperr.InvalidField("survivorID", "invalid survivor ID: %v", err)
+	}
+	seen := make(map[uuid.UUID]bool, len(duplicateIDs))
+	duplicates := make([]string, 0, len(duplicateIDs))
+	for _, duplicateID := range duplicateIDs {
+		id, err := uuid.Parse(duplicateID)
+		if err != nil {
+			return "", nil, apperr.InvalidField("duplicateIDs", "invalid duplicate ID %s", duplicateID)
+		}
+		if !seen[id] {
+			seen[id] = true
+			duplicates = append(duplicates, id.String())
+		}
+	}
+	return survivor.String(), duplicates, nil
+}
+
+// value returns *s, or "" if s is nil.
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
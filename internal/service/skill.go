@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SkillService manages skills.
+type SkillService struct {
+	skills repository.SkillRepository
+}
+
+// NewSkillService returns a SkillService storing skills in skills.
+func NewSkillService(skills repository.SkillRepository) *SkillService {
+	return &SkillService{skills: skills}
+}
+
+// List returns a page of skills, by name unless sorted by name, skillType or createdAt.
+func (s *SkillService) List(ctx context.Context, filter *generated.SkillFilter, pagination *generated.PaginationInput, sort *generated.SkillSortInput) (*generated.SkillPage, error) {
+	var skillFilter repository.SkillFilter
+	if filter != nil {
+		skillFilter = repository.SkillFilter{Name: value(filter.Name), SkillType: value(filter.SkillType)}
+	}
+	var order repository.Sort
+	if sort != nil {
+		// The sort input takes free strings rather than enums
+		switch sort.Field {
+		case "name", "skillType", "createdAt":
+		default:
+			return nil, apperr.InvalidField("sort.field", "invalid sort field: %s", sort.Field)
+		}
+		order = repository.Sort{Field: sort.Field, Desc: strings.EqualFold(sort.Order, "desc")}
+	}
+	skills, totalCount, err := s.skills.List(ctx, skillFilter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch skills")
+	}
+
+	result := make([]*generated.Skill, 0, len(skills))
+	for _, skill := range skills {
+		result = append(result, utils.ConvertSkill(skill))
+	}
+	return &generated.SkillPage{Skills: result, TotalCount: int32(totalCount)}, nil
+}
+
+// Get returns a skill.
+func (s *SkillService) Get(ctx context.Context, skillID string) (*generated.Skill, error) {
+	skill, err := s.get(ctx, skillID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertSkill(skill), nil
+}
+
+// Create adds a skill. Skill names are unique.
+func (s *SkillService) Create(ctx context.Context, input generated.CreateSkillInput) (*generated.Skill, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	if _, err := s.skills.ByName(ctx, input.Name); err == nil {
+		return nil, apperr.Conflict("skill already exists")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, internalError(err, "check skill existence")
+	}
+
+	skill := models.Skill{
+		ID:          uuid.New(),
+		Name:        input.Name,
+		Description: input.Description,
+		SkillType:   models.SkillType(input.Skilltype),
+	}
+	if err := s.skills.Create(ctx, &skill); err != nil {
+		return nil, internalError(err, "create skill")
+	}
+	return utils.ConvertSkill(skill), nil
+}
+
+// Update changes the fields set in input.
+func (s *SkillService) Update(ctx context.Context, skillID string, input generated.UpdateSkillInput) (*generated.Skill, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	skill, err := s.get(ctx, skillID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		skill.Name = *input.Name
+	}
+	if input.Description != nil {
+		skill.Description = input.Description
+	}
+	if input.Skilltype != nil {
+		skill.SkillType = models.SkillType(*input.Skilltype)
+	}
+
+	if err := s.skills.Update(ctx, &skill); err != nil {
+		return nil, internalError(err, "update skill")
+	}
+	return utils.ConvertSkill(skill), nil
+}
+
+// Delete deletes a skill.
+func (s *SkillService) Delete(ctx context.Context, skillID string) (*generated.Skill, error) {
+	skill, err := s.get(ctx, skillID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.skills.Delete(ctx, &skill); err != nil {
+		return nil, internalError(err, "delete skill")
+	}
+	return utils.ConvertSkill(skill), nil
+}
+
+func (s *SkillService) get(ctx context.Context, skillID string) (models.Skill, error) {
+	if _, err := uuid.Parse(skillID); err != nil {
+		return models.Skill{}, apperr.InvalidField("skillID", "invalid skill ID")
+	}
+	skill, err := s.skills.Get(ctx, skillID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Skill{}, apperr.NotFound("skill not found")
+		}
+		return models.Skill{}, internalError(err, "fetch skill")
+	}
+	return skill, nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/events"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskService manages tasks.
+type TaskService struct {
+	tasks repository.TaskRepository
+}
+
+// NewTaskService returns a TaskService storing tasks in tasks.
+func NewTaskService(tasks repository.TaskRepository) *TaskService {
+	return &TaskService{tasks: tasks}
+}
+
+// taskFilter maps a GraphQL task filter to the repository's. DueDate is "YYYY-MM-DD".
+func taskFilter(filter *generated.TaskFilter) (repository.TaskFilter, error) {
+	if filter == nil {
+		return repository.TaskFilter{}, nil
+	}
+	result := repository.TaskFilter{
+		UserID: value(filter.UserID),
+		TeamID: value(filter.TeamID),
+	}
+	if filter.Status != nil {
+		result.Status = filter.Status.String()
+	}
+	if filter.Priority != nil {
+		result.Priority = filter.Priority.String()
+	}
+	if filter.DueDate != nil {
+		parsedTime, err := time.Parse("2006-01-02", *filter.DueDate)
+		if err != nil {
+			return repository.TaskFilter{}, apperr.InvalidField("filter.dueDate", "invalid due date format: %v", err)
+		}
+		result.DueDate = parsedTime.Format("2006-01-02")
+	}
+	return result, nil
+}
+
+// List returns a page of tasks.
+func (s *TaskService) List(ctx context.Context, filter *generated.TaskFilter, pagination *generated.PaginationInput, sort *generated.TaskSortInput) (*generated.TaskPage, error) {
+	taskFilter, err := taskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, taskFilter, pagination, sort)
+}
+
+// ListOwn returns a page of the caller's tasks.
+func (s *TaskService) ListOwn(ctx context.Context, filter *generated.TaskFilter, pagination *generated.PaginationInput, sort *generated.TaskSortInput) (*generated.TaskPage, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	taskFilter, err := taskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	taskFilter.UserID = userID
+	taskFilter.TeamID = ""
+	return s.list(ctx, taskFilter, pagination, sort)
+}
+
+func (s *TaskService) list(ctx context.Context, filter repository.TaskFilter, pagination *generated.PaginationInput, sort *generated.TaskSortInput) (*generated.TaskPage, error) {
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	tasks, totalCount, err := s.tasks.List(ctx, filter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch tasks")
+	}
+
+	items := make([]*generated.Task, 0, len(tasks))
+	for _, task := range tasks {
+		items = append(items, utils.ConvertTask(task))
+	}
+	return &generated.TaskPage{Items: items, TotalCount: int32(totalCount)}, nil
+}
+
+// Connection returns the tasks after a cursor.
+func (s *TaskService) Connection(ctx context.Context, filter *generated.TaskFilter, first *int32, after *string) (*generated.TaskConnection, error) {
+	position, err := cursor(first, after)
+	if err != nil {
+		return nil, err
+	}
+	taskFilter, err := taskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.tasks.Page(ctx, taskFilter, position)
+	if err != nil {
+		return nil, internalError(err, "fetch tasks")
+	}
+
+	tasks, cursors, pageInfo := utils.KeysetPage(tasks, position.Size, after, func(task models.Task) string {
+		return utils.EncodeCursor(task.CreatedAt, task.ID)
+	})
+	edges := make([]*generated.TaskEdge, 0, len(tasks))
+	for i, task := range tasks {
+		edges = append(edges, &generated.TaskEdge{Node: utils.ConvertTask(task), Cursor: cursors[i]})
+	}
+	return &generated.TaskConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
+
+// Get returns a task with its user.
+func (s *TaskService) Get(ctx context.Context, taskID string) (*generated.Task, error) {
+	task, err := s.get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertTask(task), nil
+}
+
+// Create adds a task for the caller.
+func (s *TaskService) Create(ctx context.Context, input generated.CreateTaskInput) (*generated.Task, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperr.Unauthenticated("invalid user ID in token")
+	}
+
+	task := models.Task{
+		ID:          uuid.New(),
+		UserID:      parsedUserID,
+		Title:       input.Title,
+		Description: value(input.Description),
+		Status:      models.TaskStatus(input.Status),
+		Priority:    models.TaskPriority(input.Priority),
+	}
+	if input.DueDate != "" {
+		parsedDueDate, err := time.Parse(time.RFC3339, input.DueDate)
+		if err != nil {
+			return nil, apperr.InvalidField("dueDate", "invalid due date format: %v", err)
+		}
+		task.DueDate = &parsedDueDate
+	}
+	if err := s.tasks.Create(ctx, &task); err != nil {
+		return nil, internalError(err, "create task")
+	}
+
+	result := utils.ConvertTask(task)
+	events.Publish(events.Event{Type: events.TaskCreated, EntityID: task.ID, ActorID: events.ActorOf(ctx), UserID: task.UserID, Data: result})
+	return result, nil
+}
+
+// Update changes the fields set in input. A new due date is reminded of again.
+func (s *TaskService) Update(ctx context.Context, taskID string, input generated.UpdateTaskInput) (*generated.Task, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	task, err := s.get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		task.Title = *input.Title
+	}
+	if input.Description != nil {
+		task.Description = *input.Description
+	}
+	if input.Status != nil {
+		task.Status = models.TaskStatus(*input.Status)
+	}
+	if input.Priority != nil {
+		task.Priority = models.TaskPriority(*input.Priority)
+	}
+	if input.DueDate != nil {
+		parsedDueDate, err := time.Parse(time.RFC3339, *input.DueDate)
+		if err != nil {
+			return nil, apperr.InvalidField("dueDate", "invalid due date format: %v", err)
+		}
+		if task.DueDate == nil || !task.DueDate.Equal(parsedDueDate) {
+			task.RemindedAt = nil
+		}
+		task.DueDate = &parsedDueDate
+	}
+
+	if err := s.tasks.Update(ctx, &task); err != nil {
+		return nil, internalError(err, "update task")
+	}
+
+	result := utils.ConvertTask(task)
+	events.Publish(events.Event{Type: events.TaskUpdated, EntityID: task.ID, ActorID: events.ActorOf(ctx), UserID: task.UserID, Data: result})
+	return result, nil
+}
+
+// Delete deletes a task.
+func (s *TaskService) Delete(ctx context.Context, taskID string) (*generated.Task, error) {
+	task, err := s.get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tasks.Delete(ctx, &task); err != nil {
+		return nil, internalError(err, "delete task")
+	}
+
+	result := utils.ConvertTask(task)
+	events.Publish(events.Event{Type: events.TaskDeleted, EntityID: task.ID, ActorID: events.ActorOf(ctx), UserID: task.UserID, Data: result})
+	return result, nil
+}
+
+func (s *TaskService) get(ctx context.Context, taskID string) (models.Task, error) {
+	if _, err := uuid.Parse(taskID); err != nil {
+		return models.Task{}, apperr.InvalidField("taskID", "invalid task ID format: %v", err)
+	}
+	task, err := s.tasks.Get(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Task{}, apperr.NotFound("task with id %s not found", taskID)
+		}
+		return models.Task{}, internalError(err, "fetch task")
+	}
+	return task, nil
+}
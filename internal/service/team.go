@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TeamService manages teams and their members. A user belongs to at most one team.
+type TeamService struct {
+	teams repository.TeamRepository
+	users repository.UserRepository
+}
+
+// NewTeamService returns a TeamService storing teams in teams and their members in users.
+func NewTeamService(teams repository.TeamRepository, users repository.UserRepository) *TeamService {
+	return &TeamService{teams: teams, users: users}
+}
+
+// List returns every team by name.
+func (s *TeamService) List(ctx context.Context) ([]*generated.Team, error) {
+	teams, err := s.teams.List(ctx)
+	if err != nil {
+		return nil, internalError(err, "fetch teams")
+	}
+	result := make([]*generated.Team, 0, len(teams))
+	for _, team := range teams {
+		result = append(result, utils.ConvertTeam(team))
+	}
+	return result, nil
+}
+
+// Get returns a team.
+func (s *TeamService) Get(ctx context.Context, teamID string) (*generated.Team, error) {
+	team, err := s.get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertTeam(team), nil
+}
+
+// Create adds a team.
+func (s *TeamService) Create(ctx context.Context, input generated.CreateTeamInput) (*generated.Team, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	if input.Name == "" {
+		return nil, apperr.Invalid("name is required")
+	}
+	team := models.Team{
+		ID:          uuid.New(),
+		Name:        input.Name,
+		Description: value(input.Description),
+	}
+	if input.ManagerID != nil && *input.ManagerID != "" {
+		manager, err := s.manager(ctx, *input.ManagerID)
+		if err != nil {
+			return nil, err
+		}
+		team.ManagerID = &manager.ID
+	}
+
+	if err := s.teams.Create(ctx, &team); err != nil {
+		return nil, internalError(err, "create team")
+	}
+	return utils.ConvertTeam(team), nil
+}
+
+// Update changes the fields set in input. An empty manager id removes the manager.
+func (s *TeamService) Update(ctx context.Context, teamID string, input generated.UpdateTeamInput) (*generated.Team, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	team, err := s.get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if *input.Name == "" {
+			return nil, apperr.Invalid("name cannot be empty")
+		}
+		team.Name = *input.Name
+	}
+	if input.Description != nil {
+		team.Description = *input.Description
+	}
+	if input.ManagerID != nil {
+		if *input.ManagerID == "" {
+			team.ManagerID = nil
+		} else {
+			manager, err := s.manager(ctx, *input.ManagerID)
+			if err != nil {
+				return nil, err
+			}
+			team.ManagerID = &manager.ID
+		}
+	}
+
+	if err := s.teams.Update(ctx, &team); err != nil {
+		return nil, internalError(err, "update team")
+	}
+	return utils.ConvertTeam(team), nil
+}
+
+// Delete deletes a team. Its members stay, they just no longer belong to a team.
+func (s *TeamService) Delete(ctx context.Context, teamID string) (*generated.Team, error) {
+	team, err := s.get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.teams.Delete(ctx, &team); err != nil {
+		return nil, internalError(err, "delete team")
+	}
+	return utils.ConvertTeam(team), nil
+}
+
+// AddMember moves a user to a team.
+func (s *TeamService) AddMember(ctx context.Context, userID string, teamID string) (*generated.Team, error) {
+	team, err := s.get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.users.SetTeam(ctx, user.ID, &team.ID); err != nil {
+		return nil, internalError(err, "add user to team")
+	}
+	return utils.ConvertTeam(team), nil
+}
+
+// RemoveMember takes a user out of their team.
+func (s *TeamService) RemoveMember(ctx context.Context, userID string) (*generated.User, error) {
+	user, err := s.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TeamID == nil {
+		return nil, apperr.Invalid("user is not in a team")
+	}
+	if err := s.users.SetTeam(ctx, user.ID, nil); err != nil {
+		return nil, internalError(err, "remove user from team")
+	}
+	user.TeamID = nil
+	return utils.ConvertUser(user), nil
+}
+
+// Manager returns the manager of a team, or nil if it has none.
+func (s *TeamService) Manager(ctx context.Context, teamID string) (*generated.User, error) {
+	team, err := s.teams.Get(ctx, teamID)
+	if err != nil {
+		return nil, internalError(err, "fetch team manager")
+	}
+	if team.ManagerID == nil {
+		return nil, nil
+	}
+	manager, err := s.users.Get(ctx, team.ManagerID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, internalError(err, "fetch team manager")
+	}
+	return utils.ConvertUser(manager), nil
+}
+
+// Members returns the members of a team by name.
+func (s *TeamService) Members(ctx context.Context, teamID string) ([]*generated.User, error) {
+	members, err := s.users.TeamMembers(ctx, teamID)
+	if err != nil {
+		return nil, internalError(err, "fetch team members")
+	}
+	result := make([]*generated.User, 0, len(members))
+	for _, member := range members {
+		result = append(result, utils.ConvertUser(member))
+	}
+	return result, nil
+}
+
+func (s *TeamService) get(ctx context.Context, teamID string) (models.Team, error) {
+	team, err := s.teams.Get(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Team{}, apperr.NotFound("team not found")
+		}
+		return models.Team{}, internalError(err, "fetch team")
+	}
+	return team, nil
+}
+
+func (s *TeamService) user(ctx context.Context, userID string) (models.User, error) {
+	user, err := s.users.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, apperr.NotFound("user not found")
+		}
+		return models.User{}, internalError(err, "fetch user")
+	}
+	return user, nil
+}
+
+func (s *TeamService) manager(ctx context.Context, managerID string) (models.User, error) {
+	manager, err := s.users.Get(ctx, managerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, apperr.NotFound("manager not found")
+		}
+		return models.User{}, internalError(err, "fetch manager")
+	}
+	return manager, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+)
+
+// TrashService lists, restores and purges deleted records.
+type TrashService struct {
+	trash repository.TrashRepository
+}
+
+// NewTrashService returns a TrashService on trash.
+func NewTrashService(trash repository.TrashRepository) *TrashService {
+	return &TrashService{trash: trash}
+}
+
+// List returns a page of the deleted records of entityType, last deleted first.
+func (s *TrashService) List(ctx context.Context, entityType generated.TrashEntityType, pagination *generated.PaginationInput) (*generated.TrashPage, error) {
+	deleted, totalCount, err := s.trash.List(ctx, entityType.String(), page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch trash")
+	}
+	items := make([]*generated.TrashItem, 0, len(deleted))
+	for _, item := range deleted {
+		items = append(items, utils.ConvertTrashItem(entityType, item))
+	}
+	return &generated.TrashPage{Items: items, TotalCount: int32(totalCount)}, nil
+}
+
+// Restore undeletes a record and those deleted with it.
+func (s *TrashService) Restore(ctx context.Context, entityType generated.TrashEntityType, id string) (*generated.TrashItem, error) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperr.InvalidField("id", "invalid ID: %v", err)
+	}
+	item, err := s.trash.Restore(ctx, entityType.String(), parsedID)
+	if err != nil {
+		return nil, internalError(err, "restore record")
+	}
+	return utils.ConvertTrashItem(entityType, item), nil
+}
+
+// Purge removes for good the records of entityType, or of every type if it is
+// nil, deleted more than olderThanDays ago, or than retention if it is nil.
+// It returns how many it removed.
+func (s *TrashService) Purge(ctx context.Context, entityType *generated.TrashEntityType, olderThanDays *int32, retention time.Duration) (int32, error) {
+	if olderThanDays != nil {
+		if *olderThanDays < 0 {
+			return 0, apperr.Invalid("olderThanDays must not be negative")
+		}
+		retention = time.Duration(*olderThanDays) * 24 * time.Hour
+	}
+	var entity string
+	if entityType != nil {
+		entity = entityType.String()
+	}
+	purged, err := s.trash.Purge(ctx, entity, time.Now().Add(-retention))
+	if err != nil {
+		return int32(purged), internalError(err, "purge trash")
+	}
+	return int32(purged), nil
+}
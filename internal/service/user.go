@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// maxReportingDepth bounds walks up the manager chain.
+const maxReportingDepth = 50
+
+// UserService manages users and who they report to.
+type UserService struct {
+	users repository.UserRepository
+	teams repository.TeamRepository
+}
+
+// NewUserService returns a UserService storing users in users and looking up
+// their teams in teams.
+func NewUserService(users repository.UserRepository, teams repository.TeamRepository) *UserService {
+	return &UserService{users: users, teams: teams}
+}
+
+// convertUserWithCampaigns maps a user to the GraphQL type along with their campaigns.
+func convertUserWithCampaigns(user models.User) *generated.User {
+	result := utils.ConvertUser(user)
+	for _, campaign := range user.Campaigns {
+		result.Campaigns = append(result.Campaigns, utils.ConvertCampaign(campaign))
+	}
+	return result
+}
+
+// validRole reports whether role is one users can have.
+func validRole(role string) bool {
+	return role == "ADMIN" || role == "SALES_EXECUTIVE" || role == "MANAGER"
+}
+
+// List returns a page of users with their campaigns.
+func (s *UserService) List(ctx context.Context, filter *generated.UserFilter, pagination *generated.PaginationInput, sort *generated.UserSortInput) (*generated.UserPage, error) {
+	var userFilter repository.UserFilter
+	if filter != nil {
+		userFilter = repository.UserFilter{
+			Name:   value(filter.Name),
+			Email:  value(filter.Email),
+			TeamID: value(filter.TeamID),
+			Search: value(filter.Search),
+		}
+		if filter.Role != nil {
+			userFilter.Role = filter.Role.String()
+		}
+	}
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	users, totalCount, err := s.users.List(ctx, userFilter, order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch users")
+	}
+
+	items := make([]*generated.User, 0, len(users))
+	for _, user := range users {
+		items = append(items, convertUserWithCampaigns(user))
+	}
+	return &generated.UserPage{
+		Items:      items,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// Get returns a user with their campaigns.
+func (s *UserService) Get(ctx context.Context, userID string) (*generated.User, error) {
+	user, err := s.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertUserWithCampaigns(user), nil
+}
+
+// Create adds a user with a hashed password.
+func (s *UserService) Create(ctx context.Context, input generated.CreateUserInput) (*generated.User, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	if input.Name == "" {
+		return nil, apperr.Invalid("name is required")
+	}
+	if input.Email == "" {
+		return nil, apperr.Invalid("email is required")
+	}
+	if input.Password == "" {
+		return nil, apperr.Invalid("password is required")
+	}
+	if input.Role == "" {
+		return nil, apperr.Invalid("role is required")
+	}
+	if !validRole(input.Role.String()) {
+		return nil, apperr.Invalid("invalid role")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, internalError(err, "hash password")
+	}
+	user := models.User{
+		ID:       uuid.New(),
+		GoogleId: value(input.GoogleID),
+		Name:     input.Name,
+		Email:    input.Email,
+		Phone:    value(input.Phone),
+		Password: string(hashedPassword),
+		Role:     input.Role.String(),
+	}
+	if err := s.users.Create(ctx, &user); err != nil {
+		return nil, internalError(err, "create user")
+	}
+	return utils.ConvertUser(user), nil
+}
+
+// Update changes the fields set in input.
+func (s *UserService) Update(ctx context.Context, userID string, input generated.UpdateUserInput) (*generated.User, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	user, err := s.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		user.Name = *input.Name
+	}
+	if input.Email != nil {
+		user.Email = *input.Email
+	}
+	if input.Phone != nil {
+		user.Phone = *input.Phone
+	}
+	if input.Role != nil {
+		user.Role = input.Role.String()
+	}
+	if !validRole(user.Role) {
+		return nil, apperr.Invalid("enter a valid role")
+	}
+
+	if err := s.users.Update(ctx, &user); err != nil {
+		return nil, internalError(err, "update user")
+	}
+	return convertUserWithCampaigns(user), nil
+}
+
+// Delete deletes a user.
+func (s *UserService) Delete(ctx context.Context, userID string) (*generated.User, error) {
+	user, err := s.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.users.Delete(ctx, &user); err != nil {
+		return nil, internalError(err, "delete user")
+	}
+	return convertUserWithCampaigns(user), nil
+}
+
+// SetManager makes managerID the manager of a user, or leaves them without one
+// if managerID is empty. A user cannot end up managing themselves, directly or
+// through their reports.
+func (s *UserService) SetManager(ctx context.Context, userID string, managerID *string) (*generated.User, error) {
+	user, err := s.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var manager *uuid.UUID
+	if managerID != nil && *managerID != "" {
+		parsedManagerID, err := uuid.Parse(*managerID)
+		if err != nil {
+			return nil, apperr.InvalidField("managerID", "invalid ManagerID: %v", err)
+		}
+		if err := s.validateManager(ctx, user.ID, parsedManagerID); err != nil {
+			return nil, err
+		}
+		manager = &parsedManagerID
+	}
+
+	if err := s.users.SetManager(ctx, user.ID, manager); err != nil {
+		return nil, internalError(err, "update manager")
+	}
+	user.ManagerID = manager
+	return utils.ConvertUser(user), nil
+}
+
+// validateManager checks that managerID exists and that making it userID's
+// manager would not create a reporting cycle.
+func (s *UserService) validateManager(ctx context.Context, userID uuid.UUID, managerID uuid.UUID) error {
+	if userID == managerID {
+		return apperr.InvalidField("managerID", "a user cannot be their own manager")
+	}
+	current := managerID
+	for depth := 0; depth < maxReportingDepth; depth++ {
+		manager, err := s.users.Get(ctx, current.String())
+		if err != nil {
+			if depth == 0 && errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperr.NotFound("manager not found")
+			}
+			return internalError(err, "check reporting chain")
+		}
+		if manager.ManagerID == nil {
+			return nil
+		}
+		if *manager.ManagerID == userID {
+			return apperr.InvalidField("managerID", "cannot set manager: the user already manages %s directly or indirectly", managerID)
+		}
+		current = *manager.ManagerID
+	}
+	return apperr.Invalid("reporting chain is deeper than %d levels", maxReportingDepth)
+}
+
+// Reports returns the users reporting to a user, only the direct reports if
+// directOnly is set.
+func (s *UserService) Reports(ctx context.Context, userID string, directOnly bool) ([]*generated.User, error) {
+	users, err := s.users.Reports(ctx, userID, directOnly)
+	if err != nil {
+		return nil, internalError(err, "fetch reports")
+	}
+	result := make([]*generated.User, 0, len(users))
+	for _, user := range users {
+		result = append(result, utils.ConvertUser(user))
+	}
+	return result, nil
+}
+
+// Team returns the team of a user, or nil if they have none.
+func (s *UserService) Team(ctx context.Context, userID string) (*generated.Team, error) {
+	user, err := s.users.Get(ctx, userID)
+	if err != nil {
+		return nil, internalError(err, "fetch user team")
+	}
+	if user.TeamID == nil {
+		return nil, nil
+	}
+	team, err := s.teams.Get(ctx, user.TeamID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, internalError(err, "fetch user team")
+	}
+	return utils.ConvertTeam(team), nil
+}
+
+// Manager returns the manager of a user, or nil if they have none.
+func (s *UserService) Manager(ctx context.Context, userID string) (*generated.User, error) {
+	user, err := s.users.Get(ctx, userID)
+	if err != nil {
+		return nil, internalError(err, "fetch user manager")
+	}
+	if user.ManagerID == nil {
+		return nil, nil
+	}
+	manager, err := s.users.Get(ctx, user.ManagerID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, internalError(err, "fetch user manager")
+	}
+	return utils.ConvertUser(manager), nil
+}
+
+// Login checks the password of the user with email and signs them in on device:
+// it returns an access token, a refresh token and the user.
+func (s *UserService) Login(ctx context.Context, email string, password string, device string) (*generated.AuthPayload, error) {
+	user, err := s.users.ByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("user not found")
+		}
+		return nil, internalError(err, "fetch user")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, apperr.Unauthenticated("invalid password")
+	}
+
+	accessToken, refreshToken, err := auth.GenerateTokens(&user, "Local", device)
+	if err != nil {
+		return nil, internalError(err, "generate token")
+	}
+	return &generated.AuthPayload{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		User: &generated.User{
+			UserID:   user.ID.String(),
+			GoogleID: &user.GoogleId,
+			Name:     user.Name,
+			Email:    user.Email,
+			Phone:    user.Phone,
+			Role:     user.Role,
+			Password: user.Password,
+		},
+	}, nil
+}
+
+func (s *UserService) get(ctx context.Context, userID string) (models.User, error) {
+	user, err := s.users.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, apperr.NotFound("user not found")
+		}
+		return models.User{}, internalError(err, "fetch user")
+	}
+	return user, nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/events"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/validation"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VendorService manages vendors.
+type VendorService struct {
+	vendors repository.VendorRepository
+	skills  repository.SkillRepository
+}
+
+// NewVendorService returns a VendorService storing vendors in vendors and
+// looking their skills up in skills.
+func NewVendorService(vendors repository.VendorRepository, skills repository.SkillRepository) *VendorService {
+	return &VendorService{vendors: vendors, skills: skills}
+}
+
+// vendorFilter maps a GraphQL vendor filter to the repository's.
+func vendorFilter(filter *generated.VendorFilter) repository.VendorFilter {
+	if filter == nil {
+		return repository.VendorFilter{}
+	}
+	result := repository.VendorFilter{
+		CompanyName: value(filter.CompanyName),
+		Search:      value(filter.Search),
+		SkillIDs:    filter.SkillIDs,
+	}
+	if filter.Status != nil {
+		result.Status = filter.Status.String()
+	}
+	if filter.PaymentTerms != nil {
+		result.PaymentTerms = filter.PaymentTerms.String()
+	}
+	return result
+}
+
+// List returns a page of vendors.
+func (s *VendorService) List(ctx context.Context, filter *generated.VendorFilter, pagination *generated.PaginationInput, sort *generated.VendorSortInput) (*generated.VendorPage, error) {
+	var order repository.Sort
+	if sort != nil {
+		order = sortBy(sort.Field.String(), sort.Order)
+	}
+	vendors, totalCount, err := s.vendors.List(ctx, vendorFilter(filter), order, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch vendors")
+	}
+
+	items := make([]*generated.Vendor, 0, len(vendors))
+	for _, vendor := range vendors {
+		items = append(items, utils.ConvertVendor(vendor))
+	}
+	return &generated.VendorPage{
+		Items:      items,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// Connection returns the vendors after a cursor.
+func (s *VendorService) Connection(ctx context.Context, filter *generated.VendorFilter, first *int32, after *string) (*generated.VendorConnection, error) {
+	position, err := cursor(first, after)
+	if err != nil {
+		return nil, err
+	}
+	vendors, err := s.vendors.Page(ctx, vendorFilter(filter), position)
+	if err != nil {
+		return nil, internalError(err, "fetch vendors")
+	}
+
+	vendors, cursors, pageInfo := utils.KeysetPage(vendors, position.Size, after, func(vendor models.Vendor) string {
+		return utils.EncodeCursor(vendor.CreatedAt, vendor.ID)
+	})
+	edges := make([]*generated.VendorEdge, 0, len(vendors))
+	for i, vendor := range vendors {
+		edges = append(edges, &generated.VendorEdge{Node: utils.ConvertVendor(vendor), Cursor: cursors[i]})
+	}
+	return &generated.VendorConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
+
+// Get returns a vendor.
+func (s *VendorService) Get(ctx context.Context, vendorID string) (*generated.Vendor, error) {
+	vendor, err := s.get(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertVendor(vendor), nil
+}
+
+// Create adds a vendor with the skills of input.
+func (s *VendorService) Create(ctx context.Context, input generated.CreateVendorInput) (*generated.Vendor, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	vendor := models.Vendor{
+		CompanyName:     input.CompanyName,
+		Status:          models.VendorStatus(input.Status),
+		PaymentTerms:    models.PaymentTerms(input.PaymentTerms),
+		Address:         input.Address,
+		GstOrVatDetails: input.GstOrVatDetails,
+		Notes:           input.Notes,
+	}
+	if len(input.SkillIDs) > 0 {
+		skills, err := s.lookupSkills(ctx, input.SkillIDs)
+		if err != nil {
+			return nil, err
+		}
+		vendor.Skills = skills
+	}
+
+	if err := s.vendors.Create(ctx, &vendor); err != nil {
+		return nil, internalError(err, "create vendor")
+	}
+	result := utils.ConvertVendor(vendor)
+	events.Publish(events.Event{Type: events.VendorCreated, EntityID: vendor.ID, ActorID: events.ActorOf(ctx), Data: result})
+	return result, nil
+}
+
+// Update changes the fields set in input. Skills, when set, replace the vendor's skills.
+func (s *VendorService) Update(ctx context.Context, vendorID string, input generated.UpdateVendorInput) (*generated.Vendor, error) {
+	if err := validation.Input(input); err != nil {
+		return nil, err
+	}
+	vendor, err := s.get(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.CompanyName != nil {
+		vendor.CompanyName = *input.CompanyName
+	}
+	if input.Status != nil {
+		vendor.Status = models.VendorStatus(*input.Status)
+	}
+	if input.PaymentTerms != nil {
+		vendor.PaymentTerms = models.PaymentTerms(*input.PaymentTerms)
+	}
+	if input.Address != nil {
+		vendor.Address = *input.Address
+	}
+	if input.GstOrVatDetails != nil {
+		vendor.GstOrVatDetails = input.GstOrVatDetails
+	}
+	if input.Notes != nil {
+		vendor.Notes = input.Notes
+	}
+
+	var skills []models.Skill
+	if input.SkillIDs != nil {
+		if skills, err = s.lookupSkills(ctx, input.SkillIDs); err != nil {
+			return nil, err
+		}
+		// Not nil, so that an empty list clears the skills
+		if skills == nil {
+			skills = []models.Skill{}
+		}
+		vendor.Skills = skills
+	}
+
+	if err := s.vendors.Update(ctx, &vendor, skills); err != nil {
+		return nil, internalError(err, "update vendor")
+	}
+	result := utils.ConvertVendor(vendor)
+	events.Publish(events.Event{Type: events.VendorUpdated, EntityID: vendor.ID, ActorID: events.ActorOf(ctx), Data: result})
+	return result, nil
+}
+
+// Delete moves a vendor to the trash with its contacts and ratings; purgeTrash
+// deletes them permanently.
+func (s *VendorService) Delete(ctx context.Context, vendorID string) (*generated.Vendor, error) {
+	vendor, err := s.get(ctx, vendorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.vendors.Delete(ctx, vendor.ID); err != nil {
+		return nil, internalError(err, "delete vendor")
+	}
+
+	result := utils.ConvertVendor(vendor)
+	events.Publish(events.Event{Type: events.VendorDeleted, EntityID: vendor.ID, ActorID: events.ActorOf(ctx), Data: result})
+	return result, nil
+}
+
+func (s *VendorService) get(ctx context.Context, vendorID string) (models.Vendor, error) {
+	parsedVendorID, err := uuid.Parse(vendorID)
+	if err != nil {
+		return models.Vendor{}, apperr.InvalidField("vendorID", "invalid vendor ID")
+	}
+	vendor, err := s.vendors.Get(ctx, parsedVendorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Vendor{}, apperr.NotFound("vendor not found")
+		}
+		return models.Vendor{}, internalError(err, "fetch vendor")
+	}
+	return vendor, nil
+}
+
+// lookupSkills returns the skills with skillIDs, in order.
+func (s *VendorService) lookupSkills(ctx context.Context, skillIDs []string) ([]models.Skill, error) {
+	var skills []models.Skill
+	for _, skillID := range skillIDs {
+		if _, err := uuid.Parse(skillID); err != nil {
+			return nil, apperr.InvalidField("skillIDs", "invalid skill ID %s", skillID)
+		}
+		skill, err := s.skills.Get(ctx, skillID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperr.NotFound("skill with ID %s not found", skillID)
+			}
+			return nil, internalError(err, "fetch skills")
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/repository"
+	"github.com/Zenithive/it-crm-backend/internal/webhooks"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/Zenithive/it-crm-backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookService manages webhooks and shows their deliveries. Secrets are only
+// returned when they are created or rotated.
+type WebhookService struct {
+	webhooks repository.WebhookRepository
+}
+
+// NewWebhookService returns a WebhookService storing webhooks in webhooks.
+func NewWebhookService(webhooks repository.WebhookRepository) *WebhookService {
+	return &WebhookService{webhooks: webhooks}
+}
+
+// List returns every webhook, newest first.
+func (s *WebhookService) List(ctx context.Context) ([]*generated.Webhook, error) {
+	hooks, err := s.webhooks.List(ctx)
+	if err != nil {
+		return nil, internalError(err, "fetch webhooks")
+	}
+	result := make([]*generated.Webhook, 0, len(hooks))
+	for _, webhook := range hooks {
+		result = append(result, utils.ConvertWebhook(webhook))
+	}
+	return result, nil
+}
+
+// Get returns a webhook.
+func (s *WebhookService) Get(ctx context.Context, webhookID string) (*generated.Webhook, error) {
+	webhook, err := s.get(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertWebhook(webhook), nil
+}
+
+// Create adds an active webhook created by the current user, with a new secret.
+func (s *WebhookService) Create(ctx context.Context, input generated.CreateWebhookInput) (*generated.Webhook, error) {
+	userID, err := auth.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperr.InvalidField("userID", "invalid user ID: %v", err)
+	}
+	if err := webhooks.ValidateURL(input.URL); err != nil {
+		return nil, err
+	}
+	if err := webhooks.ValidateEvents(input.Events); err != nil {
+		return nil, err
+	}
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, internalError(err, "create webhook")
+	}
+
+	webhook := models.Webhook{
+		ID:          uuid.New(),
+		URL:         strings.TrimSpace(input.URL),
+		Events:      input.Events,
+		Secret:      secret,
+		Active:      true,
+		Description: value(input.Description),
+		CreatedBy:   parsedUserID,
+	}
+	if err := s.webhooks.Create(ctx, &webhook); err != nil {
+		return nil, internalError(err, "create webhook")
+	}
+	if webhook, err = s.get(ctx, webhook.ID.String()); err != nil {
+		return nil, err
+	}
+	result := utils.ConvertWebhook(webhook)
+	result.Secret = &secret
+	return result, nil
+}
+
+// Update changes the fields set in input.
+func (s *WebhookService) Update(ctx context.Context, webhookID string, input generated.UpdateWebhookInput) (*generated.Webhook, error) {
+	webhook, err := s.get(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := webhooks.ValidateURL(*input.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = strings.TrimSpace(*input.URL)
+	}
+	if input.Events != nil {
+		if err := webhooks.ValidateEvents(input.Events); err != nil {
+			return nil, err
+		}
+		webhook.Events = input.Events
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+	if input.Description != nil {
+		webhook.Description = *input.Description
+	}
+	if err := s.webhooks.Update(ctx, &webhook); err != nil {
+		return nil, internalError(err, "update webhook")
+	}
+	return utils.ConvertWebhook(webhook), nil
+}
+
+// Delete deletes a webhook.
+func (s *WebhookService) Delete(ctx context.Context, webhookID string) (*generated.Webhook, error) {
+	webhook, err := s.get(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.webhooks.Delete(ctx, &webhook); err != nil {
+		return nil, internalError(err, "delete webhook")
+	}
+	return utils.ConvertWebhook(webhook), nil
+}
+
+// RotateSecret gives a webhook a new secret and returns it.
+func (s *WebhookService) RotateSecret(ctx context.Context, webhookID string) (*generated.Webhook, error) {
+	webhook, err := s.get(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, internalError(err, "rotate webhook secret")
+	}
+	if err := s.webhooks.SetSecret(ctx, &webhook, secret); err != nil {
+		return nil, internalError(err, "rotate webhook secret")
+	}
+	result := utils.ConvertWebhook(webhook)
+	result.Secret = &secret
+	return result, nil
+}
+
+// Deliveries returns a page of the deliveries matching filter, newest first.
+func (s *WebhookService) Deliveries(ctx context.Context, filter *generated.WebhookDeliveryFilter, pagination *generated.PaginationInput) (*generated.WebhookDeliveryPage, error) {
+	var deliveryFilter repository.WebhookDeliveryFilter
+	if filter != nil {
+		deliveryFilter = repository.WebhookDeliveryFilter{WebhookID: value(filter.WebhookID), Event: value(filter.Event)}
+		if filter.Status != nil {
+			deliveryFilter.Status = filter.Status.String()
+		}
+	}
+	deliveries, totalCount, err := s.webhooks.Deliveries(ctx, deliveryFilter, page(pagination))
+	if err != nil {
+		return nil, internalError(err, "fetch webhook deliveries")
+	}
+	items := make([]*generated.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, utils.ConvertWebhookDelivery(delivery))
+	}
+	return &generated.WebhookDeliveryPage{Items: items, TotalCount: int32(totalCount)}, nil
+}
+
+// Redeliver queues the payload of a past delivery again.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID string) (*generated.WebhookDelivery, error) {
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return nil, apperr.InvalidField("deliveryID", "invalid delivery ID")
+	}
+	delivery, err := s.webhooks.Redeliver(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("webhook delivery not found")
+		}
+		return nil, internalError(err, "redeliver webhook")
+	}
+	return utils.ConvertWebhookDelivery(*delivery), nil
+}
+
+func (s *WebhookService) get(ctx context.Context, webhookID string) (models.Webhook, error) {
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return models.Webhook{}, apperr.InvalidField("webhookID", "invalid webhook ID")
+	}
+	webhook, err := s.webhooks.Get(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Webhook{}, apperr.NotFound("webhook not found")
+		}
+		return models.Webhook{}, internalError(err, "fetch webhook")
+	}
+	return webhook, nil
+}
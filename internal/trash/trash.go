@@ -0,0 +1,298 @@
+// Package trash lists, restores and purges soft-deleted rows.
+//
+// Every model embeds gorm.Model, so deleting only sets deleted_at. Delete
+// soft-deletes a row together with its dependents under one timestamp, which is
+// how Restore tells them from dependents that were deleted on their own before.
+package trash
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	ErrUnknownEntity = apperr.InvalidField("entityType", "unknown entity type")
+	ErrNotFound      = apperr.NotFound("deleted record not found")
+	ErrParentDeleted = apperr.Conflict("the record it belongs to is deleted")
+)
+
+// Entity is a kind of record that can be deleted and restored.
+type Entity struct {
+	Model func() interface{}
+	// Label is an SQL expression naming a row in the trash view.
+	Label string
+	// Dependents are associations of Model that are deleted, restored and purged with it.
+	Dependents []string
+}
+
+// Entities by type, as in the TrashEntityType GraphQL enum.
+var Entities = map[string]Entity{
+	"LEAD": {
+		Model:      func() interface{} { return &models.Lead{} },
+		Label:      "concat_ws(' ', first_name, last_name)",
+		Dependents: []string{"Activities"},
+	},
+	"ACTIVITY": {
+		Model: func() interface{} { return &models.Activity{} },
+		Label: "concat_ws(' ', activity_type, to_char(date_time, 'YYYY-MM-DD'))",
+	},
+	"DEAL": {
+		Model: func() interface{} { return &models.Deal{} },
+		Label: "deal_name",
+	},
+	"TASK": {
+		Model: func() interface{} { return &models.Task{} },
+		Label: "title",
+	},
+	"ORGANIZATION": {
+		Model: func() interface{} { return &models.Organization{} },
+		Label: "organization_name",
+	},
+	"CAMPAIGN": {
+		Model: func() interface{} { return &models.Campaign{} },
+		Label: "campaign_name",
+	},
+	"VENDOR": {
+		Model:      func() interface{} { return &models.Vendor{} },
+		Label:      "company_name",
+		Dependents: []string{"ContactList", "PerformanceRatings", "Skills"},
+	},
+	"RESOURCE_PROFILE": {
+		Model:      func() interface{} { return &models.ResourceProfile{} },
+		Label:      "concat_ws(' ', first_name, last_name)",
+		Dependents: []string{"ResourceSkills", "PastProjects"},
+	},
+	"CASE_STUDY": {
+		Model: func() interface{} { return &models.CaseStudy{} },
+		Label: "project_name",
+	},
+	"SKILL": {
+		Model: func() interface{} { return &models.Skill{} },
+		Label: "name",
+	},
+	"USER": {
+		Model: func() interface{} { return &models.User{} },
+		Label: "concat_ws(' ', name, '<' || email || '>')",
+	},
+	"TEAM": {
+		Model: func() interface{} { return &models.Team{} },
+		Label: "name",
+	},
+}
+
+// Item is a deleted row as shown in the trash view.
+type Item struct {
+	ID        uuid.UUID
+	Label     string
+	DeletedAt time.Time
+}
+
+func lookup(db *gorm.DB, entityType string) (Entity, *schema.Schema, error) {
+	entity, ok := Entities[entityType]
+	if !ok {
+		return Entity{}, nil, fmt.Errorf("%w: %s", ErrUnknownEntity, entityType)
+	}
+	s, err := parse(db, entity.Model())
+	return entity, s, err
+}
+
+func parse(db *gorm.DB, model interface{}) (*schema.Schema, error) {
+	statement := &gorm.Statement{DB: db}
+	if err := statement.Parse(model); err != nil {
+		return nil, err
+	}
+	return statement.Schema, nil
+}
+
+func softDeletes(s *schema.Schema) bool {
+	return s.LookUpField("deleted_at") != nil
+}
+
+// dependents returns the has-many associations of entity whose rows are soft-deleted with it.
+func dependents(s *schema.Schema, entity Entity) []*schema.Relationship {
+	var relations []*schema.Relationship
+	for _, name := range entity.Dependents {
+		rel, ok := s.Relationships.Relations[name]
+		if !ok || rel.Type != schema.HasMany || !softDeletes(rel.FieldSchema) {
+			continue
+		}
+		relations = append(relations, rel)
+	}
+	return relations
+}
+
+func foreignKey(rel *schema.Relationship) string {
+	return rel.References[0].ForeignKey.DBName
+}
+
+// List returns the deleted rows of entityType, most recently deleted first, and
+// how many there are. A limit of zero lists all of them.
+func List(db *gorm.DB, entityType string, offset, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]Item, int64, error) {
+	entity, s, err := lookup(db, entityType)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := db.Unscoped().Model(entity.Model()).Scopes(scopes...).Where(s.Table + ".deleted_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	var items []Item
+	err = query.Select(s.Table + ".id, " + entity.Label + " AS label, " + s.Table + ".deleted_at").
+		Order(s.Table + ".deleted_at DESC").
+		Scan(&items).Error
+	return items, total, err
+}
+
+// Delete soft-deletes a row of entityType and its dependents.
+func Delete(db *gorm.DB, entityType string, id uuid.UUID) error {
+	entity, s, err := lookup(db, entityType)
+	if err != nil {
+		return err
+	}
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(entity.Model()).Where("id = ?", id).Update("deleted_at", deletedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		for _, rel := range dependents(s, entity) {
+			child := reflect.New(rel.FieldSchema.ModelType).Interface()
+			if err := tx.Model(child).Where(foreignKey(rel)+" = ?", id).Update("deleted_at", deletedAt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Restore undeletes a row of entityType and the dependents deleted with it. The
+// scopes restrict which deleted rows may be restored.
+func Restore(db *gorm.DB, entityType string, id uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) (Item, error) {
+	entity, s, err := lookup(db, entityType)
+	if err != nil {
+		return Item{}, err
+	}
+	var item Item
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var items []Item
+		err := tx.Unscoped().Model(entity.Model()).Scopes(scopes...).
+			Select(s.Table+".id, "+entity.Label+" AS label, "+s.Table+".deleted_at").
+			Where(s.Table+".id = ? AND "+s.Table+".deleted_at IS NOT NULL", id).
+			Scan(&items).Error
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return ErrNotFound
+		}
+		item = items[0]
+		if err := checkParents(tx, s, id); err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(entity.Model()).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		for _, rel := range dependents(s, entity) {
+			child := reflect.New(rel.FieldSchema.ModelType).Interface()
+			err := tx.Unscoped().Model(child).
+				Where(foreignKey(rel)+" = ? AND deleted_at = ?", id, item.DeletedAt).
+				Update("deleted_at", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return item, err
+}
+
+// checkParents fails if the row is a dependent of a row that is still deleted,
+// e.g. an activity of a deleted lead.
+func checkParents(tx *gorm.DB, s *schema.Schema, id uuid.UUID) error {
+	for parentType, parent := range Entities {
+		ps, err := parse(tx, parent.Model())
+		if err != nil {
+			return err
+		}
+		for _, rel := range dependents(ps, parent) {
+			if rel.FieldSchema.Table != s.Table {
+				continue
+			}
+			var count int64
+			err := tx.Unscoped().Table(ps.Table).
+				Where("deleted_at IS NOT NULL AND id = (?)", tx.Unscoped().Table(s.Table).Select(foreignKey(rel)).Where("id = ?", id)).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				name := strings.ToLower(strings.ReplaceAll(parentType, "_", " "))
+				return fmt.Errorf("%w: restore the %s first", ErrParentDeleted, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Purge permanently deletes rows of entityType, or of every type if it is empty,
+// that were deleted before the given time, along with their dependents. Rows
+// that cannot be removed, e.g. because live records still reference them, are
+// logged and skipped. It returns the number of rows purged, dependents not counted.
+func Purge(db *gorm.DB, entityType string, before time.Time) (int64, error) {
+	types := []string{entityType}
+	if entityType == "" {
+		types = types[:0]
+		for name := range Entities {
+			types = append(types, name)
+		}
+	}
+
+	var purged int64
+	for _, name := range types {
+		entity, s, err := lookup(db, name)
+		if err != nil {
+			return purged, err
+		}
+		var ids []uuid.UUID
+		if err := db.Unscoped().Model(entity.Model()).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+			return purged, err
+		}
+		for _, id := range ids {
+			row := entity.Model()
+			if err := s.PrioritizedPrimaryField.Set(db.Statement.Context, reflect.ValueOf(row), id); err != nil {
+				return purged, err
+			}
+			err := db.Transaction(func(tx *gorm.DB) error {
+				query := tx.Unscoped()
+				if len(entity.Dependents) > 0 {
+					query = query.Select(entity.Dependents)
+				}
+				return query.Delete(row).Error
+			})
+			if err != nil {
+				log.Printf("Error purging %s %s, skipping it: %v", s.Name, id, err)
+				continue
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
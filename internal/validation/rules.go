@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// e164 matches phone numbers in E.164 format: "+", a country code and at most 15 digits.
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// formats check the format argument of @constraint. Each returns what is
+// wrong with a non-empty value, or "".
+var formats = map[string]func(string) string{
+	"EMAIL": func(s string) string {
+		if address, err := mail.ParseAddress(s); err != nil || address.Address != s {
+			return "must be a valid email address"
+		}
+		return ""
+	},
+	"PHONE": func(s string) string {
+		if !e164.MatchString(s) {
+			return "must be a phone number in E.164 format, e.g. +14155550100"
+		}
+		return ""
+	},
+	"URL": func(s string) string {
+		if u, err := url.ParseRequestURI(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "must be an absolute http or https URL"
+		}
+		return ""
+	},
+	"DATE": func(s string) string {
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return "must be a date in YYYY-MM-DD format"
+		}
+		return ""
+	},
+	"DATETIME": func(s string) string {
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be a date and time in RFC 3339 format, e.g. 2024-01-31T09:00:00Z"
+		}
+		return ""
+	},
+	"UUID": func(s string) string {
+		if _, err := uuid.Parse(s); err != nil {
+			return "must be a UUID"
+		}
+		return ""
+	},
+}
+
+// check returns what is wrong with value under the @constraint arguments args, or "".
+func check(args map[string]any, value any) string {
+	if s, ok := value.(string); ok {
+		return checkString(args, s)
+	}
+	if n, ok := toFloat(value); ok {
+		if min, ok := toFloat(args["min"]); ok && n < min {
+			return fmt.Sprintf("must be at least %v", min)
+		}
+		if max, ok := toFloat(args["max"]); ok && n > max {
+			return fmt.Sprintf("must be at most %v", max)
+		}
+	}
+	return ""
+}
+
+func checkString(args map[string]any, s string) string {
+	// Surrounding whitespace does not count, so "  " is empty
+	trimmed := strings.TrimSpace(s)
+	length := utf8.RuneCountInString(trimmed)
+	if min, ok := toFloat(args["minLength"]); ok && float64(length) < min {
+		if min == 1 {
+			return "is required"
+		}
+		return fmt.Sprintf("must be at least %v characters", min)
+	}
+	if max, ok := toFloat(args["maxLength"]); ok && float64(length) > max {
+		return fmt.Sprintf("must be at most %v characters", max)
+	}
+	// Formats and choices apply to values given; minLength makes a field required
+	if trimmed == "" {
+		return ""
+	}
+	if format, ok := formats[fmt.Sprint(args["format"])]; ok {
+		if message := format(s); message != "" {
+			return message
+		}
+	}
+	if choices, ok := args["oneOf"].([]any); ok {
+		options := make([]string, 0, len(choices))
+		for _, choice := range choices {
+			options = append(options, fmt.Sprint(choice))
+		}
+		if !slices.Contains(options, s) {
+			return "must be one of " + strings.Join(options, ", ")
+		}
+	}
+	return ""
+}
+
+// toFloat reads a number from argument values, which hold JSON numbers when
+// they come from variables.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,160 @@
+// Package validation checks values against the @constraint schema directive
+// and reports every violation at once in a single VALIDATION_FAILED error (see
+// apperr.InvalidFields). Services check their inputs with Input before saving;
+// Extension checks the arguments of every field before it resolves.
+//
+// Violations are keyed by their path from the argument, e.g. "filter.from" or
+// "skillInputs.0.experienceYears". The argument name is left out for the
+// "input" argument of mutations, so its fields read "email" rather than
+// "input.email".
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// schema is the GraphQL schema Input looks input types up in.
+var schema = sync.OnceValue(func() *ast.Schema {
+	return generated.NewExecutableSchema(generated.Config{}).Schema()
+})
+
+// Input checks input, a value of one of the generated input types, against the
+// @constraint directives of the GraphQL input type of the same name. Violations
+// are keyed by their path from input, like the fields of an "input" argument.
+func Input(input any) error {
+	typ := reflect.Indirect(reflect.ValueOf(input)).Type()
+	def := schema().Types[typ.Name()]
+	if def == nil || def.Kind != ast.InputObject {
+		return fmt.Errorf("%s is not a GraphQL input type", typ)
+	}
+
+	// Check the value as it would arrive in variables
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", typ, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", typ, err)
+	}
+
+	v := validator{schema: schema(), fields: map[string]string{}}
+	v.value("", value, ast.NonNullNamedType(def.Name, nil), nil)
+	return v.err()
+}
+
+// Arguments checks args, the values of the arguments defined by defs, against
+// their @constraint directives. The name of the "input" argument is left out
+// of the violations of its fields.
+func Arguments(schema *ast.Schema, defs ast.ArgumentDefinitionList, args map[string]any) error {
+	v := validator{schema: schema, fields: map[string]string{}}
+	for _, arg := range defs {
+		path := arg.Name
+		if arg.Name == "input" {
+			path = ""
+		}
+		v.value(path, args[arg.Name], arg.Type, arg.Directives)
+	}
+	return v.err()
+}
+
+// Extension validates the arguments of every field that has any.
+type Extension struct {
+	schema *ast.Schema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.FieldInterceptor
+} = &Extension{}
+
+func (e *Extension) ExtensionName() string {
+	return "Validation"
+}
+
+func (e *Extension) Validate(schema graphql.ExecutableSchema) error {
+	e.schema = schema.Schema()
+	return nil
+}
+
+func (e *Extension) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || fc.Field.Field == nil || fc.Field.Definition == nil || len(fc.Field.Definition.Arguments) == 0 {
+		return next(ctx)
+	}
+
+	args := fc.Field.ArgumentMap(graphql.GetOperationContext(ctx).Variables)
+	if err := Arguments(e.schema, fc.Field.Definition.Arguments, args); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+type validator struct {
+	schema *ast.Schema
+	// fields collects the first violation of each path
+	fields map[string]string
+}
+
+// err returns the violations found, if any.
+func (v *validator) err() error {
+	if len(v.fields) > 0 {
+		return apperr.InvalidFields(v.fields)
+	}
+	return nil
+}
+
+// value checks one argument or input field, then the fields of input objects within it.
+func (v *validator) value(path string, value any, typ *ast.Type, directives ast.DirectiveList) {
+	if value == nil {
+		return
+	}
+	if typ.Elem != nil {
+		items, ok := value.([]any)
+		if !ok {
+			// A single value passed for a list
+			items = []any{value}
+		}
+		for i, item := range items {
+			v.value(join(path, strconv.Itoa(i)), item, typ.Elem, directives)
+		}
+		return
+	}
+
+	if constraint := directives.ForName("constraint"); constraint != nil {
+		if message := check(constraint.ArgumentMap(nil), value); message != "" {
+			if _, seen := v.fields[path]; !seen {
+				v.fields[path] = message
+			}
+		}
+	}
+
+	def := v.schema.Types[typ.Name()]
+	object, ok := value.(map[string]any)
+	if def == nil || def.Kind != ast.InputObject || !ok {
+		return
+	}
+	for _, field := range def.Fields {
+		v.value(join(path, field.Name), object[field.Name], field.Type, field.Directives)
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
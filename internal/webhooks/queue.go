@@ -0,0 +1,262 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/events"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxAttempts is how often a delivery is tried before it is marked failed.
+	MaxAttempts = 8
+	// baseBackoff is the wait after the first failure; it doubles after each one.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+	// lease keeps a delivery from being picked up again while it is being sent.
+	lease = 2 * time.Minute
+	// pollInterval is how often the queue is checked for due retries.
+	pollInterval = 5 * time.Second
+	batchSize    = 20
+	// maxResponseBody is how much of the endpoint's answer is kept in the log.
+	maxResponseBody = 1024
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// wake nudges the worker when new deliveries are queued.
+var wake = make(chan struct{}, 1)
+
+// Start queues a delivery for every event a webhook subscribed to and runs the
+// worker that sends them, until ctx is done.
+func Start(ctx context.Context, db *gorm.DB) {
+	bus := events.SubscribeReliable(ctx)
+	go func() {
+		for event := range bus {
+			if err := enqueue(db, event); err != nil {
+				log.Printf("Error queueing webhooks for %s %s: %v", event.Type, event.EntityID, err)
+			}
+		}
+	}()
+	go work(ctx, db)
+}
+
+// enqueue stores a delivery of event for every active webhook subscribed to it.
+func enqueue(db *gorm.DB, event events.Event) error {
+	var webhooks []models.Webhook
+	if err := db.Where("active = ?", true).Find(&webhooks).Error; err != nil {
+		return err
+	}
+	payload := Payload{
+		ID:         uuid.New(),
+		Event:      event.Type,
+		OccurredAt: event.At,
+		EntityID:   event.EntityID,
+		From:       event.From,
+		To:         event.To,
+		Data:       event.Data,
+	}
+	if event.ActorID != uuid.Nil {
+		payload.ActorID = &event.ActorID
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []models.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhook.Events, event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			Event:         event.Type,
+			Payload:       string(body),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: time.Now(),
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := db.Create(&deliveries).Error; err != nil {
+		return err
+	}
+	Wake()
+	return nil
+}
+
+// Redeliver queues the payload of a past delivery again, as a new delivery.
+func Redeliver(db *gorm.DB, deliveryID string) (*models.WebhookDelivery, error) {
+	var previous models.WebhookDelivery
+	if err := db.First(&previous, "id = ?", deliveryID).Error; err != nil {
+		return nil, err
+	}
+	delivery := models.WebhookDelivery{
+		ID:            uuid.New(),
+		WebhookID:     previous.WebhookID,
+		Event:         previous.Event,
+		Payload:       previous.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := db.Create(&delivery).Error; err != nil {
+		return nil, err
+	}
+	Wake()
+	return &delivery, nil
+}
+
+// Wake makes the worker look at the queue now instead of at its next poll.
+func Wake() {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+func work(ctx context.Context, db *gorm.DB) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			sent, err := sendDue(db)
+			if err != nil {
+				log.Printf("Error sending webhooks: %v", err)
+			}
+			if sent < batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wake:
+		}
+	}
+}
+
+// sendDue sends a batch of due deliveries and returns how many it picked up.
+func sendDue(db *gorm.DB) (int, error) {
+	var due []models.WebhookDelivery
+	if err := db.
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at").
+		Limit(batchSize).
+		Find(&due).Error; err != nil {
+		return 0, err
+	}
+	for _, delivery := range due {
+		// Claim the delivery; another instance may have taken it in the meantime
+		now := time.Now()
+		claim := db.Model(&models.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND attempts = ?", delivery.ID, models.WebhookDeliveryPending, delivery.Attempts).
+			Updates(map[string]interface{}{
+				"attempts":        delivery.Attempts + 1,
+				"last_attempt_at": now,
+				"next_attempt_at": now.Add(lease),
+			})
+		if claim.Error != nil {
+			return 0, claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			continue
+		}
+		delivery.Attempts++
+		delivery.LastAttemptAt = &now
+		if err := attempt(db, &delivery); err != nil {
+			log.Printf("Error recording webhook delivery %s: %v", delivery.ID, err)
+		}
+	}
+	return len(due), nil
+}
+
+// attempt posts the delivery once and records the outcome.
+func attempt(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	var webhook models.Webhook
+	if err := db.First(&webhook, "id = ?", delivery.WebhookID).Error; err != nil {
+		// The webhook was deleted, there is nowhere to send it
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = "webhook not found"
+		return save(db, delivery)
+	}
+	send(webhook, delivery)
+	return save(db, delivery)
+}
+
+// send posts the delivery to webhook once and sets its outcome: succeeded,
+// retried after Backoff, or failed once MaxAttempts were made.
+func send(webhook models.Webhook, delivery *models.WebhookDelivery) {
+	status, body, err := post(webhook, delivery)
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	delivery.Error = ""
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case status < 200 || status > 299:
+		delivery.Error = fmt.Sprintf("endpoint answered %d", status)
+	default:
+		delivery.Status = models.WebhookDeliverySucceeded
+		return
+	}
+
+	if delivery.Attempts >= MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+	} else {
+		delivery.NextAttemptAt = time.Now().Add(Backoff(delivery.Attempts))
+	}
+}
+
+func post(webhook models.Webhook, delivery *models.WebhookDelivery) (int, string, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "it-crm-webhooks")
+	request.Header.Set("X-Webhook-Event", delivery.Event)
+	request.Header.Set("X-Webhook-Delivery", delivery.ID.String())
+	request.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Webhook-Signature", Sign(webhook.Secret, timestamp, body))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+	answer, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBody))
+	// Postgres text takes neither invalid UTF-8 nor NUL bytes
+	text := strings.ReplaceAll(strings.ToValidUTF8(string(answer), ""), "\x00", "")
+	return response.StatusCode, text, nil
+}
+
+func save(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	return db.Model(delivery).Select("status", "next_attempt_at", "response_status", "response_body", "error").Updates(delivery).Error
+}
+
+// Backoff is the wait before the retry that follows attempt number attempts.
+func Backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxBackoff)
+}
@@ -0,0 +1,152 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// receiver checks the signature of deliveries against secret and answers them
+// with the statuses in turn, repeating the last one. It counts the deliveries
+// and keeps the headers of the last.
+type receiver struct {
+	*httptest.Server
+	received atomic.Int32
+	headers  atomic.Pointer[http.Header]
+}
+
+func newReceiver(t *testing.T, secret string, statuses ...int) *receiver {
+	t.Helper()
+	rc := &receiver{}
+	rc.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers := r.Header.Clone()
+		rc.headers.Store(&headers)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		timestamp := r.Header.Get("X-Webhook-Timestamp")
+		if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+			t.Errorf("X-Webhook-Timestamp = %q, want Unix seconds", timestamp)
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "." + string(body)))
+		if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); r.Header.Get("X-Webhook-Signature") != want {
+			t.Errorf("X-Webhook-Signature = %q, want %q", r.Header.Get("X-Webhook-Signature"), want)
+		}
+		n := int(rc.received.Add(1))
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(rc.Close)
+	return rc
+}
+
+func newDelivery(webhook models.Webhook) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: webhook.ID,
+		Event:     "lead.created",
+		Payload:   `{"event":"lead.created"}`,
+		Status:    models.WebhookDeliveryPending,
+	}
+}
+
+func TestSendSignsTheDelivery(t *testing.T) {
+	webhook := models.Webhook{ID: uuid.New(), Secret: "whsec_test"}
+	rc := newReceiver(t, webhook.Secret, http.StatusNoContent)
+	webhook.URL = rc.URL
+
+	delivery := newDelivery(webhook)
+	delivery.Attempts = 1
+	send(webhook, delivery)
+	if delivery.Status != models.WebhookDeliverySucceeded || delivery.ResponseStatus != http.StatusNoContent {
+		t.Fatalf("delivery = %s answered %d (%s), want %s", delivery.Status, delivery.ResponseStatus, delivery.Error, models.WebhookDeliverySucceeded)
+	}
+	if rc.received.Load() != 1 {
+		t.Fatalf("received %d deliveries, want 1", rc.received.Load())
+	}
+	headers := *rc.headers.Load()
+	for name, want := range map[string]string{
+		"Content-Type":       "application/json",
+		"X-Webhook-Event":    "lead.created",
+		"X-Webhook-Delivery": delivery.ID.String(),
+	} {
+		if got := headers.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSendRetriesWithBackoffUntilMaxAttempts(t *testing.T) {
+	webhook := models.Webhook{ID: uuid.New(), Secret: "whsec_test"}
+	rc := newReceiver(t, webhook.Secret, http.StatusServiceUnavailable)
+	webhook.URL = rc.URL
+
+	delivery := newDelivery(webhook)
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		delivery.Attempts = attempt
+		before := time.Now()
+		send(webhook, delivery)
+		if attempt == MaxAttempts {
+			break
+		}
+		if delivery.Status != models.WebhookDeliveryPending || delivery.Error != "endpoint answered 503" {
+			t.Fatalf("attempt %d: delivery = %s (%s), want a pending retry", attempt, delivery.Status, delivery.Error)
+		}
+		if wait := delivery.NextAttemptAt.Sub(before); wait < Backoff(attempt) || wait > Backoff(attempt)+time.Second {
+			t.Errorf("attempt %d: retry in %v, want %v", attempt, wait, Backoff(attempt))
+		}
+	}
+	if delivery.Status != models.WebhookDeliveryFailed {
+		t.Errorf("after %d attempts: delivery = %s, want %s", MaxAttempts, delivery.Status, models.WebhookDeliveryFailed)
+	}
+	if rc.received.Load() != MaxAttempts {
+		t.Errorf("received %d deliveries, want %d", rc.received.Load(), MaxAttempts)
+	}
+}
+
+func TestSendSucceedsOnRetry(t *testing.T) {
+	webhook := models.Webhook{ID: uuid.New(), Secret: "whsec_test"}
+	rc := newReceiver(t, webhook.Secret, http.StatusInternalServerError, http.StatusOK)
+	webhook.URL = rc.URL
+
+	delivery := newDelivery(webhook)
+	delivery.Attempts = 1
+	send(webhook, delivery)
+	if delivery.Status != models.WebhookDeliveryPending {
+		t.Fatalf("first attempt: delivery = %s, want %s", delivery.Status, models.WebhookDeliveryPending)
+	}
+	delivery.Attempts = 2
+	send(webhook, delivery)
+	if delivery.Status != models.WebhookDeliverySucceeded || delivery.Error != "" {
+		t.Errorf("second attempt: delivery = %s (%s), want %s", delivery.Status, delivery.Error, models.WebhookDeliverySucceeded)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{20, 6 * time.Hour},
+	}
+	for _, test := range tests {
+		if got := Backoff(test.attempts); got != test.want {
+			t.Errorf("Backoff(%d) = %v, want %v", test.attempts, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package webhooks sends events from the event bus to registered endpoints.
+//
+// Every event a webhook subscribed to becomes a models.WebhookDelivery row, so the
+// queue survives restarts. A worker posts due deliveries and retries failures with
+// exponential backoff until MaxAttempts.
+//
+// Each request carries:
+//   - X-Webhook-Event: the event type, e.g. lead.created
+//   - X-Webhook-Delivery: the delivery id, the same on every attempt
+//   - X-Webhook-Timestamp: Unix seconds of the attempt
+//   - X-Webhook-Signature: "sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret
+//
+// The body is a Payload.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/events"
+	"github.com/google/uuid"
+)
+
+// Payload is the JSON body of a delivery. ID identifies the event, so it is the
+// same for every webhook the event goes to and lets receivers drop repeats.
+type Payload struct {
+	ID         uuid.UUID   `json:"id"`
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	EntityID   uuid.UUID   `json:"entityID"`
+	ActorID    *uuid.UUID  `json:"actorID,omitempty"`
+	From       string      `json:"from,omitempty"`
+	To         string      `json:"to,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+}
+
+// Sign returns the X-Webhook-Signature value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// ValidateURL checks that rawURL is an absolute http or https URL.
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return apperr.InvalidField("url", "invalid webhook URL %q, expected an http or https URL", rawURL)
+	}
+	return nil
+}
+
+// ValidateEvents checks that every event is a known event type.
+func ValidateEvents(types []string) error {
+	if len(types) == 0 {
+		return apperr.InvalidField("events", "a webhook needs at least one event")
+	}
+	for _, t := range types {
+		if !slices.Contains(events.Types, t) {
+			return apperr.InvalidField("events", "unknown event %q", t)
+		}
+	}
+	return nil
+}
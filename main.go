@@ -1,16 +1,104 @@
 package main
 
 import (
+	"fmt"
+	"log"
+	"os"
+
 	initializers "github.com/Zenithive/it-crm-backend/Initializers"
 	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/audit"
+	"github.com/Zenithive/it-crm-backend/internal/config"
 	"github.com/Zenithive/it-crm-backend/internal/graphql"
+	"github.com/Zenithive/it-crm-backend/models"
 )
 
-func init() {
-	initializers.ConnectToDatabase()
+const usage = `Usage: it-crm-backend [command]
+
+Commands:
+  serve      migrate the database and start the server (the default)
+  migrate    apply, revert or create schema migrations
+  seed       add demo data
+  user       create users and reset passwords
+  tokens     revoke refresh tokens
+  reindex    rebuild database indexes and recalculate lead scores
 
-	auth.InitGoogleStore()
+Run "it-crm-backend <command> -h" for the options of a command.
+Settings are read from the environment, .env and config.yaml, see
+config.example.yaml.
+`
+
+// commands run a subcommand with its arguments and return the exit code.
+var commands = map[string]func(cfg *config.Config, args []string) int{
+	"serve":   runServe,
+	"migrate": runMigrate,
+	"seed":    runSeed,
+	"user":    runUser,
+	"tokens":  runTokens,
+	"reindex": runReindex,
 }
+
 func main() {
-	graphql.Handler()
+	command, args := "serve", []string(nil)
+	if len(os.Args) > 1 {
+		command, args = os.Args[1], os.Args[2:]
+	}
+
+	run, ok := commands[command]
+	switch {
+	case command == "help" || command == "-h" || command == "-help" || command == "--help":
+		fmt.Print(usage)
+		return
+	case !ok:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	models.SetBaseCurrency(cfg.BaseCurrency)
+	os.Exit(run(cfg, args))
+}
+
+func runServe(cfg *config.Config, args []string) int {
+	if len(args) != 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	// Fail before touching anything if a secret or setting is missing
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	initializers.ConnectToDatabase(cfg.DatabaseURL)
+	initializers.MigrateDatabase()
+
+	auth.Configure(cfg.Auth, initializers.DB)
+	graphql.Handler(cfg, initializers.DB)
+	return 0
+}
+
+// connectDatabase connects to the database for commands other than serve.
+func connectDatabase(cfg *config.Config) error {
+	if err := cfg.ValidateDatabase(); err != nil {
+		return err
+	}
+	initializers.ConnectToDatabase(cfg.DatabaseURL)
+	return nil
+}
+
+// openDatabase connects to the database for commands that change data, which
+// are audited as made by the system.
+func openDatabase(cfg *config.Config) error {
+	if err := connectDatabase(cfg); err != nil {
+		return err
+	}
+	if err := audit.Register(initializers.DB, auth.CurrentUser); err != nil {
+		log.Fatalf("Failed to register audit callbacks: %v", err)
+	}
+	return nil
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	initializers "github.com/Zenithive/it-crm-backend/Initializers"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"github.com/Zenithive/it-crm-backend/internal/migrate"
+)
+
+const migrateUsage = `Usage: it-crm-backend migrate <command>
+
+Commands:
+  up [-steps n]          apply pending migrations, all of them by default
+  down [-steps n]        revert the last n applied migrations, 1 by default
+  status                 list migrations and when they were applied
+  create [-dir d] <name> add empty up and down files for a new migration
+`
+
+// runMigrate runs the migrate subcommand and returns the exit code.
+func runMigrate(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	command, args := args[0], args[1:]
+	flags := flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, migrateUsage) }
+	steps := 0
+	dir := migrate.Dir
+	switch command {
+	case "up":
+		flags.IntVar(&steps, "steps", 0, "number of migrations to apply, 0 for all")
+	case "down":
+		flags.IntVar(&steps, "steps", 1, "number of migrations to revert")
+	case "create":
+		flags.StringVar(&dir, "dir", migrate.Dir, "directory of the migration files")
+	case "status":
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown migrate command %q\n\n%s", command, migrateUsage)
+		return 2
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if command == "create" {
+		if flags.NArg() != 1 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		up, down, err := migrate.Create(dir, flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create migration: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Created %s\nCreated %s\n", up, down)
+		return 0
+	}
+
+	if err := connectDatabase(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	var err error
+	switch command {
+	case "up":
+		var applied []migrate.Migration
+		if applied, err = migrate.Up(initializers.DB, steps); err == nil && len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	case "down":
+		var reverted []migrate.Migration
+		if reverted, err = migrate.Down(initializers.DB, steps); err == nil && len(reverted) == 0 {
+			fmt.Println("No applied migrations")
+		}
+	case "status":
+		err = printMigrationStatus()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func printMigrationStatus() error {
+	statuses, err := migrate.List(initializers.DB)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Assignment strategies supported by the rules engine (internal/assignment).
+const (
+	AssignmentStrategyRoundRobin  = "ROUND_ROBIN"
+	AssignmentStrategyLeastLoaded = "LEAST_LOADED"
+	AssignmentStrategyManual      = "MANUAL" // Only used in logs, for leads assigned by hand
+)
+
+// AssignmentRule picks an owner for leads created without one.
+// Rules are tried in ascending Priority; a rule applies when the lead matches all of its
+// conditions (empty conditions match everything) and it has at least one candidate user.
+// Candidates are Users plus the members of Team.
+type AssignmentRule struct {
+	gorm.Model
+	ID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name       string     `gorm:"not null" json:"name"`
+	Priority   int        `gorm:"not null;default:0;index" json:"priority"`
+	Enabled    bool       `gorm:"not null" json:"enabled"`
+	Strategy   string     `gorm:"type:varchar(20);not null" json:"strategy"`
+	Countries  []string   `gorm:"serializer:json" json:"countries"` // Territory, matched case-insensitively against Lead.Country
+	CampaignID *uuid.UUID `gorm:"type:uuid;index" json:"campaignId"`
+	TeamID     *uuid.UUID `gorm:"type:uuid;index" json:"teamId"`
+	Users      []User     `gorm:"many2many:assignment_rule_users;constraint:OnDelete:CASCADE;" json:"users"`
+
+	// Round-robin position: the candidate who received the previous lead
+	LastAssignedUserID *uuid.UUID `gorm:"type:uuid" json:"lastAssignedUserId"`
+}
+
+// LeadAssignmentLog records why a lead went to a rep.
+type LeadAssignmentLog struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	LeadID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"leadId"`
+	AssignedTo     uuid.UUID  `gorm:"type:uuid;not null" json:"assignedTo"`
+	AssignedToUser User       `gorm:"foreignKey:AssignedTo" json:"assignedToUser"`
+	AssignedBy     *uuid.UUID `gorm:"type:uuid" json:"assignedBy"` // User whose action triggered the assignment
+	AssignedByUser *User      `gorm:"foreignKey:AssignedBy" json:"assignedByUser"`
+	RuleID         *uuid.UUID `gorm:"type:uuid;index" json:"ruleId"`
+	RuleName       string     `json:"ruleName"` // Kept so the log stays readable after the rule changes
+	Strategy       string     `gorm:"type:varchar(20);not null" json:"strategy"`
+	Reason         string     `json:"reason"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
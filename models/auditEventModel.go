@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions (internal/audit).
+const (
+	AuditActionCreate  = "CREATE"
+	AuditActionUpdate  = "UPDATE"
+	AuditActionDelete  = "DELETE"
+	AuditActionRestore = "RESTORE"
+	AuditActionPurge   = "PURGE"
+)
+
+// AuditChange is one column's value before and after a change. From is nil on
+// create, To is nil on delete.
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AuditEvent records one row created, updated or deleted. Rows are only ever
+// inserted; internal/audit rejects updates and deletes of this table.
+type AuditEvent struct {
+	ID         uuid.UUID              `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	CreatedAt  time.Time              `gorm:"not null;index" json:"createdAt"`
+	ActorID    *uuid.UUID             `gorm:"type:uuid;index" json:"actorId"` // nil for changes made by the system
+	Actor      *User                  `gorm:"foreignKey:ActorID" json:"actor"`
+	ActorRole  string                 `json:"actorRole"`
+	EntityType string                 `gorm:"not null;index:idx_audit_events_entity,priority:1" json:"entityType"` // Model name, e.g. Lead
+	EntityID   string                 `gorm:"not null;index:idx_audit_events_entity,priority:2" json:"entityId"`
+	Action     string                 `gorm:"type:varchar(10);not null" json:"action"`
+	Changes    map[string]AuditChange `gorm:"serializer:json;type:jsonb" json:"changes"` // By column name
+}
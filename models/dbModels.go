@@ -138,11 +138,18 @@ const (
 	// Add other skill types as needed
 )
 
+// RefreshToken is one refresh token of a session (see auth.RefreshSession).
+// Tokens rotate: each is used once and replaced by the next of its family, the
+// tokens descending from one sign-in on one device.
 type RefreshToken struct {
-	ID        uuid.UUID `gorm:"primaryKey"`
-	UserID    uuid.UUID `gorm:"not null"`
-	Token     string    `gorm:"unique;not null"`
-	CreatedAt time.Time
-	ExpiresAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"` // Soft delete support
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	FamilyID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash    string    `gorm:"type:varchar(64);unique;not null"` // Hex SHA-256 of the token, which is not stored
+	Device       string    // Label of the device the session was started on
+	AuthProvider string    // How the session was started, e.g. "Local" or "Google"
+	CreatedAt    time.Time
+	ExpiresAt    time.Time  `gorm:"not null"`
+	UsedAt       *time.Time // When it was exchanged for the next token
+	RevokedAt    *time.Time // When its session ended
 }
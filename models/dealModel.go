@@ -15,6 +15,6 @@ type Deal struct {
 	DealStartDate       time.Time `json:"dealStartDate"`
 	DealEndDate         time.Time `json:"dealEndDate"`
 	ProjectRequirements string    `json:"projectRequirements"`
-	DealAmount          string    `json:"dealAmount"`
+	DealAmount          Money     `gorm:"embedded;embeddedPrefix:deal_amount_" json:"dealAmount"`
 	DealStatus          string    `json:"dealStatus"`
 }
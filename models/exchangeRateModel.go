@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// ExchangeRate converts one unit of Currency into the base currency (see BaseCurrency).
+// The base currency itself needs no row.
+type ExchangeRate struct {
+	gorm.Model
+	ID         uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Currency   string          `gorm:"type:varchar(3);not null;uniqueIndex" json:"currency"`
+	RateToBase decimal.Decimal `gorm:"type:numeric(20,8);not null" json:"rateToBase"`
+}
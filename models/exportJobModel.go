@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Export job lifecycle (internal/exporter).
+const (
+	ExportStatusPending   = "PENDING"
+	ExportStatusRunning   = "RUNNING"
+	ExportStatusCompleted = "COMPLETED"
+	ExportStatusFailed    = "FAILED"
+)
+
+// ExportDocumentType is the Document.ReferenceType of export files; ReferenceID is the job.
+const ExportDocumentType = "EXPORT"
+
+// ExportJob tracks an export that runs in the background. The file is stored as a Document.
+type ExportJob struct {
+	gorm.Model
+	ID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Query      string     `gorm:"not null" json:"query"`   // List query name, e.g. getLeads
+	Filter     string     `gorm:"type:text" json:"filter"` // JSON, as sent by the client
+	Sort       string     `gorm:"type:text" json:"sort"`   // JSON, as sent by the client
+	Columns    []string   `gorm:"serializer:json" json:"columns"`
+	Format     string     `gorm:"type:varchar(10);not null" json:"format"`
+	Status     string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Error      string     `json:"error"`
+	RowCount   int        `gorm:"not null;default:0" json:"rowCount"`
+	DocumentID *uuid.UUID `gorm:"type:uuid" json:"documentId"`
+	Document   *Document  `gorm:"foreignKey:DocumentID" json:"document"`
+
+	CreatedBy     uuid.UUID  `gorm:"type:uuid;not null;index" json:"createdBy"`
+	CreatedByUser User       `gorm:"foreignKey:CreatedBy" json:"createdByUser"`
+	StartedAt     *time.Time `json:"startedAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Record types a spreadsheet can be imported into (internal/importer).
+const (
+	ImportEntityLead            = "LEAD"
+	ImportEntityOrganization    = "ORGANIZATION"
+	ImportEntityResourceProfile = "RESOURCE_PROFILE"
+)
+
+// Import job lifecycle. A dry run stops at PREVIEW_READY until it is committed.
+const (
+	ImportStatusPending      = "PENDING"
+	ImportStatusRunning      = "RUNNING"
+	ImportStatusPreviewReady = "PREVIEW_READY"
+	ImportStatusCompleted    = "COMPLETED"
+	ImportStatusFailed       = "FAILED"
+)
+
+// Outcome of one imported row.
+const (
+	ImportRowValid   = "VALID"   // Dry run: the row would be created
+	ImportRowCreated = "CREATED" // The record was created
+	ImportRowFailed  = "FAILED"  // Validation or creation failed, see Errors
+)
+
+// ImportJob tracks the import of one uploaded CSV or XLSX file.
+type ImportJob struct {
+	gorm.Model
+	ID       uuid.UUID         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Entity   string            `gorm:"type:varchar(20);not null" json:"entity"`
+	FileName string            `json:"fileName"`
+	FilePath string            `json:"filePath"`                       // Kept until the job is committed, fails or its preview expires (importer.PreviewExpiry)
+	Mapping  map[string]string `gorm:"serializer:json" json:"mapping"` // Column header -> field
+	DryRun   bool              `gorm:"not null" json:"dryRun"`
+	Status   string            `gorm:"type:varchar(20);not null;index" json:"status"`
+	Error    string            `json:"error"` // Set when the whole job failed, e.g. unreadable file
+
+	TotalRows   int `gorm:"not null;default:0" json:"totalRows"`
+	ValidRows   int `gorm:"not null;default:0" json:"validRows"`
+	CreatedRows int `gorm:"not null;default:0" json:"createdRows"`
+	FailedRows  int `gorm:"not null;default:0" json:"failedRows"`
+
+	CreatedBy     uuid.UUID  `gorm:"type:uuid;not null;index" json:"createdBy"`
+	CreatedByUser User       `gorm:"foreignKey:CreatedBy" json:"createdByUser"`
+	StartedAt     *time.Time `json:"startedAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+
+	Rows []ImportJobRow `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE;" json:"rows"`
+}
+
+// ImportJobRow is the outcome of one spreadsheet row. Row is 1-based and counts the header.
+type ImportJobRow struct {
+	ID       uuid.UUID         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	JobID    uuid.UUID         `gorm:"type:uuid;not null;index" json:"jobId"`
+	Row      int               `gorm:"not null" json:"row"`
+	Status   string            `gorm:"type:varchar(10);not null" json:"status"`
+	RecordID *uuid.UUID        `gorm:"type:uuid" json:"recordId"`
+	Values   map[string]string `gorm:"serializer:json" json:"values"` // Field -> cell, after mapping
+	Errors   []string          `gorm:"serializer:json" json:"errors"`
+	Warnings []string          `gorm:"serializer:json" json:"warnings"`
+}
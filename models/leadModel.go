@@ -28,6 +28,7 @@ type Lead struct {
 	LeadStage      LeadStage    `json:"leadStage"`
 	LeadNotes      string       `json:"leadNotes"`
 	LeadPriority   string       `json:"leadPriority"`
+	Score          int          `gorm:"not null;default:0;index" json:"score"` // Maintained by internal/scoring
 	OrganizationID uuid.UUID    `gorm:"index" json:"organizationId"`
 	Organization   Organization `gorm:"foreignKey:OrganizationID" json:"organization"`
 	CampaignID     uuid.UUID    `gorm:"index" json:"campaignId"`
@@ -43,6 +44,10 @@ type LeadStageHistory struct {
 	OldStage  LeadStage `json:"oldStage"`
 	NewStage  LeadStage `json:"newStage"`
 	ChangedAt time.Time `json:"changedAt"`
+
+	// User who moved the lead to NewStage (nullable for rows recorded before this was tracked)
+	ChangedBy     *uuid.UUID `gorm:"type:uuid;index" json:"changedBy"`
+	ChangedByUser *User      `gorm:"foreignKey:ChangedBy;constraint:OnDelete:SET NULL;" json:"changedByUser"`
 }
 
 type LeadStage string
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is a decimal amount in an ISO 4217 currency.
+// Models embed it (gorm:"embedded;embeddedPrefix:...") so it is stored as
+// <prefix>amount NUMERIC and <prefix>currency VARCHAR(3) columns.
+// It is also bound to the Money GraphQL scalar, see gqlgen.yml.
+type Money struct {
+	Amount   decimal.Decimal `gorm:"type:numeric(20,2);not null;default:0" json:"amount"`
+	Currency string          `gorm:"type:varchar(3);not null;default:''" json:"currency"`
+}
+
+// baseCurrency is returned by BaseCurrency, see SetBaseCurrency.
+var baseCurrency = "USD"
+
+// BaseCurrency returns the currency pipeline totals are reported in and the one
+// assumed for amounts entered without a currency, USD unless SetBaseCurrency changed it.
+func BaseCurrency() string {
+	return baseCurrency
+}
+
+// SetBaseCurrency sets the currency BaseCurrency returns. Commands call it once
+// at startup with config.Config.BaseCurrency, before reading or writing amounts.
+func SetBaseCurrency(currency string) {
+	baseCurrency = currency
+}
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Symbols accepted when parsing free-form amounts such as "$1,200".
+var currencySymbols = map[string]string{
+	"$": "USD",
+	"€": "EUR",
+	"£": "GBP",
+	"₹": "INR",
+	"¥": "JPY",
+}
+
+// NewMoney builds a Money value and validates the currency code.
+func NewMoney(amount decimal.Decimal, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !currencyCodePattern.MatchString(currency) {
+		return Money{}, fmt.Errorf("invalid currency code %q, expected an ISO 4217 code such as USD", currency)
+	}
+	return Money{Amount: amount.Round(2), Currency: currency}, nil
+}
+
+// ParseMoney parses free-form amounts like "1200", "1,200.50 USD", "EUR 300" or "$1,200".
+// defaultCurrency is used when the text carries no currency.
+func ParseMoney(text string, defaultCurrency string) (Money, error) {
+	value := strings.TrimSpace(text)
+	if value == "" {
+		return Money{}, errors.New("empty amount")
+	}
+
+	currency := ""
+	for symbol, code := range currencySymbols {
+		if strings.Contains(value, symbol) {
+			currency = code
+			value = strings.ReplaceAll(value, symbol, "")
+		}
+	}
+
+	// A three letter code may lead or trail the number
+	fields := strings.Fields(value)
+	var numberParts []string
+	for _, field := range fields {
+		upper := strings.ToUpper(field)
+		if currencyCodePattern.MatchString(upper) {
+			if currency != "" && currency != upper {
+				return Money{}, fmt.Errorf("conflicting currencies in %q", text)
+			}
+			currency = upper
+			continue
+		}
+		numberParts = append(numberParts, field)
+	}
+	if len(numberParts) != 1 {
+		return Money{}, fmt.Errorf("cannot parse amount %q", text)
+	}
+
+	number := strings.ReplaceAll(numberParts[0], ",", "")
+	amount, err := decimal.NewFromString(number)
+	if err != nil {
+		return Money{}, fmt.Errorf("cannot parse amount %q", text)
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	return NewMoney(amount, currency)
+}
+
+// String renders the value as "1200.00 USD".
+func (m Money) String() string {
+	return m.Amount.StringFixed(2) + " " + m.Currency
+}
+
+// MarshalGQL writes the value as {"amount": "1200.00", "currency": "USD"}.
+// The amount is a string so no precision is lost in JavaScript clients.
+func (m Money) MarshalGQL(w io.Writer) {
+	out, _ := json.Marshal(map[string]string{
+		"amount":   m.Amount.StringFixed(2),
+		"currency": m.Currency,
+	})
+	w.Write(out)
+}
+
+// UnmarshalGQL accepts either an object {amount, currency} or a string such as "1200.50 USD".
+// A missing currency falls back to BaseCurrency.
+func (m *Money) UnmarshalGQL(v interface{}) error {
+	switch value := v.(type) {
+	case string:
+		parsed, err := ParseMoney(value, BaseCurrency())
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case map[string]interface{}:
+		currency, _ := value["currency"].(string)
+		if currency == "" {
+			currency = BaseCurrency()
+		}
+		var amount decimal.Decimal
+		switch raw := value["amount"].(type) {
+		case string:
+			parsed, err := decimal.NewFromString(strings.ReplaceAll(raw, ",", ""))
+			if err != nil {
+				return fmt.Errorf("invalid money amount %q", raw)
+			}
+			amount = parsed
+		case json.Number:
+			parsed, err := decimal.NewFromString(raw.String())
+			if err != nil {
+				return fmt.Errorf("invalid money amount %q", raw)
+			}
+			amount = parsed
+		case float64:
+			amount = decimal.NewFromFloat(raw)
+		case int64:
+			amount = decimal.NewFromInt(raw)
+		case int:
+			amount = decimal.NewFromInt(int64(raw))
+		default:
+			return errors.New("money amount is required")
+		}
+		parsed, err := NewMoney(amount, currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	default:
+		return fmt.Errorf("money must be an object {amount, currency} or a string, got %T", v)
+	}
+}
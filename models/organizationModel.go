@@ -14,6 +14,6 @@ type Organization struct {
 	City                string    `json:"city"`
 	Country             string    `json:"country"`
 	NoOfEmployees       string    `json:"noOfEmployees"`
-	AnnualRevenue       string    `json:"annualRevenue"`
+	AnnualRevenue       Money     `gorm:"embedded;embeddedPrefix:annual_revenue_" json:"annualRevenue"`
 	Leads               []Lead    `gorm:"foreignKey:OrganizationID" json:"leads"`
 }
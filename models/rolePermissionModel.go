@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RolePermission grants a single permission (e.g. "lead:write:own") to a role.
+// The table is seeded with auth.DefaultRolePermissions the first time it is read.
+type RolePermission struct {
+	gorm.Model
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Role       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_role_permission" json:"role"`
+	Permission string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_role_permission" json:"permission"`
+}
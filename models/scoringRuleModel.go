@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attributes a scoring rule can test, see internal/scoring.
+const (
+	ScoringAttributeLeadSource      = "LEAD_SOURCE"
+	ScoringAttributeLeadPriority    = "LEAD_PRIORITY"
+	ScoringAttributeEmployees       = "EMPLOYEES"        // Organization.NoOfEmployees
+	ScoringAttributeAnnualRevenue   = "ANNUAL_REVENUE"   // Organization.AnnualRevenue, in the base currency
+	ScoringAttributeActivityCount   = "ACTIVITY_COUNT"   // Number of activities on the lead
+	ScoringAttributeActivityRecency = "ACTIVITY_RECENCY" // Days since the latest activity
+	ScoringAttributeCampaign        = "CAMPAIGN"         // Campaign ID, or empty for "in any campaign"
+)
+
+// Operators a scoring rule compares with.
+const (
+	ScoringOperatorEquals = "EQUALS" // Case-insensitive text match
+	ScoringOperatorGTE    = "GTE"
+	ScoringOperatorLTE    = "LTE"
+)
+
+// ScoringRule adds Weight points to a lead's score when Attribute compared with
+// Value using Operator holds. A lead's score is the sum over all matching rules.
+type ScoringRule struct {
+	gorm.Model
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Attribute   string    `gorm:"type:varchar(30);not null" json:"attribute"`
+	Operator    string    `gorm:"type:varchar(10);not null" json:"operator"`
+	Value       string    `json:"value"`
+	Weight      int       `gorm:"not null" json:"weight"`
+	Description string    `json:"description"`
+}
@@ -35,4 +35,7 @@ type Task struct {
 	Priority    TaskPriority `gorm:"type:task_priority;not null" json:"priority"`
 	DueDate     *time.Time   `json:"dueDate"` // Nullable time for dueDate
 	User        User         `gorm:"foreignKey:UserID;references:ID" json:"user"`
+
+	// When the due-soon reminder went out (internal/events), reset when DueDate changes
+	RemindedAt *time.Time `json:"remindedAt"`
 }
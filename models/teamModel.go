@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Team groups users for reporting, visibility ("my team") and assignment rules.
+type Team struct {
+	gorm.Model
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name        string     `gorm:"not null;uniqueIndex" json:"name"`
+	Description string     `json:"description"`
+	ManagerID   *uuid.UUID `gorm:"type:uuid;index" json:"managerId"` // Team lead, usually a MANAGER
+	Members     []User     `gorm:"foreignKey:TeamID;constraint:OnDelete:SET NULL;" json:"members"`
+}
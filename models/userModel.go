@@ -33,6 +33,8 @@ type User struct {
 	Role      string     `json:"role"`
 	Password  string     `json:"password"`
 	Campaigns []Campaign `gorm:"many2many:campaign_users;joinForeignKey:UserID;joinReferences:CampaignID;constraint:OnDelete:CASCADE;" json:"campaigns"`
+	TeamID    *uuid.UUID `gorm:"type:uuid;index" json:"teamId"`
+	ManagerID *uuid.UUID `gorm:"type:uuid;index" json:"managerId"` // The user this user reports to
 }
 type GoogleUser struct {
 	Provider          string
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook delivery states (internal/webhooks).
+const (
+	WebhookDeliveryPending   = "PENDING"   // Waiting for its first or next attempt
+	WebhookDeliverySucceeded = "SUCCEEDED" // The endpoint answered 2xx
+	WebhookDeliveryFailed    = "FAILED"    // Every attempt failed, no more retries
+)
+
+// Webhook is an endpoint that is sent the events it subscribed to.
+type Webhook struct {
+	gorm.Model
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	URL         string    `gorm:"not null" json:"url"`
+	Events      []string  `gorm:"serializer:json;not null" json:"events"` // Event types, e.g. lead.created
+	Secret      string    `gorm:"not null" json:"-"`                      // HMAC-SHA256 key for the signature header
+	Active      bool      `gorm:"not null;default:true" json:"active"`
+	Description string    `json:"description"`
+
+	CreatedBy     uuid.UUID `gorm:"type:uuid;not null" json:"createdBy"`
+	CreatedByUser User      `gorm:"foreignKey:CreatedBy" json:"createdByUser"`
+}
+
+// WebhookDelivery is one event queued for one webhook, and the log of sending it.
+type WebhookDelivery struct {
+	gorm.Model
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	WebhookID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"webhookId"`
+	Event          string     `gorm:"not null;index" json:"event"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_queue,priority:1" json:"status"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_deliveries_queue,priority:2" json:"nextAttemptAt"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt"`
+	ResponseStatus int        `json:"responseStatus"`
+	ResponseBody   string     `gorm:"type:text" json:"responseBody"` // Truncated
+	Error          string     `json:"error"`
+}
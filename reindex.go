@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	initializers "github.com/Zenithive/it-crm-backend/Initializers"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"github.com/Zenithive/it-crm-backend/internal/scoring"
+)
+
+const reindexUsage = `Usage: it-crm-backend reindex [-scores-only]
+
+Rebuilds the indexes of the application's tables without blocking writes,
+refreshes the planner statistics, then recalculates every lead score, e.g.
+after a bulk import or a restore.
+
+  -scores-only    only recalculate lead scores
+`
+
+// runReindex runs the reindex subcommand and returns the exit code.
+func runReindex(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, reindexUsage) }
+	scoresOnly := flags.Bool("scores-only", false, "only recalculate lead scores")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		fmt.Fprint(os.Stderr, reindexUsage)
+		return 2
+	}
+
+	if err := openDatabase(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	if !*scoresOnly {
+		var schema string
+		if err := initializers.DB.Raw("SELECT current_schema()").Scan(&schema).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to find the database schema: %v\n", err)
+			return 1
+		}
+		log.Printf("Rebuilding the indexes of schema %s", schema)
+		// CONCURRENTLY keeps the tables writable, but cannot run in a transaction
+		if err := initializers.DB.Exec(`REINDEX SCHEMA CONCURRENTLY "` + schema + `"`).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to rebuild indexes: %v\n", err)
+			return 1
+		}
+		if err := initializers.DB.Exec("ANALYZE").Error; err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to refresh statistics: %v\n", err)
+			return 1
+		}
+	}
+
+	log.Printf("Recalculating lead scores")
+	if err := scoring.RecalculateAll(initializers.DB); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to recalculate lead scores: %v\n", err)
+		return 1
+	}
+	fmt.Println("Reindex complete")
+	return 0
+}
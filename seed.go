@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	initializers "github.com/Zenithive/it-crm-backend/Initializers"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"github.com/Zenithive/it-crm-backend/internal/seed"
+)
+
+const seedUsage = `Usage: it-crm-backend seed
+
+Migrates the database, then adds demo data: a team of demo users and leads,
+deals, tasks, vendors and resources to try the CRM with. It refuses to run
+twice on the same database.
+`
+
+// runSeed runs the seed subcommand and returns the exit code.
+func runSeed(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, seedUsage) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		fmt.Fprint(os.Stderr, seedUsage)
+		return 2
+	}
+
+	if err := openDatabase(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	initializers.MigrateDatabase()
+	users, err := seed.Demo(initializers.DB)
+	if errors.Is(err, seed.ErrSeeded) {
+		fmt.Printf("Nothing to do: the %q team already exists\n", seed.TeamName)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to seed demo data: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Seeded demo data. Sign in with password %q as:\n", seed.Password)
+	for _, user := range users {
+		fmt.Printf("  %s (%s)\n", user.Email, user.Role)
+	}
+	return 0
+}
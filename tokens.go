@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	initializers "github.com/Zenithive/it-crm-backend/Initializers"
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+)
+
+const tokensUsage = `Usage: it-crm-backend tokens <command>
+
+Commands:
+  revoke -user u    revoke the refresh tokens of the user with email or ID u,
+                    signing them out once their access token expires
+`
+
+// runTokens runs the tokens subcommand and returns the exit code.
+func runTokens(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "revoke" {
+		if len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "Unknown tokens command %q\n\n", args[0])
+		}
+		fmt.Fprint(os.Stderr, tokensUsage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("tokens revoke", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, tokensUsage) }
+	userRef := flags.String("user", "", "email or ID of the user")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *userRef == "" || flags.NArg() != 0 {
+		fmt.Fprint(os.Stderr, tokensUsage)
+		return 2
+	}
+
+	if err := openDatabase(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	user, err := findUser(*userRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	if err := auth.RevokeUserSessions(initializers.DB, user.ID.String()); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to revoke tokens of %s: %v\n", user.Email, err)
+		return 1
+	}
+	fmt.Printf("Revoked the refresh tokens of %s\n", user.Email)
+	return 0
+}
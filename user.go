@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	initializers "github.com/Zenithive/it-crm-backend/Initializers"
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/config"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const userUsage = `Usage: it-crm-backend user <command>
+
+Commands:
+  create -email e -name n -role r [-phone p] [-password p]
+                            add a user, e.g. the first ADMIN, who can then
+                            add everyone else through the API
+  reset-password -email e [-password p]
+                            set a new password and revoke the user's refresh
+                            tokens
+
+The password is read from standard input when -password is not given.
+Roles: ADMIN, MANAGER, SALES_EXECUTIVE.
+`
+
+// runUser runs the user subcommand and returns the exit code.
+func runUser(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, userUsage)
+		return 2
+	}
+
+	command, args := args[0], args[1:]
+	flags := flag.NewFlagSet("user "+command, flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, userUsage) }
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "password, read from standard input if not given")
+	var name, role, phone *string
+	switch command {
+	case "create":
+		name = flags.String("name", "", "name of the user")
+		role = flags.String("role", "", "ADMIN, MANAGER or SALES_EXECUTIVE")
+		phone = flags.String("phone", "", "phone number of the user")
+	case "reset-password":
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown user command %q\n\n%s", command, userUsage)
+		return 2
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *email == "" || flags.NArg() != 0 || (command == "create" && (*name == "" || *role == "")) {
+		fmt.Fprint(os.Stderr, userUsage)
+		return 2
+	}
+	if command == "create" {
+		*role = strings.ToUpper(*role)
+		if address, err := mail.ParseAddress(*email); err != nil || address.Address != *email {
+			fmt.Fprintf(os.Stderr, "Invalid email %q\n", *email)
+			return 2
+		}
+		if !slices.Contains(auth.Roles, *role) {
+			fmt.Fprintf(os.Stderr, "Invalid role %q, expected one of %s\n", *role, strings.Join(auth.Roles, ", "))
+			return 2
+		}
+	}
+
+	if *password == "" {
+		var err error
+		if *password, err = readPassword(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read password: %v\n", err)
+			return 1
+		}
+	}
+	hashedPassword, err := hashPassword(*password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	if err := openDatabase(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	if command == "create" {
+		err = createUser(*email, *name, *role, *phone, hashedPassword)
+	} else {
+		err = resetPassword(*email, hashedPassword)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func createUser(email, name, role, phone, hashedPassword string) error {
+	var existing int64
+	if err := initializers.DB.Model(&models.User{}).Where("email = ?", email).Count(&existing).Error; err != nil {
+		return fmt.Errorf("failed to look up %s: %w", email, err)
+	}
+	if existing > 0 {
+		return fmt.Errorf("a user with email %s already exists", email)
+	}
+
+	user := models.User{
+		ID:       uuid.New(),
+		Name:     name,
+		Email:    email,
+		Phone:    phone,
+		Password: hashedPassword,
+		Role:     role,
+	}
+	if err := initializers.DB.Create(&user).Error; err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	fmt.Printf("Created %s user %s with ID %s\n", user.Role, user.Email, user.ID)
+	return nil
+}
+
+func resetPassword(email, hashedPassword string) error {
+	user, err := findUser(email)
+	if err != nil {
+		return err
+	}
+	if err := initializers.DB.Model(&user).Update("password", hashedPassword).Error; err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	// Sessions started with the old password end with it
+	if err := auth.RevokeUserSessions(initializers.DB, user.ID.String()); err != nil {
+		return fmt.Errorf("password reset, but failed to revoke refresh tokens: %w", err)
+	}
+	fmt.Printf("Reset the password of %s\n", user.Email)
+	return nil
+}
+
+// findUser looks a user up by email or ID.
+func findUser(ref string) (models.User, error) {
+	var user models.User
+	query := initializers.DB.Where("email = ?", ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		query = initializers.DB.Where("id = ?", id)
+	}
+	if err := query.First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, fmt.Errorf("no user with email or ID %q", ref)
+		}
+		return user, fmt.Errorf("failed to look up %s: %w", ref, err)
+	}
+	return user, nil
+}
+
+// hashPassword checks password against the limits of the createUser mutation and hashes it.
+func hashPassword(password string) (string, error) {
+	if length := utf8.RuneCountInString(password); length < 6 || len(password) > 72 {
+		return "", errors.New("password must be 6 to 72 characters")
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashed), nil
+}
+
+// readPassword reads a password from the first line of standard input, so it
+// stays out of the shell history. Input typed at a terminal is echoed.
+func readPassword() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Password: ")
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertAssignmentRule maps a rule, with Users preloaded, to the GraphQL type.
+func ConvertAssignmentRule(rule models.AssignmentRule) *generated.AssignmentRule {
+	result := &generated.AssignmentRule{
+		RuleID:    rule.ID.String(),
+		Name:      rule.Name,
+		Priority:  int32(rule.Priority),
+		Enabled:   rule.Enabled,
+		Strategy:  generated.AssignmentStrategy(rule.Strategy),
+		Countries: rule.Countries,
+		Users:     []*generated.User{},
+	}
+	if result.Countries == nil {
+		result.Countries = []string{}
+	}
+	if rule.CampaignID != nil {
+		campaignID := rule.CampaignID.String()
+		result.CampaignID = &campaignID
+	}
+	if rule.TeamID != nil {
+		teamID := rule.TeamID.String()
+		result.TeamID = &teamID
+	}
+	for _, user := range rule.Users {
+		result.Users = append(result.Users, ConvertUser(user))
+	}
+	return result
+}
+
+// ConvertLeadAssignmentLog maps a log entry, with the users preloaded, to the GraphQL type.
+func ConvertLeadAssignmentLog(entry models.LeadAssignmentLog) *generated.LeadAssignmentLog {
+	result := &generated.LeadAssignmentLog{
+		LogID:      entry.ID.String(),
+		LeadID:     entry.LeadID.String(),
+		AssignedTo: ConvertUser(entry.AssignedToUser),
+		Strategy:   entry.Strategy,
+		Reason:     entry.Reason,
+		CreatedAt:  entry.CreatedAt.Format(time.RFC3339),
+	}
+	if entry.AssignedByUser != nil {
+		result.AssignedBy = ConvertUser(*entry.AssignedByUser)
+	}
+	if entry.RuleID != nil {
+		ruleID := entry.RuleID.String()
+		result.RuleID = &ruleID
+	}
+	if entry.RuleName != "" {
+		result.RuleName = &entry.RuleName
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertAuditEvent maps an audit event to the GraphQL type. Actor is used if preloaded.
+func ConvertAuditEvent(event models.AuditEvent) *generated.AuditEvent {
+	changes := make([]*generated.AuditChange, 0, len(event.Changes))
+	for field, change := range event.Changes {
+		changes = append(changes, &generated.AuditChange{
+			Field: field,
+			From:  auditValue(change.From),
+			To:    auditValue(change.To),
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	result := &generated.AuditEvent{
+		AuditEventID: event.ID.String(),
+		EntityType:   event.EntityType,
+		EntityID:     event.EntityID,
+		Action:       generated.AuditAction(event.Action),
+		Changes:      changes,
+		OccurredAt:   event.CreatedAt.Format(time.RFC3339),
+	}
+	if event.ActorID != nil {
+		result.Actor = &generated.User{UserID: event.ActorID.String()}
+		if event.Actor != nil {
+			result.Actor.Name = event.Actor.Name
+			result.Actor.Email = event.Actor.Email
+		}
+		result.ActorRole = &event.ActorRole
+	}
+	return result
+}
+
+func auditValue(value interface{}) *string {
+	if value == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	text := string(encoded)
+	return &text
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertCampaign maps a campaign to the GraphQL type. Users and leads have their own resolvers.
+func ConvertCampaign(campaign models.Campaign) *generated.Campaign {
+	return &generated.Campaign{
+		CampaignID:       campaign.ID.String(),
+		CampaignName:     campaign.CampaignName,
+		CampaignCountry:  campaign.CampaignCountry,
+		CampaignRegion:   campaign.CampaignRegion,
+		IndustryTargeted: campaign.IndustryTargeted,
+	}
+}
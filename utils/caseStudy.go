@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertCaseStudy maps a case study to the GraphQL type.
+func ConvertCaseStudy(caseStudy models.CaseStudy) *generated.CaseStudy {
+	return &generated.CaseStudy{
+		CaseStudyID:     caseStudy.ID.String(),
+		ProjectName:     caseStudy.ProjectName,
+		ClientName:      caseStudy.ClientName,
+		TechStack:       caseStudy.TechStack,
+		ProjectDuration: caseStudy.ProjectDuration,
+		KeyOutcomes:     caseStudy.KeyOutcomes,
+		IndustryTarget:  caseStudy.IndustryTarget,
+		Tags:            caseStudy.Tags,
+		Document:        caseStudy.Document,
+	}
+}
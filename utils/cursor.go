@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/google/uuid"
+)
+
+// Connections (Relay cursor pagination) walk a table newest first by
+// (created_at, id), so a page is found through the index instead of by
+// skipping rows as Offset does. The (created_at, id) indexes are created in
+// internal/migrate/migrations/0001_baseline.up.sql.
+const (
+	defaultConnectionSize = 20
+	maxConnectionSize     = 100
+)
+
+var errInvalidCursor = apperr.InvalidField("after", "invalid cursor")
+
+// EncodeCursor returns the opaque cursor of a row.
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()))
+}
+
+// DecodeCursor returns the position EncodeCursor encoded.
+func DecodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(decoded), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
+	parsedCreatedAt, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
+	return parsedCreatedAt, parsedID, nil
+}
+
+// ConnectionSize returns the size of a connection page asked for with first.
+func ConnectionSize(first *int32) (int, error) {
+	if first == nil {
+		return defaultConnectionSize, nil
+	}
+	if *first < 0 {
+		return 0, apperr.InvalidField("first", "first must not be negative")
+	}
+	return min(int(*first), maxConnectionSize), nil
+}
+
+// KeysetPage drops the extra row fetched after a page of size rows and returns
+// the rows of the page, their cursors and the page info.
+func KeysetPage[T any](rows []T, size int, after *string, cursorOf func(T) string) ([]T, []string, *generated.PageInfo) {
+	pageInfo := &generated.PageInfo{
+		HasNextPage:     len(rows) > size,
+		HasPreviousPage: after != nil && *after != "",
+	}
+	if len(rows) > size {
+		rows = rows[:size]
+	}
+	cursors := make([]string, len(rows))
+	for i, row := range rows {
+		cursors[i] = cursorOf(row)
+	}
+	if len(cursors) > 0 {
+		pageInfo.StartCursor = &cursors[0]
+		pageInfo.EndCursor = &cursors[len(cursors)-1]
+	}
+	return rows, cursors, pageInfo
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertDeal maps a deal to the GraphQL type.
+func ConvertDeal(deal models.Deal) *generated.Deal {
+	return &generated.Deal{
+		DealID:              deal.ID.String(),
+		LeadID:              deal.LeadID.String(),
+		DealName:            deal.DealName,
+		DealAmount:          deal.DealAmount,
+		DealStartDate:       deal.DealStartDate.Format(time.RFC3339),
+		DealEndDate:         deal.DealEndDate.Format(time.RFC3339),
+		ProjectRequirements: deal.ProjectRequirements,
+		DealStatus:          deal.DealStatus,
+	}
+}
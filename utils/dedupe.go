@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"github.com/Zenithive/it-crm-backend/internal/dedupe"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/google/uuid"
+)
+
+// DedupeLead builds the duplicate check input from a GraphQL lead.
+func DedupeLead(lead *generated.Lead) dedupe.Lead {
+	candidate := dedupe.Lead{
+		FirstName: lead.FirstName,
+		LastName:  lead.LastName,
+		Email:     lead.Email,
+		Phone:     lead.Phone,
+	}
+	candidate.ID, _ = uuid.Parse(lead.LeadID)
+	if lead.Organization != nil {
+		candidate.OrganizationID, _ = uuid.Parse(lead.Organization.OrganizationID)
+	}
+	return candidate
+}
+
+// DedupeOrganization builds the duplicate check input from a GraphQL organization.
+func DedupeOrganization(organization *generated.Organization) dedupe.Organization {
+	candidate := dedupe.Organization{
+		Name:  organization.OrganizationName,
+		Email: organization.OrganizationEmail,
+	}
+	if organization.OrganizationWebsite != nil {
+		candidate.Website = *organization.OrganizationWebsite
+	}
+	candidate.ID, _ = uuid.Parse(organization.OrganizationID)
+	return candidate
+}
+
+// ConvertLeadMatches maps duplicate leads to the GraphQL type.
+func ConvertLeadMatches(matches []dedupe.LeadMatch) []*generated.LeadDuplicate {
+	result := make([]*generated.LeadDuplicate, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, &generated.LeadDuplicate{
+			Lead:    ConvertLead(match.Lead),
+			Reasons: match.Reasons,
+		})
+	}
+	return result
+}
+
+// ConvertOrganizationMatches maps duplicate organizations to the GraphQL type.
+func ConvertOrganizationMatches(matches []dedupe.OrganizationMatch) []*generated.OrganizationDuplicate {
+	result := make([]*generated.OrganizationDuplicate, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, &generated.OrganizationDuplicate{
+			Organization: ConvertOrganization(match.Organization),
+			Reasons:      match.Reasons,
+		})
+	}
+	return result
+}
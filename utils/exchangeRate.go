@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// LoadExchangeRates returns every rate stored in db keyed by currency code.
+// The base currency is always present with a rate of 1.
+func LoadExchangeRates(db *gorm.DB) (map[string]decimal.Decimal, error) {
+	var rates []models.ExchangeRate
+	if err := db.Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]decimal.Decimal, len(rates)+1)
+	for _, rate := range rates {
+		result[strings.ToUpper(rate.Currency)] = rate.RateToBase
+	}
+	result[models.BaseCurrency()] = decimal.NewFromInt(1)
+	return result, nil
+}
+
+// ConvertToBase converts an amount to the base currency.
+// It returns false when no rate is known for the currency.
+func ConvertToBase(amount decimal.Decimal, currency string, rates map[string]decimal.Decimal) (decimal.Decimal, bool) {
+	rate, ok := rates[strings.ToUpper(currency)]
+	if !ok {
+		return decimal.Zero, false
+	}
+	return amount.Mul(rate), true
+}
+
+// ConvertExchangeRate maps an exchange rate row to the GraphQL type.
+func ConvertExchangeRate(rate models.ExchangeRate) *generated.ExchangeRate {
+	return &generated.ExchangeRate{
+		Currency:     rate.Currency,
+		BaseCurrency: models.BaseCurrency(),
+		RateToBase:   rate.RateToBase.String(),
+		UpdatedAt:    rate.UpdatedAt.Format(time.RFC3339),
+	}
+}
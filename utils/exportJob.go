@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertExportJob maps an export job to the GraphQL type. CreatedByUser is used if preloaded.
+func ConvertExportJob(job models.ExportJob) *generated.ExportJob {
+	columns := job.Columns
+	if columns == nil {
+		columns = []string{}
+	}
+	result := &generated.ExportJob{
+		JobID:    job.ID.String(),
+		Query:    job.Query,
+		Format:   generated.ExportFormat(job.Format),
+		Columns:  columns,
+		Status:   generated.ExportJobStatus(job.Status),
+		RowCount: int32(job.RowCount),
+		CreatedBy: &generated.User{
+			UserID: job.CreatedBy.String(),
+			Name:   job.CreatedByUser.Name,
+			Email:  job.CreatedByUser.Email,
+		},
+		CreatedAt: job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.Error != "" {
+		result.Error = &job.Error
+	}
+	if job.DocumentID != nil {
+		documentID := job.DocumentID.String()
+		downloadURL := "/download?id=" + documentID
+		result.DocumentID = &documentID
+		result.DownloadURL = &downloadURL
+	}
+	if job.StartedAt != nil {
+		startedAt := job.StartedAt.Format(time.RFC3339)
+		result.StartedAt = &startedAt
+	}
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.Format(time.RFC3339)
+		result.FinishedAt = &finishedAt
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/importer"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertImportJob maps an import job to the GraphQL type. CreatedByUser is used if preloaded.
+func ConvertImportJob(job models.ImportJob) *generated.ImportJob {
+	mapping := make([]*generated.ImportColumnMapping, 0, len(job.Mapping))
+	for column, field := range job.Mapping {
+		mapping = append(mapping, &generated.ImportColumnMapping{Column: column, Field: field})
+	}
+	sort.Slice(mapping, func(i, j int) bool { return mapping[i].Column < mapping[j].Column })
+
+	result := &generated.ImportJob{
+		JobID:       job.ID.String(),
+		Entity:      generated.ImportEntity(job.Entity),
+		FileName:    job.FileName,
+		DryRun:      job.DryRun,
+		Status:      generated.ImportJobStatus(job.Status),
+		Mapping:     mapping,
+		TotalRows:   int32(job.TotalRows),
+		ValidRows:   int32(job.ValidRows),
+		CreatedRows: int32(job.CreatedRows),
+		FailedRows:  int32(job.FailedRows),
+		CreatedBy: &generated.User{
+			UserID: job.CreatedBy.String(),
+			Name:   job.CreatedByUser.Name,
+			Email:  job.CreatedByUser.Email,
+		},
+		CreatedAt: job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.Error != "" {
+		result.Error = &job.Error
+	}
+	if job.StartedAt != nil {
+		startedAt := job.StartedAt.Format(time.RFC3339)
+		result.StartedAt = &startedAt
+	}
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.Format(time.RFC3339)
+		result.FinishedAt = &finishedAt
+	}
+	return result
+}
+
+// ConvertImportRows maps import row results to the GraphQL type.
+func ConvertImportRows(rows []models.ImportJobRow) []*generated.ImportRow {
+	result := make([]*generated.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		values := make([]*generated.ImportValue, 0, len(row.Values))
+		for field, value := range row.Values {
+			values = append(values, &generated.ImportValue{Field: field, Value: value})
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i].Field < values[j].Field })
+
+		converted := &generated.ImportRow{
+			Row:      int32(row.Row),
+			Status:   generated.ImportRowStatus(row.Status),
+			Values:   values,
+			Errors:   row.Errors,
+			Warnings: row.Warnings,
+		}
+		if converted.Errors == nil {
+			converted.Errors = []string{}
+		}
+		if converted.Warnings == nil {
+			converted.Warnings = []string{}
+		}
+		if row.RecordID != nil {
+			recordID := row.RecordID.String()
+			converted.RecordID = &recordID
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+// ConvertImportFields maps the importable fields of an entity to the GraphQL type.
+func ConvertImportFields(fields []importer.Field) []*generated.ImportField {
+	result := make([]*generated.ImportField, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, &generated.ImportField{
+			Name:        field.Name,
+			Required:    field.Required,
+			Description: field.Description,
+		})
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// ConvertLead maps a lead to the GraphQL type.
+// Campaign and Activities are used if preloaded, as are Creator, Assignee and
+// Organization, which the Lead field resolvers otherwise load by id.
+func ConvertLead(lead models.Lead) *generated.Lead {
+	activities := []*generated.Activity{}
+	for _, activity := range lead.Activities {
+		activities = append(activities, ConvertActivity(activity))
+	}
+
+	// Map Organization
+	var organization *generated.Organization
+	if lead.OrganizationID != uuid.Nil {
+		organization = &generated.Organization{
+			OrganizationID:    lead.OrganizationID.String(),
+			OrganizationName:  lead.Organization.OrganizationName,
+			OrganizationEmail: lead.Organization.OrganizationEmail,
+		}
+	}
+
+	// Map Campaign
+	var campaign *generated.Campaign
+	if lead.CampaignID != uuid.Nil {
+		campaign = &generated.Campaign{
+			CampaignID:       lead.CampaignID.String(),
+			CampaignName:     lead.Campaign.CampaignName,
+			CampaignCountry:  lead.Campaign.CampaignCountry,
+			CampaignRegion:   lead.Campaign.CampaignRegion,
+			IndustryTargeted: lead.Campaign.IndustryTargeted,
+		}
+	}
+
+	return &generated.Lead{
+		LeadID:     lead.ID.String(),
+		FirstName:  lead.FirstName,
+		LastName:   lead.LastName,
+		LinkedIn:   lead.LinkedIn,
+		Email:      lead.Email,
+		Country:    lead.Country,
+		Phone:      lead.Phone,
+		LeadSource: lead.LeadSource,
+		LeadCreatedBy: &generated.User{
+			UserID: lead.LeadCreatedBy.String(),
+			Name:   lead.Creator.Name,
+			Email:  lead.Creator.Email,
+		},
+		LeadAssignedTo: &generated.User{
+			UserID: lead.LeadAssignedTo.String(),
+			Name:   lead.Assignee.Name,
+			Email:  lead.Assignee.Email,
+		},
+		LeadStage:          string(lead.LeadStage),
+		LeadPriority:       lead.LeadPriority,
+		Score:              int32(lead.Score),
+		LeadNotes:          lead.LeadNotes,
+		InitialContactDate: lead.InitialContactDate.Format(dateLayout),
+		CreatedAt:          lead.CreatedAt.Format(time.RFC3339),
+		Activities:         activities,
+		Organization:       organization,
+		Campaign:           campaign,
+	}
+}
+
+// ConvertActivity maps an activity to the GraphQL type.
+func ConvertActivity(activity models.Activity) *generated.Activity {
+	return &generated.Activity{
+		ActivityID:           activity.ID.String(),
+		LeadID:               activity.LeadID.String(),
+		ActivityType:         activity.ActivityType,
+		DateTime:             activity.DateTime.Format(dateLayout),
+		CommunicationChannel: activity.CommunicationChannel,
+		ContentNotes:         activity.ContentNotes,
+		ParticipantDetails:   activity.ParticipantDetails,
+		FollowUpActions:      activity.FollowUpActions,
+	}
+}
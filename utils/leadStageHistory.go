@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/apperr"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/loaders"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+// ParseDateRange converts a DateRangeInput into time bounds.
+// A nil bound means the range is open on that side. The end date is inclusive,
+// so the returned upper bound is the start of the following day.
+func ParseDateRange(dateRange *generated.DateRangeInput) (*time.Time, *time.Time, error) {
+	if dateRange == nil {
+		return nil, nil, nil
+	}
+
+	var from, to *time.Time
+	if dateRange.StartDate != nil && *dateRange.StartDate != "" {
+		parsed, err := time.Parse(dateLayout, *dateRange.StartDate)
+		if err != nil {
+			return nil, nil, apperr.InvalidField("dateRange.startDate", "invalid startDate format, expected YYYY-MM-DD: %v", err)
+		}
+		from = &parsed
+	}
+	if dateRange.EndDate != nil && *dateRange.EndDate != "" {
+		parsed, err := time.Parse(dateLayout, *dateRange.EndDate)
+		if err != nil {
+			return nil, nil, apperr.InvalidField("dateRange.endDate", "invalid endDate format, expected YYYY-MM-DD: %v", err)
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		to = &parsed
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, apperr.Invalid("startDate must not be after endDate")
+	}
+	return from, to, nil
+}
+
+// ConvertLeadStageHistory maps the stage history of a lead created at createdAt
+// to the GraphQL type. history must be the complete history of the lead ordered
+// by ChangedAt ascending, because the time spent in each stage is measured from
+// the previous change (or from the lead's creation for the first entry).
+func ConvertLeadStageHistory(createdAt time.Time, history []models.LeadStageHistory) []*generated.LeadStageHistory {
+	result := make([]*generated.LeadStageHistory, 0, len(history))
+	enteredAt := createdAt
+	for _, entry := range history {
+		duration := entry.ChangedAt.Sub(enteredAt)
+		if duration < 0 {
+			duration = 0
+		}
+
+		var changedBy *generated.User
+		if entry.ChangedByUser != nil {
+			changedBy = &generated.User{
+				UserID: entry.ChangedByUser.ID.String(),
+				Name:   entry.ChangedByUser.Name,
+				Email:  entry.ChangedByUser.Email,
+				Role:   entry.ChangedByUser.Role,
+			}
+		}
+
+		result = append(result, &generated.LeadStageHistory{
+			StageHistoryID:  entry.ID.String(),
+			LeadID:          entry.LeadID.String(),
+			OldStage:        string(entry.OldStage),
+			NewStage:        string(entry.NewStage),
+			ChangedBy:       changedBy,
+			ChangedAt:       entry.ChangedAt.Format(time.RFC3339),
+			DurationInStage: int32(duration.Seconds()),
+		})
+		enteredAt = entry.ChangedAt
+	}
+	return result
+}
+
+// CurrentStageDuration returns how long a lead created at createdAt has been in
+// its current stage at now: since the last change of its complete, ascending
+// history, or since its creation if it never changed stage.
+func CurrentStageDuration(createdAt time.Time, history []models.LeadStageHistory, now time.Time) time.Duration {
+	enteredAt := createdAt
+	if len(history) > 0 {
+		enteredAt = history[len(history)-1].ChangedAt
+	}
+	return max(now.Sub(enteredAt), 0)
+}
+
+// LoadStageHistory returns the complete stage history of lead, oldest change
+// first, and when the lead was created, through the request's loaders l.
+func LoadStageHistory(ctx context.Context, l *loaders.Loaders, lead *generated.Lead) ([]models.LeadStageHistory, time.Time, error) {
+	id, err := uuid.Parse(lead.LeadID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	createdAt, err := time.Parse(time.RFC3339, lead.CreatedAt)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	history, err := l.StageHistory.Load(ctx, id)
+	return history, createdAt, err
+}
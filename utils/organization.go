@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertOrganization maps an organization to the GraphQL type, without its leads.
+func ConvertOrganization(organization models.Organization) *generated.Organization {
+	return &generated.Organization{
+		OrganizationID:      organization.ID.String(),
+		OrganizationName:    organization.OrganizationName,
+		OrganizationEmail:   organization.OrganizationEmail,
+		OrganizationWebsite: &organization.OrganizationWebsite,
+		City:                organization.City,
+		Country:             organization.Country,
+		NoOfEmployees:       organization.NoOfEmployees,
+		AnnualRevenue:       organization.AnnualRevenue,
+	}
+}
@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// PipelineStages lists the lead stages in funnel order.
+var PipelineStages = []models.LeadStage{
+	models.LeadStageNew,
+	models.LeadStageInProgress,
+	models.LeadStageFollowUp,
+	models.LeadStageClosedWon,
+	models.LeadStageClosedLost,
+}
+
+// pipelineLeadQuery returns a fresh query over the leads in db the user in ctx may
+// read, with the analytics filter applied. It is called once per aggregate so the
+// statements never share state.
+func pipelineLeadQuery(ctx context.Context, db *gorm.DB, filter *generated.PipelineAnalyticsFilter) (*gorm.DB, error) {
+	query := db.Model(&models.Lead{}).Scopes(auth.LeadScope(ctx, auth.LeadActionRead))
+	if filter == nil {
+		return query, nil
+	}
+
+	if filter.CampaignID != nil && *filter.CampaignID != "" {
+		query = query.Where("leads.campaign_id = ?", *filter.CampaignID)
+	}
+	if filter.AssignedTo != nil && *filter.AssignedTo != "" {
+		query = query.Where("leads.lead_assigned_to = ?", *filter.AssignedTo)
+	}
+	if filter.TeamID != nil && *filter.TeamID != "" {
+		query = query.Where("leads.lead_assigned_to IN (?)", TeamMembersSubquery(db, *filter.TeamID))
+	}
+	if filter.OrganizationCountry != nil && *filter.OrganizationCountry != "" {
+		query = query.
+			Joins("JOIN organizations ON organizations.id = leads.organization_id").
+			Where("LOWER(organizations.country) = LOWER(?)", *filter.OrganizationCountry)
+	}
+
+	from, to, err := ParseDateRange(filter.DateRange)
+	if err != nil {
+		return nil, err
+	}
+	if from != nil {
+		query = query.Where("leads.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("leads.created_at < ?", *to)
+	}
+	return query, nil
+}
+
+// BuildPipelineAnalytics aggregates the sales funnel for the leads in db matching
+// filter that the user in ctx may read.
+func BuildPipelineAnalytics(ctx context.Context, db *gorm.DB, filter *generated.PipelineAnalyticsFilter) (*generated.PipelineAnalytics, error) {
+	db = db.WithContext(ctx)
+	// --- Lead and deal counts per stage ---
+	var stageRows []struct {
+		LeadStage models.LeadStage
+		LeadCount int64
+		DealCount int64
+	}
+	query, err := pipelineLeadQuery(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.
+		Select("leads.lead_stage, COUNT(DISTINCT leads.id) AS lead_count, COUNT(deals.id) AS deal_count").
+		Joins("LEFT JOIN deals ON deals.lead_id = leads.id AND deals.deleted_at IS NULL").
+		Group("leads.lead_stage").
+		Scan(&stageRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count leads per stage: %w", err)
+	}
+
+	counts := make(map[models.LeadStage]*generated.PipelineStageCount)
+	var totalLeads int64
+	for _, row := range stageRows {
+		counts[row.LeadStage] = &generated.PipelineStageCount{
+			Stage:     string(row.LeadStage),
+			LeadCount: int32(row.LeadCount),
+			DealCount: int32(row.DealCount),
+		}
+		totalLeads += row.LeadCount
+	}
+
+	// Always report every known stage, in funnel order, followed by any unexpected values
+	var stageCounts []*generated.PipelineStageCount
+	for _, stage := range PipelineStages {
+		if count, ok := counts[stage]; ok {
+			stageCounts = append(stageCounts, count)
+			delete(counts, stage)
+			continue
+		}
+		stageCounts = append(stageCounts, &generated.PipelineStageCount{Stage: string(stage)})
+	}
+	unexpected := make([]string, 0, len(counts))
+	for stage := range counts {
+		unexpected = append(unexpected, string(stage))
+	}
+	sort.Strings(unexpected)
+	for _, stage := range unexpected {
+		stageCounts = append(stageCounts, counts[models.LeadStage(stage)])
+	}
+
+	// --- Deal value per stage, converted to the base currency ---
+	valueQuery, err := pipelineLeadQuery(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+	var valueRows []struct {
+		LeadStage models.LeadStage
+		Currency  string
+		Amount    decimal.Decimal
+	}
+	if err := valueQuery.
+		Select("leads.lead_stage, deals.deal_amount_currency AS currency, SUM(deals.deal_amount_amount) AS amount").
+		Joins("JOIN deals ON deals.lead_id = leads.id AND deals.deleted_at IS NULL").
+		Group("leads.lead_stage, deals.deal_amount_currency").
+		Scan(&valueRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum deal values per stage: %w", err)
+	}
+	rates, err := LoadExchangeRates(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+
+	baseCurrency := models.BaseCurrency()
+	stageValues := make(map[string]decimal.Decimal)
+	totalValue := decimal.Zero
+	missing := make(map[string]bool)
+	for _, row := range valueRows {
+		converted, ok := ConvertToBase(row.Amount, row.Currency, rates)
+		if !ok {
+			missing[row.Currency] = true
+			continue
+		}
+		stageValues[string(row.LeadStage)] = stageValues[string(row.LeadStage)].Add(converted)
+		totalValue = totalValue.Add(converted)
+	}
+	for _, count := range stageCounts {
+		count.DealValue = models.Money{Amount: stageValues[count.Stage].Round(2), Currency: baseCurrency}
+	}
+	missingRates := make([]string, 0, len(missing))
+	for currency := range missing {
+		missingRates = append(missingRates, currency)
+	}
+	sort.Strings(missingRates)
+
+	// --- Stage-to-stage conversions from the stage history ---
+	leadIDs, err := pipelineLeadQuery(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+	var transitionRows []struct {
+		OldStage models.LeadStage
+		NewStage models.LeadStage
+		Count    int64
+	}
+	if err := db.Model(&models.LeadStageHistory{}).
+		Select("old_stage, new_stage, COUNT(*) AS count").
+		Where("lead_id IN (?)", leadIDs.Select("leads.id")).
+		Group("old_stage, new_stage").
+		Order("old_stage, new_stage").
+		Scan(&transitionRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute stage conversions: %w", err)
+	}
+
+	leftStage := make(map[models.LeadStage]int64)
+	for _, row := range transitionRows {
+		leftStage[row.OldStage] += row.Count
+	}
+	var conversions []*generated.StageConversion
+	for _, row := range transitionRows {
+		rate := 0.0
+		if leftStage[row.OldStage] > 0 {
+			rate = float64(row.Count) / float64(leftStage[row.OldStage])
+		}
+		conversions = append(conversions, &generated.StageConversion{
+			FromStage:      string(row.OldStage),
+			ToStage:        string(row.NewStage),
+			Count:          int32(row.Count),
+			ConversionRate: rate,
+		})
+	}
+
+	// --- Win/loss ---
+	var won, lost int32
+	for _, count := range stageCounts {
+		switch models.LeadStage(count.Stage) {
+		case models.LeadStageClosedWon:
+			won = count.LeadCount
+		case models.LeadStageClosedLost:
+			lost = count.LeadCount
+		}
+	}
+	var winLossRatio *float64
+	if lost > 0 {
+		ratio := float64(won) / float64(lost)
+		winLossRatio = &ratio
+	}
+	winRate := 0.0
+	if won+lost > 0 {
+		winRate = float64(won) / float64(won+lost)
+	}
+
+	// --- Average time to close, from lead creation to its first move into CLOSED_WON ---
+	closedQuery, err := pipelineLeadQuery(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+	var avgSeconds sql.NullFloat64
+	if err := closedQuery.
+		Select("AVG(EXTRACT(EPOCH FROM (won.changed_at - leads.created_at)))").
+		Joins(`JOIN (SELECT lead_id, MIN(changed_at) AS changed_at FROM lead_stage_histories
+			WHERE new_stage = ? AND deleted_at IS NULL GROUP BY lead_id) won ON won.lead_id = leads.id`, models.LeadStageClosedWon).
+		Where("leads.lead_stage = ?", models.LeadStageClosedWon).
+		Scan(&avgSeconds).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute average time to close: %w", err)
+	}
+	var avgDays *float64
+	if avgSeconds.Valid {
+		days := avgSeconds.Float64 / (24 * 60 * 60)
+		avgDays = &days
+	}
+
+	return &generated.PipelineAnalytics{
+		TotalLeads:             int32(totalLeads),
+		StageCounts:            stageCounts,
+		ConversionRates:        conversions,
+		WonCount:               won,
+		LostCount:              lost,
+		WinLossRatio:           winLossRatio,
+		WinRate:                winRate,
+		AverageTimeToCloseDays: avgDays,
+		TotalDealValue:         models.Money{Amount: totalValue.Round(2), Currency: baseCurrency},
+		MissingExchangeRates:   missingRates,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertResourceProfile maps a resource profile to the GraphQL type.
+// ResourceSkills.Skill, PastProjects and Vendor are used if preloaded.
+func ConvertResourceProfile(profile models.ResourceProfile) *generated.ResourceProfile {
+	var pastProjects []*generated.PastProject
+	for _, project := range profile.PastProjects {
+		pastProjects = append(pastProjects, &generated.PastProject{
+			PastProjectID:     project.ID.String(),
+			CreatedAt:         project.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:         project.UpdatedAt.Format(time.RFC3339),
+			ResourceProfileID: project.ResourceProfileID.String(),
+			ProjectName:       project.ProjectName,
+			Description:       &project.Description,
+		})
+	}
+
+	var vendor *generated.Vendor
+	if profile.Vendor != nil {
+		vendor = &generated.Vendor{
+			VendorID:        profile.Vendor.ID.String(),
+			CreatedAt:       profile.Vendor.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:       profile.Vendor.UpdatedAt.Format(time.RFC3339),
+			CompanyName:     profile.Vendor.CompanyName,
+			Status:          generated.VendorStatus(profile.Vendor.Status),
+			PaymentTerms:    generated.PaymentTerms(profile.Vendor.PaymentTerms),
+			Address:         profile.Vendor.Address,
+			GstOrVatDetails: profile.Vendor.GstOrVatDetails,
+			Notes:           profile.Vendor.Notes,
+		}
+	}
+
+	return &generated.ResourceProfile{
+		ResourceProfileID:  profile.ID.String(),
+		Type:               generated.ResourceType(profile.Type),
+		FirstName:          profile.FirstName,
+		LastName:           profile.LastName,
+		TotalExperience:    profile.TotalExperience,
+		ContactInformation: string(profile.ContactInformation),
+		GoogleDriveLink:    profile.GoogleDriveLink,
+		Status:             generated.ResourceStatus(profile.Status),
+		VendorID:           profile.VendorID.String(),
+		Vendor:             vendor,
+		ResourceSkills:     ConvertResourceSkills(profile.ResourceSkills),
+		PastProjects:       pastProjects,
+	}
+}
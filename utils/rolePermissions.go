@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"github.com/Zenithive/it-crm-backend/auth"
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+)
+
+// LoadRolePermissions returns the current permissions of every role, or of one role if given.
+func LoadRolePermissions(roles ...generated.UserRole) ([]*generated.RolePermissions, error) {
+	mapping, err := auth.GetRolePermissions()
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		for _, role := range auth.Roles {
+			roles = append(roles, generated.UserRole(role))
+		}
+	}
+	result := make([]*generated.RolePermissions, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, &generated.RolePermissions{
+			Role:        role,
+			Permissions: mapping[string(role)],
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+)
+
+// ScoringRuleFromInput builds a scoring rule from the GraphQL input.
+func ScoringRuleFromInput(input generated.ScoringRuleInput) models.ScoringRule {
+	rule := models.ScoringRule{
+		ID:        uuid.New(),
+		Attribute: string(input.Attribute),
+		Operator:  string(input.Operator),
+		Value:     input.Value,
+		Weight:    int(input.Weight),
+	}
+	if input.Description != nil {
+		rule.Description = *input.Description
+	}
+	return rule
+}
+
+// ConvertScoringRules maps scoring rules to the GraphQL type.
+func ConvertScoringRules(rules []models.ScoringRule) []*generated.ScoringRule {
+	result := make([]*generated.ScoringRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, &generated.ScoringRule{
+			RuleID:      rule.ID.String(),
+			Attribute:   generated.ScoringAttribute(rule.Attribute),
+			Operator:    generated.ScoringOperator(rule.Operator),
+			Value:       rule.Value,
+			Weight:      int32(rule.Weight),
+			Description: rule.Description,
+		})
+	}
+	return result
+}
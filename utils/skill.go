@@ -3,10 +3,10 @@ package utils
 import (
 	"fmt"
 
-	initializers "github.com/Zenithive/it-crm-backend/Initializers"
 	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
 	"github.com/Zenithive/it-crm-backend/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 func ConvertSkills(modelSkills []models.Skill) []*generated.Skill {
@@ -51,19 +51,24 @@ func ConvertSkills(modelSkills []models.Skill) []*generated.Skill {
 // 	}
 // 	return skills, nil
 
-func FetchSkills(skillIDs []uint) ([]models.Skill, error) {
+func FetchSkills(db *gorm.DB, skillIDs []uint) ([]models.Skill, error) {
 	var skills []models.Skill
-	if err := initializers.DB.Find(&skills, "id IN ?", skillIDs).Error; err != nil {
+	if err := db.Find(&skills, "id IN ?", skillIDs).Error; err != nil {
 		return nil, fmt.Errorf("error retrieving skills: %w", err)
 	}
 	return skills, nil
 }
 func ConvertSkill(s models.Skill) *generated.Skill {
-	return &generated.Skill{
-		SkillID:     s.ID.String(),
-		Name:        s.Name,
-		Description: *s.Description,
+	skill := &generated.Skill{
+		SkillID:   s.ID.String(),
+		Name:      s.Name,
+		Skilltype: generated.SkillType(s.SkillType),
 	}
+	// Description is optional when creating a skill
+	if s.Description != nil {
+		skill.Description = *s.Description
+	}
+	return skill
 }
 
 // ConvertResourceSkills converts a slice of models.ResourceSkill into a slice of generated.ResourceSkill.
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertTask maps a task to the GraphQL type. User is used if preloaded.
+func ConvertTask(task models.Task) *generated.Task {
+	result := &generated.Task{
+		TaskID:      task.ID.String(),
+		User:        &generated.User{UserID: task.UserID.String(), Name: task.User.Name, Email: task.User.Email},
+		Title:       task.Title,
+		Description: &task.Description,
+		Status:      generated.TaskStatus(task.Status),
+		Priority:    generated.TaskPriority(task.Priority),
+	}
+	if task.DueDate != nil {
+		result.DueDate = task.DueDate.Format(time.RFC3339)
+	}
+	return result
+}
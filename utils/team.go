@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/loaders"
+	"github.com/Zenithive/it-crm-backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConvertUser maps a user to the GraphQL type without nested fields.
+func ConvertUser(user models.User) *generated.User {
+	return &generated.User{
+		UserID:   user.ID.String(),
+		GoogleID: &user.GoogleId,
+		Name:     user.Name,
+		Email:    user.Email,
+		Phone:    user.Phone,
+		Role:     user.Role,
+	}
+}
+
+// LoadUser fills in a user known only by its id through the request's loaders l.
+// A user that no longer exists is returned as it is.
+func LoadUser(ctx context.Context, l *loaders.Loaders, user *generated.User) (*generated.User, error) {
+	if user == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(user.UserID)
+	if err != nil {
+		return user, nil
+	}
+	loaded, err := l.Users.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if loaded.ID == uuid.Nil {
+		return user, nil
+	}
+	return ConvertUser(loaded), nil
+}
+
+// ConvertTeam maps a team to the GraphQL type. Manager and members are resolved separately.
+func ConvertTeam(team models.Team) *generated.Team {
+	return &generated.Team{
+		TeamID:      team.ID.String(),
+		Name:        team.Name,
+		Description: team.Description,
+	}
+}
+
+// TeamMembersSubquery selects the ids of the users in teamID, for "column IN (?)"
+// filters of a query on db.
+func TeamMembersSubquery(db *gorm.DB, teamID string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.User{}).Select("id").Where("team_id = ?", teamID)
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/internal/trash"
+)
+
+// ConvertTrashItem maps a deleted row to the GraphQL type.
+func ConvertTrashItem(entityType generated.TrashEntityType, item trash.Item) *generated.TrashItem {
+	return &generated.TrashItem{
+		EntityType: entityType,
+		ID:         item.ID.String(),
+		Label:      item.Label,
+		DeletedAt:  item.DeletedAt.Format(time.RFC3339),
+	}
+}
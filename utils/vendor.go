@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertVendor maps a vendor to the GraphQL type. ContactList, Skills and
+// PerformanceRatings are used if preloaded; resources have their own resolver.
+func ConvertVendor(vendor models.Vendor) *generated.Vendor {
+	var contacts []*generated.Contact
+	for _, contact := range vendor.ContactList {
+		contacts = append(contacts, &generated.Contact{
+			ContactID:   contact.ID.String(),
+			CreatedAt:   contact.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   contact.UpdatedAt.Format(time.RFC3339),
+			VendorID:    contact.VendorID.String(),
+			Name:        contact.Name,
+			Email:       &contact.Email,
+			PhoneNumber: &contact.PhoneNumber,
+		})
+	}
+
+	var skills []*generated.Skill
+	for _, skill := range vendor.Skills {
+		description := ""
+		if skill.Description != nil {
+			description = *skill.Description
+		}
+		skills = append(skills, &generated.Skill{
+			SkillID:     skill.ID.String(),
+			Name:        skill.Name,
+			Description: description,
+			Skilltype:   generated.SkillType(skill.SkillType),
+		})
+	}
+
+	var performanceRatings []*generated.PerformanceRating
+	for _, rating := range vendor.PerformanceRatings {
+		performanceRatings = append(performanceRatings, &generated.PerformanceRating{
+			PerformanceRatingsID: rating.ID.String(),
+			CreatedAt:            rating.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:            rating.UpdatedAt.Format(time.RFC3339),
+			VendorID:             rating.VendorID.String(),
+			Rating:               int32(rating.Rating),
+			Review:               rating.Review,
+		})
+	}
+
+	return &generated.Vendor{
+		VendorID:           vendor.ID.String(),
+		CreatedAt:          vendor.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          vendor.UpdatedAt.Format(time.RFC3339),
+		CompanyName:        vendor.CompanyName,
+		Status:             generated.VendorStatus(vendor.Status),
+		PaymentTerms:       generated.PaymentTerms(vendor.PaymentTerms),
+		Address:            vendor.Address,
+		GstOrVatDetails:    vendor.GstOrVatDetails,
+		Notes:              vendor.Notes,
+		ContactList:        contacts,
+		Skills:             skills,
+		PerformanceRatings: performanceRatings,
+	}
+}
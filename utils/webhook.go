@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Zenithive/it-crm-backend/internal/graphql/generated"
+	"github.com/Zenithive/it-crm-backend/models"
+)
+
+// ConvertWebhook maps a webhook to the GraphQL type, without its secret.
+// CreatedByUser is used if preloaded.
+func ConvertWebhook(webhook models.Webhook) *generated.Webhook {
+	result := &generated.Webhook{
+		WebhookID: webhook.ID.String(),
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Active:    webhook.Active,
+		CreatedBy: &generated.User{
+			UserID: webhook.CreatedBy.String(),
+			Name:   webhook.CreatedByUser.Name,
+			Email:  webhook.CreatedByUser.Email,
+		},
+		CreatedAt: webhook.CreatedAt.Format(time.RFC3339),
+	}
+	if result.Events == nil {
+		result.Events = []string{}
+	}
+	if webhook.Description != "" {
+		result.Description = &webhook.Description
+	}
+	return result
+}
+
+// ConvertWebhookDelivery maps a delivery log entry to the GraphQL type.
+func ConvertWebhookDelivery(delivery models.WebhookDelivery) *generated.WebhookDelivery {
+	result := &generated.WebhookDelivery{
+		DeliveryID: delivery.ID.String(),
+		WebhookID:  delivery.WebhookID.String(),
+		Event:      delivery.Event,
+		Payload:    delivery.Payload,
+		Status:     generated.WebhookDeliveryStatus(delivery.Status),
+		Attempts:   int32(delivery.Attempts),
+		CreatedAt:  delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt.Format(time.RFC3339)
+		result.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.LastAttemptAt != nil {
+		lastAttemptAt := delivery.LastAttemptAt.Format(time.RFC3339)
+		result.LastAttemptAt = &lastAttemptAt
+	}
+	if delivery.ResponseStatus != 0 {
+		responseStatus := int32(delivery.ResponseStatus)
+		result.ResponseStatus = &responseStatus
+		result.ResponseBody = &delivery.ResponseBody
+	}
+	if delivery.Error != "" {
+		result.Error = &delivery.Error
+	}
+	return result
+}